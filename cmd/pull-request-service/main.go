@@ -3,20 +3,37 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"syscall"
 	"time"
+	// Часовые пояса рабочих часов: в образе debian-slim нет tzdata
+	_ "time/tzdata"
 
+	"pull-request-service/internal/email"
 	httpapi "pull-request-service/internal/http"
+	"pull-request-service/internal/notify"
+	"pull-request-service/internal/outbound"
+	"pull-request-service/internal/outbox"
 	"pull-request-service/internal/repository"
+	"pull-request-service/internal/scheduler"
 	"pull-request-service/internal/service"
+	"pull-request-service/internal/webhook"
 )
 
 func main() {
+	// Подкоманда импорта/экспорта состава команд
+	if len(os.Args) > 1 && os.Args[1] == "roster" {
+		os.Exit(runRoster(os.Args[2:]))
+	}
+
 	// Контекст для корректного завершения
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -41,24 +58,156 @@ func main() {
 	teamRepo := repository.NewTeamRepo(db)
 	userRepo := repository.NewUserRepo(db)
 	prRepo := repository.NewPRRepo(db)
+	webhookRepo := repository.NewWebhookRepo(db)
+	outboxRepo := repository.NewOutboxRepo(db)
+	notificationRepo := repository.NewNotificationRepo(db)
+	mailRepo := repository.NewMailRepo(db)
+	schedulerRepo := repository.NewSchedulerRepo(db)
+	cycleTimeRepo := repository.NewCycleTimeRepo(db)
 
 	// 2. Инициализация Менеджера Транзакций
 	txManager := repository.NewTransactionManager(db)
 
+	// Период разгона для вернувшихся ревьюверов (по умолчанию выключен)
+	rampUp, err := rampUpPolicyFromEnv()
+	if err != nil {
+		log.Fatalf("invalid ramp-up config: %v", err)
+	}
+
 	// 3. Инициализация сервисов
 	teamService := service.NewTeamService(teamRepo, userRepo, prRepo, txManager)
-	userService := service.NewUserService(userRepo)
+	teamService.SetRampUpPolicy(rampUp)
+	teamService.SetEventRecorder(outboxRepo)
+	userService := service.NewUserService(userRepo, prRepo, txManager)
+	userService.SetRampUpPolicy(rampUp)
+	userService.SetEventRecorder(outboxRepo)
 
 	// Внедряем txManager в PRService
 	prService := service.NewPRService(prRepo, userRepo, txManager)
+	prService.SetRampUpPolicy(rampUp)
+	prService.SetEventRecorder(outboxRepo)
 
 	// 4. Инициализация HTTP-обработчика
 	handler := httpapi.NewHandler(teamService, userService, prService, logger)
+	handler.EnableWebhookSubscriptions(service.NewWebhookService(webhookRepo))
+	handler.EnableJobRuns(service.NewJobService(schedulerRepo))
+
+	// Поток событий ревью по SSE читает журнал опубликованных событий outbox
+	reviewStream := service.NewReviewStreamService(outboxRepo, userRepo)
+	go reviewStream.Run(ctx, logger)
+	handler.EnableReviewStream(reviewStream)
+
+	// SCIM включается только с токеном: без него эндпоинты провижининга не регистрируются
+	if token := os.Getenv("SCIM_TOKEN"); token != "" {
+		provisioningService := service.NewProvisioningService(teamRepo, userRepo, prRepo, txManager)
+		provisioningService.SetRampUpPolicy(rampUp)
+		provisioningService.SetEventRecorder(outboxRepo)
+		handler.EnableSCIM(provisioningService, token)
+	}
+
+	// Вебхуки хостинга принимаются только при заданном секрете
+	webhooks := []struct {
+		secretEnv, loginsEnv string
+		provider             func(secret string) webhook.Provider
+	}{
+		{"GITHUB_WEBHOOK_SECRET", "GITHUB_LOGIN_MAP", func(s string) webhook.Provider { return webhook.GitHub{Secret: s} }},
+		{"GITLAB_WEBHOOK_TOKEN", "GITLAB_LOGIN_MAP", func(s string) webhook.Provider { return webhook.GitLab{Token: s} }},
+	}
+	for _, wh := range webhooks {
+		secret := os.Getenv(wh.secretEnv)
+		if secret == "" {
+			continue
+		}
+		logins := webhook.LoginMap{}
+		if path := os.Getenv(wh.loginsEnv); path != "" {
+			if logins, err = webhook.LoadLoginMap(path); err != nil {
+				log.Fatalf("invalid %s: %v", wh.loginsEnv, err)
+			}
+		}
+		handler.EnableWebhook(wh.provider(secret), logins)
+	}
+
+	// Шаблоны уведомлений в чат можно переопределить (синтаксис text/template)
+	chatTemplates, err := notify.ParseTemplates(os.Getenv("CHAT_CREATED_TEMPLATE"), os.Getenv("CHAT_REASSIGNED_TEMPLATE"))
+	if err != nil {
+		log.Fatalf("invalid chat template: %v", err)
+	}
+
+	// Публикация событий из outbox: доставки подписчикам вебхуков и уведомления в чаты команд
+	// ставятся в очередь в транзакции relay всегда, внешние sinks подключаются переменными окружения
+	sinks := []outbox.Sink{
+		outbox.NewFuncSink("webhooks", webhookRepo.EnqueueDeliveries),
+		notify.NewSink(notificationRepo, chatTemplates),
+	}
+
+	// Периодические задачи выполняет один экземпляр — держатель advisory-блокировки
+	sched := scheduler.New(repository.NewLeaderLock(db), schedulerRepo, txManager, logger)
+
+	// Аналитика времени цикла читается из rollup-таблиц, которые пересчитывает планировщик
+	cycleTimeCfg, err := cycleTimeConfigFromEnv()
+	if err != nil {
+		log.Fatalf("invalid cycle time config: %v", err)
+	}
+	cycleTimeService := service.NewCycleTimeService(cycleTimeRepo, cycleTimeCfg)
+	cycleTimeSchedule := os.Getenv("CYCLE_TIME_SCHEDULE")
+	if cycleTimeSchedule == "" {
+		cycleTimeSchedule = "*/15 * * * *"
+	}
+	if err := sched.Add("cycle_time_rollups", cycleTimeSchedule, cycleTimeService.RefreshRollups); err != nil {
+		log.Fatalf("invalid CYCLE_TIME_SCHEDULE: %v", err)
+	}
+	handler.EnableCycleTime(cycleTimeService)
+
+	// Дашборд нагрузки для руководителей: PR считается зависшим через DASHBOARD_STALE_HOURS часов
+	staleHours, err := positiveIntEnv("DASHBOARD_STALE_HOURS", 48)
+	if err != nil {
+		log.Fatalf("invalid dashboard config: %v", err)
+	}
+	handler.EnableDashboard(service.NewDashboardService(prRepo, staleHours))
+
+	// Почтовые уведомления включаются адресом SMTP-сервера
+	if addr := os.Getenv("SMTP_ADDR"); addr != "" {
+		cfg := email.SMTPConfig{
+			Addr:     addr,
+			From:     os.Getenv("SMTP_FROM"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+		}
+		if cfg.From == "" {
+			log.Fatal("SMTP_FROM is required when SMTP_ADDR is set")
+		}
+		dir := os.Getenv("EMAIL_TEMPLATES_DIR")
+		if dir == "" {
+			dir = "templates/email"
+		}
+		emailTemplates, err := email.LoadTemplates(dir)
+		if err != nil {
+			log.Fatalf("invalid email templates: %v", err)
+		}
+		sinks = append(sinks, email.NewSink(mailRepo, emailTemplates))
+		go email.NewDigester(mailRepo, prRepo, txManager, emailTemplates, logger).Run(ctx)
+		go email.NewMailer(mailRepo, cfg, email.DefaultRetryPolicy, logger).Run(ctx)
+
+		if err := scheduleRemindersFromEnv(sched, mailRepo, emailTemplates); err != nil {
+			log.Fatalf("invalid reminder config: %v", err)
+		}
+	}
+	go sched.Run(ctx)
+
+	go outbox.NewRelay(outboxRepo, txManager, sinks, logger).Run(ctx)
+	for _, sink := range externalSinksFromEnv(logger) {
+		go outbox.NewForwarder(outboxRepo, sink, outbox.DefaultRetryPolicy, logger).Run(ctx)
+	}
+	// Рассылка исходящих вебхуков подписчикам и сообщений в чаты в фоне
+	go outbound.NewDispatcher(webhookRepo, outbound.DefaultRetryPolicy, logger).Run(ctx)
+	go notify.NewNotifier(notificationRepo, notify.DefaultRetryPolicy, notify.DefaultInterval, logger).Run(ctx)
 
 	server := &http.Server{
 		Addr:    ":8080",
 		Handler: handler.Router(),
 	}
+	// Shutdown ждёт завершения активных запросов, а SSE-потоки сами не заканчиваются
+	server.RegisterOnShutdown(handler.CloseStreams)
 
 	// Запуск сервера в горутине
 	go func() {
@@ -85,3 +234,111 @@ func main() {
 
 	logger.Info("server stopped")
 }
+
+// scheduleRemindersFromEnv регистрирует почтовые напоминания о давно ожидающих ревью
+// (REMINDER_SCHEDULE, REMINDER_PENDING_HOURS) и сводки по зависшим PR для руководителей
+// команд (STALLED_SUMMARY_SCHEDULE, STALLED_PR_HOURS). Расписание "off" отключает задачу.
+func scheduleRemindersFromEnv(sched *scheduler.Scheduler, store email.ReminderStore, templates email.Templates) error {
+	pendingHours, err := positiveIntEnv("REMINDER_PENDING_HOURS", 24)
+	if err != nil {
+		return err
+	}
+	stalledHours, err := positiveIntEnv("STALLED_PR_HOURS", 72)
+	if err != nil {
+		return err
+	}
+	reminders := email.NewReminders(store, templates, pendingHours, stalledHours)
+
+	jobs := []struct {
+		name, env, spec string
+		run             scheduler.JobFunc
+	}{
+		{"pending_review_reminders", "REMINDER_SCHEDULE", "0 9 * * 1-5", reminders.RemindPending},
+		{"stalled_pr_summaries", "STALLED_SUMMARY_SCHEDULE", "0 10 * * 1", reminders.SummarizeStalled},
+	}
+	for _, job := range jobs {
+		spec := job.spec
+		if v := os.Getenv(job.env); v != "" {
+			spec = v
+		}
+		if spec == "off" {
+			continue
+		}
+		if err := sched.Add(job.name, spec, job.run); err != nil {
+			return fmt.Errorf("%s: %w", job.env, err)
+		}
+	}
+	return nil
+}
+
+// cycleTimeConfigFromEnv читает окна отчёта CYCLE_TIME_WINDOWS (дни через запятую, первое — по умолчанию)
+// и глубину тренда CYCLE_TIME_TREND_WEEKS.
+func cycleTimeConfigFromEnv() (service.CycleTimeConfig, error) {
+	cfg := service.DefaultCycleTimeConfig
+	if v := os.Getenv("CYCLE_TIME_WINDOWS"); v != "" {
+		cfg.WindowDays = nil
+		for _, part := range strings.Split(v, ",") {
+			days, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil || days <= 0 {
+				return service.CycleTimeConfig{}, errors.New("CYCLE_TIME_WINDOWS must be a comma-separated list of positive integers")
+			}
+			cfg.WindowDays = append(cfg.WindowDays, days)
+		}
+	}
+	weeks, err := positiveIntEnv("CYCLE_TIME_TREND_WEEKS", cfg.TrendWeeks)
+	if err != nil {
+		return service.CycleTimeConfig{}, err
+	}
+	cfg.TrendWeeks = weeks
+	return cfg, nil
+}
+
+// positiveIntEnv читает положительное целое из переменной окружения name или возвращает def.
+func positiveIntEnv(name string, def int) (int, error) {
+	v := os.Getenv(name)
+	if v == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("%s must be a positive integer", name)
+	}
+	return n, nil
+}
+
+// rampUpPolicyFromEnv читает REVIEW_RAMPUP_DAYS и REVIEW_RAMPUP_MAX_ASSIGNMENTS.
+// Ограничение включается, только если обе переменные больше нуля.
+func rampUpPolicyFromEnv() (service.RampUpPolicy, error) {
+	var p service.RampUpPolicy
+	if v := os.Getenv("REVIEW_RAMPUP_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days < 0 {
+			return service.RampUpPolicy{}, errors.New("REVIEW_RAMPUP_DAYS must be a non-negative integer")
+		}
+		p.Days = days
+	}
+	if v := os.Getenv("REVIEW_RAMPUP_MAX_ASSIGNMENTS"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit < 0 {
+			return service.RampUpPolicy{}, errors.New("REVIEW_RAMPUP_MAX_ASSIGNMENTS must be a non-negative integer")
+		}
+		p.MaxAssignments = limit
+	}
+	return p, nil
+}
+
+// externalSinksFromEnv собирает внешние sinks outbox: лог (OUTBOX_LOG=true),
+// HTTP-эндпоинт (OUTBOX_HTTP_URL) и NDJSON-файл (OUTBOX_FILE), если они заданы.
+func externalSinksFromEnv(logger *slog.Logger) []outbox.Sink {
+	var sinks []outbox.Sink
+	if enabled, _ := strconv.ParseBool(os.Getenv("OUTBOX_LOG")); enabled {
+		sinks = append(sinks, outbox.LogSink{Log: logger})
+	}
+	if url := os.Getenv("OUTBOX_HTTP_URL"); url != "" {
+		sinks = append(sinks, outbox.NewHTTPSink(url))
+	}
+	if path := os.Getenv("OUTBOX_FILE"); path != "" {
+		sinks = append(sinks, outbox.FileSink{Path: path})
+	}
+	return sinks
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	httpapi "pull-request-service/internal/http"
+	"pull-request-service/internal/repository"
+	"pull-request-service/internal/roster"
+	"pull-request-service/internal/service"
+)
+
+const rosterUsage = `usage:
+  pull-request-service roster import -file roster.csv [-format csv|yaml|json]
+  pull-request-service roster export [-file roster.yaml] [-format csv|yaml|json]
+
+Формат по умолчанию определяется по расширению файла (для export без -file — json).
+Подключение к БД берётся из DB_DSN, период разгона — из REVIEW_RAMPUP_DAYS и REVIEW_RAMPUP_MAX_ASSIGNMENTS.`
+
+// runRoster выполняет подкоманду roster (импорт или экспорт состава команд) и возвращает код выхода.
+func runRoster(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, rosterUsage)
+		return 2
+	}
+
+	fs := flag.NewFlagSet("roster "+args[0], flag.ContinueOnError)
+	file := fs.String("file", "", "путь к файлу состава")
+	formatName := fs.String("format", "", "формат файла: csv, yaml или json")
+	if err := fs.Parse(args[1:]); err != nil {
+		return 2
+	}
+
+	format, err := rosterFormat(*formatName, *file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	dsn := os.Getenv("DB_DSN")
+	if dsn == "" {
+		fmt.Fprintln(os.Stderr, "DB_DSN environment variable is required")
+		return 1
+	}
+	// Ревью, переназначаемые при импорте, распределяются по тем же правилам разгона, что и в сервисе
+	rampUp, err := rampUpPolicyFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid ramp-up config: %v\n", err)
+		return 1
+	}
+
+	ctx := context.Background()
+	db, err := repository.NewPostgres(ctx, dsn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to init postgres: %v\n", err)
+		return 1
+	}
+	defer db.Pool.Close()
+
+	userRepo := repository.NewUserRepo(db)
+	teams := service.NewTeamService(
+		repository.NewTeamRepo(db),
+		userRepo,
+		repository.NewPRRepo(db),
+		repository.NewTransactionManager(db),
+	)
+	teams.SetRampUpPolicy(rampUp)
+	// Переназначения ревью при импорте записываются в outbox,
+	// откуда их опубликует запущенный сервис
+	teams.SetEventRecorder(repository.NewOutboxRepo(db))
+
+	switch args[0] {
+	case "import":
+		err = importRoster(ctx, teams, *file, format)
+	case "export":
+		err = exportRoster(ctx, teams, *file, format)
+	default:
+		fmt.Fprintln(os.Stderr, rosterUsage)
+		return 2
+	}
+	if err != nil {
+		printRosterError(err)
+		return 1
+	}
+	return 0
+}
+
+// rosterFormat выбирает формат: явно заданный или по расширению файла.
+func rosterFormat(name, file string) (roster.Format, error) {
+	if name == "" {
+		name = strings.TrimPrefix(filepath.Ext(file), ".")
+	}
+	if name == "" {
+		return roster.FormatJSON, nil
+	}
+	return roster.ParseFormat(name)
+}
+
+func importRoster(ctx context.Context, teams *service.TeamService, file string, format roster.Format) error {
+	if file == "" {
+		return errors.New("-file is required for import")
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	rs, err := roster.Decode(f, format)
+	if err != nil {
+		return err
+	}
+	if err := httpapi.ValidateRoster(rs); err != nil {
+		return err
+	}
+
+	diff, err := teams.ImportRoster(ctx, rs)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(diff)
+}
+
+func exportRoster(ctx context.Context, teams *service.TeamService, file string, format roster.Format) error {
+	rs, err := teams.ExportRoster(ctx)
+	if err != nil {
+		return err
+	}
+
+	if file == "" {
+		return roster.Encode(os.Stdout, format, rs)
+	}
+
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	if err := roster.Encode(f, format, rs); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// printRosterError выводит ошибку вместе со списком проблем валидации, если он есть.
+func printRosterError(err error) {
+	fmt.Fprintln(os.Stderr, "error:", err)
+
+	var appErr *service.AppError
+	if errors.As(err, &appErr) {
+		if problems, ok := appErr.Details.([]string); ok && len(problems) > 1 {
+			for _, p := range problems {
+				fmt.Fprintln(os.Stderr, "  -", p)
+			}
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package email
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"pull-request-service/internal/model"
+)
+
+const (
+	// digestInterval — как часто проверяется, кому пора отправить сводку.
+	digestInterval = time.Minute
+	// digestBatch — сколько сводок формируется за одну транзакцию.
+	digestBatch = 100
+)
+
+// DigestStore описывает выбор получателей сводок и очередь писем.
+type DigestStore interface {
+	ClaimDueDigests(ctx context.Context, now time.Time, limit int) ([]model.DigestRecipient, error)
+	EnqueueEmail(ctx context.Context, to, subject, body string) error
+}
+
+// ReviewLister возвращает ожидающие ревью пользователя.
+type ReviewLister interface {
+	ListPendingAssignedToUser(ctx context.Context, userID string) ([]model.PendingReview, error)
+}
+
+// TransactionManager описывает запуск функции в транзакции БД.
+type TransactionManager interface {
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Digester формирует сводки ожидающих ревью для пользователей с режимами hourly и daily.
+// Выбор получателя, отметка о сводке и постановка письма в очередь происходят в одной транзакции,
+// поэтому при нескольких экземплярах сервиса сводка не дублируется и не теряется.
+type Digester struct {
+	store     DigestStore
+	reviews   ReviewLister
+	tx        TransactionManager
+	templates Templates
+	log       *slog.Logger
+}
+
+// NewDigester создаёт формирователь сводок.
+func NewDigester(store DigestStore, reviews ReviewLister, tx TransactionManager, templates Templates, log *slog.Logger) *Digester {
+	return &Digester{store: store, reviews: reviews, tx: tx, templates: templates, log: log}
+}
+
+// Run формирует сводки раз в digestInterval, пока не отменён ctx.
+func (d *Digester) Run(ctx context.Context) {
+	ticker := time.NewTicker(digestInterval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			n, err := d.DigestOnce(ctx, time.Now())
+			if err != nil && ctx.Err() == nil {
+				d.log.Error("email digest failed", slog.Any("err", err))
+			}
+			if err != nil || n < digestBatch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DigestOnce формирует сводки для пачки пользователей, которым они положены к моменту now.
+// Пользователю без ожидающих ревью письмо не отправляется, но отсчёт до следующей сводки начинается заново.
+// Возвращает число обработанных пользователей.
+func (d *Digester) DigestOnce(ctx context.Context, now time.Time) (int, error) {
+	var claimed int
+	err := d.tx.RunInTransaction(ctx, func(ctx context.Context) error {
+		recipients, err := d.store.ClaimDueDigests(ctx, now, digestBatch)
+		if err != nil {
+			return err
+		}
+		claimed = len(recipients)
+
+		for _, r := range recipients {
+			pending, err := d.reviews.ListPendingAssignedToUser(ctx, r.UserID)
+			if err != nil {
+				return err
+			}
+			if len(pending) == 0 {
+				continue
+			}
+
+			digest := Digest{Username: r.Username, Mode: r.Mode, Reviews: make([]DigestItem, 0, len(pending))}
+			for _, p := range pending {
+				isNew := r.PreviousDigestAt == nil || p.AssignedAt.After(*r.PreviousDigestAt)
+				if isNew {
+					digest.NewCount++
+				}
+				digest.Reviews = append(digest.Reviews, DigestItem{PendingReview: p, New: isNew})
+			}
+
+			subject, body, err := render(d.templates.Digest, digest)
+			if err != nil {
+				return err
+			}
+			if err := d.store.EnqueueEmail(ctx, r.Email, subject, body); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return claimed, err
+}
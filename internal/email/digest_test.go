@@ -0,0 +1,84 @@
+package email_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/email"
+	"pull-request-service/internal/email/mocks"
+	"pull-request-service/internal/model"
+)
+
+// templatesDir — шаблоны писем, которые кладутся рядом с сервисом.
+const templatesDir = "../../templates/email"
+
+func runInTx() *mocks.TransactionManager {
+	tx := new(mocks.TransactionManager)
+	tx.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	return tx
+}
+
+func TestDigester_DigestOnce(t *testing.T) {
+	templates, err := email.LoadTemplates(templatesDir)
+	require.NoError(t, err)
+
+	now := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	previous := now.Add(-24 * time.Hour)
+
+	store := new(mocks.DigestStore)
+	store.On("ClaimDueDigests", mock.Anything, now, mock.Anything).Return([]model.DigestRecipient{
+		{UserID: "u2", Username: "bob", Email: "bob@example.com", Mode: model.EmailDaily, PreviousDigestAt: &previous},
+		{UserID: "u3", Username: "carol", Email: "carol@example.com", Mode: model.EmailHourly},
+	}, nil)
+	var subject, body string
+	store.On("EnqueueEmail", mock.Anything, "bob@example.com", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { subject, body = args.String(2), args.String(3) }).
+		Return(nil).Once()
+
+	reviews := new(mocks.ReviewLister)
+	reviews.On("ListPendingAssignedToUser", mock.Anything, "u2").Return([]model.PendingReview{
+		{
+			PullRequestShort: model.PullRequestShort{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1", Status: model.StatusOpen, Priority: model.PriorityHigh},
+			AssignedAt:       now.Add(-2 * time.Hour),
+		},
+		{
+			PullRequestShort: model.PullRequestShort{PullRequestID: "pr-2", PullRequestName: "Fix login", AuthorID: "u4", Status: model.StatusOpen, Priority: model.PriorityNormal},
+			AssignedAt:       now.Add(-72 * time.Hour),
+		},
+	}, nil)
+	// Пользователю без ожидающих ревью сводка не отправляется
+	reviews.On("ListPendingAssignedToUser", mock.Anything, "u3").Return([]model.PendingReview{}, nil)
+
+	d := email.NewDigester(store, reviews, runInTx(), templates, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	n, err := d.DigestOnce(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "2 pending reviews (1 new)", subject)
+	assert.Contains(t, body, "Hi bob,")
+	assert.Contains(t, body, "[new] Add search (pr-1) by u1, priority high, assigned 2025-11-03 07:00 UTC")
+	assert.Contains(t, body, "\nFix login (pr-2) by u4, priority normal")
+	assert.Contains(t, body, `notification mode is "daily"`)
+	store.AssertExpectations(t)
+	reviews.AssertExpectations(t)
+}
+
+func TestLoadTemplates_RequiresSubjectAndBody(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "assigned.tmpl"), []byte(`{{define "subject"}}Hi{{end}}`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "digest.tmpl"), []byte(`{{define "subject"}}x{{end}}{{define "body"}}y{{end}}`), 0o600))
+
+	_, err := email.LoadTemplates(dir)
+	assert.ErrorContains(t, err, `"body" is not defined`)
+}
@@ -0,0 +1,192 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/outbound"
+)
+
+const (
+	// batchSize — сколько писем забирается из очереди за раз.
+	batchSize = 20
+	// pollInterval — пауза между опросами очереди, если она пуста.
+	pollInterval = 5 * time.Second
+	// claimLease — на сколько забранные письма скрываются от других экземпляров сервиса.
+	claimLease = 5 * time.Minute
+	// defaultSMTPTimeout ограничивает отправку одного письма, если SMTPConfig.Timeout не задан.
+	// Должен быть заметно меньше claimLease, иначе зависшее письмо заберёт другой экземпляр.
+	defaultSMTPTimeout = 30 * time.Second
+)
+
+// DefaultRetryPolicy — 8 попыток в течение нескольких часов: письмо, в отличие от сообщения
+// в чате, полезно и с опозданием.
+var DefaultRetryPolicy = outbound.RetryPolicy{MaxAttempts: 8, BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+// SMTPConfig — параметры SMTP-сервера. Без Username письма отправляются без аутентификации.
+// Timeout ограничивает весь SMTP-диалог одного письма, от соединения до QUIT (ноль — 30 секунд).
+type SMTPConfig struct {
+	Addr     string
+	From     string
+	Username string
+	Password string
+	Timeout  time.Duration
+}
+
+// Store описывает очередь писем, из которой работает Mailer.
+type Store interface {
+	ClaimEmails(ctx context.Context, limit int, lease time.Duration) ([]model.EmailNotification, error)
+	CompleteEmail(ctx context.Context, id int64) error
+	RetryEmail(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string) error
+	FailEmail(ctx context.Context, id int64, lastErr string) error
+}
+
+// Mailer отправляет письма из очереди по SMTP, повторяя неудачные отправки с экспоненциальной задержкой.
+type Mailer struct {
+	store   Store
+	cfg     SMTPConfig
+	retrier outbound.Retrier
+	log     *slog.Logger
+}
+
+// NewMailer создаёт отправителя писем.
+func NewMailer(store Store, cfg SMTPConfig, policy outbound.RetryPolicy, log *slog.Logger) *Mailer {
+	return &Mailer{
+		store: store,
+		cfg:   cfg,
+		retrier: outbound.Retrier{
+			Policy:   policy,
+			Complete: store.CompleteEmail,
+			Retry:    store.RetryEmail,
+			Fail:     store.FailEmail,
+			Log:      log,
+			Message:  "email failed",
+		},
+		log: log,
+	}
+}
+
+// Run отправляет письма, пока не отменён ctx.
+func (m *Mailer) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		n, err := m.SendOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			m.log.Error("email send failed", slog.Any("err", err))
+		}
+		if n == batchSize && err == nil {
+			timer.Reset(0)
+		} else {
+			timer.Reset(pollInterval)
+		}
+	}
+}
+
+// SendOnce забирает пачку писем и отправляет их по очереди. Возвращает число забранных писем.
+func (m *Mailer) SendOnce(ctx context.Context) (int, error) {
+	emails, err := m.store.ClaimEmails(ctx, batchSize, claimLease)
+	if err != nil {
+		return 0, err
+	}
+	for _, e := range emails {
+		if err := m.retrier.Record(ctx, e.ID, e.Attempts, m.send(ctx, e), slog.Int64("email_id", e.ID)); err != nil {
+			m.log.Error("email bookkeeping failed", slog.Int64("email_id", e.ID), slog.Any("err", err))
+		}
+	}
+	return len(emails), nil
+}
+
+// send отправляет письмо. STARTTLS используется, если сервер его поддерживает.
+// В отличие от smtp.SendMail, соединение и весь диалог ограничены по времени: молчащий сервер
+// не должен останавливать очередь.
+func (m *Mailer) send(ctx context.Context, e model.EmailNotification) error {
+	host, _, err := net.SplitHostPort(m.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	timeout := m.cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultSMTPTimeout
+	}
+
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", m.cfg.Addr)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if m.cfg.Username != "" {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return errors.New("smtp: server doesn't support AUTH")
+		}
+		if err := c.Auth(smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, host)); err != nil {
+			return err
+		}
+	}
+	if err := c.Mail(m.cfg.From); err != nil {
+		return err
+	}
+	if err := c.Rcpt(e.To); err != nil {
+		return err
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(buildMessage(m.cfg.From, e, time.Now())); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// buildMessage собирает письмо в формате RFC 5322: текст в UTF-8, тема в кодировке RFC 2047.
+func buildMessage(from string, e model.EmailNotification, date time.Time) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", e.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", e.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(e.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return b.Bytes()
+}
@@ -0,0 +1,192 @@
+package email_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"log/slog"
+	"mime"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/email"
+	"pull-request-service/internal/email/mocks"
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/outbound"
+)
+
+// smtpSink — локальный SMTP-сервер для тестов: принимает письма без аутентификации
+// и запоминает их. Если rejectRcpt не пуст, отвечает им на RCPT TO; silent — принимает
+// соединение и молчит.
+type smtpSink struct {
+	ln         net.Listener
+	rejectRcpt string
+	silent     bool
+
+	mu       sync.Mutex
+	messages []string
+	rcpts    []string
+}
+
+func newSMTPSink(t *testing.T, rejectRcpt string, silent bool) *smtpSink {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &smtpSink{ln: ln, rejectRcpt: rejectRcpt, silent: silent}
+	t.Cleanup(func() { _ = ln.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *smtpSink) Addr() string { return s.ln.Addr().String() }
+
+func (s *smtpSink) serve() {
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpSink) handle(conn net.Conn) {
+	defer conn.Close()
+	if s.silent {
+		_, _ = io.Copy(io.Discard, conn)
+		return
+	}
+	tp := textproto.NewConn(conn)
+	_ = tp.PrintfLine("220 localhost ESMTP test sink")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+		switch cmd {
+		case "EHLO", "HELO":
+			_ = tp.PrintfLine("250 localhost")
+		case "MAIL":
+			_ = tp.PrintfLine("250 OK")
+		case "RCPT":
+			if s.rejectRcpt != "" {
+				_ = tp.PrintfLine("%s", s.rejectRcpt)
+				continue
+			}
+			s.mu.Lock()
+			s.rcpts = append(s.rcpts, strings.Trim(strings.TrimPrefix(line[4:], " TO:"), "<>"))
+			s.mu.Unlock()
+			_ = tp.PrintfLine("250 OK")
+		case "DATA":
+			_ = tp.PrintfLine("354 go ahead")
+			data, err := io.ReadAll(tp.DotReader())
+			if err != nil {
+				return
+			}
+			s.mu.Lock()
+			s.messages = append(s.messages, string(data))
+			s.mu.Unlock()
+			_ = tp.PrintfLine("250 queued")
+		case "QUIT":
+			_ = tp.PrintfLine("221 bye")
+			return
+		default:
+			_ = tp.PrintfLine("250 OK")
+		}
+	}
+}
+
+func TestMailer_SendOnce(t *testing.T) {
+	policy := outbound.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	queued := model.EmailNotification{ID: 4, To: "bob@example.com", Subject: "Review requested: Добавить поиск", Body: "Hi bob,\n\nplease review.\n"}
+
+	tests := []struct {
+		name       string
+		rejectRcpt string
+		silent     bool
+		attempts   int
+		setupMocks func(store *mocks.Store)
+		wantSent   bool
+	}{
+		{
+			name: "Success: message is delivered",
+			setupMocks: func(store *mocks.Store) {
+				store.On("CompleteEmail", mock.Anything, int64(4)).Return(nil)
+			},
+			wantSent: true,
+		},
+		{
+			name:       "Retry: server rejects recipient",
+			rejectRcpt: "451 try again later",
+			setupMocks: func(store *mocks.Store) {
+				store.On("RetryEmail", mock.Anything, int64(4), mock.MatchedBy(func(at time.Time) bool {
+					return time.Until(at) > 50*time.Second
+				}), mock.MatchedBy(func(msg string) bool { return strings.Contains(msg, "try again later") })).Return(nil)
+			},
+		},
+		{
+			name:   "Retry: server does not answer",
+			silent: true,
+			setupMocks: func(store *mocks.Store) {
+				store.On("RetryEmail", mock.Anything, int64(4), mock.AnythingOfType("time.Time"), mock.MatchedBy(func(msg string) bool {
+					return strings.Contains(msg, "timeout")
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "Fail: last attempt fails",
+			rejectRcpt: "550 no such user",
+			attempts:   2,
+			setupMocks: func(store *mocks.Store) {
+				store.On("FailEmail", mock.Anything, int64(4), mock.MatchedBy(func(msg string) bool {
+					return strings.Contains(msg, "no such user")
+				})).Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := newSMTPSink(t, tt.rejectRcpt, tt.silent)
+
+			e := queued
+			e.Attempts = tt.attempts
+			store := new(mocks.Store)
+			store.On("ClaimEmails", mock.Anything, mock.Anything, mock.Anything).Return([]model.EmailNotification{e}, nil)
+			tt.setupMocks(store)
+
+			cfg := email.SMTPConfig{Addr: sink.Addr(), From: "reviews@example.com", Timeout: 200 * time.Millisecond}
+			m := email.NewMailer(store, cfg, policy, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			n, err := m.SendOnce(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+			store.AssertExpectations(t)
+
+			sink.mu.Lock()
+			defer sink.mu.Unlock()
+			if !tt.wantSent {
+				assert.Empty(t, sink.messages)
+				return
+			}
+			require.Len(t, sink.messages, 1)
+			assert.Equal(t, []string{"bob@example.com"}, sink.rcpts)
+
+			msg, err := textproto.NewReader(bufio.NewReader(strings.NewReader(sink.messages[0]))).ReadMIMEHeader()
+			require.NoError(t, err)
+			assert.Equal(t, "reviews@example.com", msg.Get("From"))
+			subject, err := new(mime.WordDecoder).DecodeHeader(msg.Get("Subject"))
+			require.NoError(t, err)
+			assert.Equal(t, queued.Subject, subject)
+			assert.Contains(t, sink.messages[0], "Hi bob,\n\nplease review.\n")
+		})
+	}
+}
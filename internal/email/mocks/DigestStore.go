@@ -0,0 +1,80 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "pull-request-service/internal/model"
+
+	time "time"
+)
+
+// DigestStore is an autogenerated mock type for the DigestStore type
+type DigestStore struct {
+	mock.Mock
+}
+
+// ClaimDueDigests provides a mock function with given fields: ctx, now, limit
+func (_m *DigestStore) ClaimDueDigests(ctx context.Context, now time.Time, limit int) ([]model.DigestRecipient, error) {
+	ret := _m.Called(ctx, now, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDueDigests")
+	}
+
+	var r0 []model.DigestRecipient
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]model.DigestRecipient, error)); ok {
+		return rf(ctx, now, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []model.DigestRecipient); ok {
+		r0 = rf(ctx, now, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.DigestRecipient)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, now, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnqueueEmail provides a mock function with given fields: ctx, to, subject, body
+func (_m *DigestStore) EnqueueEmail(ctx context.Context, to string, subject string, body string) error {
+	ret := _m.Called(ctx, to, subject, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, to, subject, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewDigestStore creates a new instance of DigestStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDigestStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DigestStore {
+	mock := &DigestStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,83 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "pull-request-service/internal/model"
+)
+
+// Queue is an autogenerated mock type for the Queue type
+type Queue struct {
+	mock.Mock
+}
+
+// AssignmentMail provides a mock function with given fields: ctx, prID, reviewerID
+func (_m *Queue) AssignmentMail(ctx context.Context, prID string, reviewerID string) (model.AssignmentMail, bool, error) {
+	ret := _m.Called(ctx, prID, reviewerID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AssignmentMail")
+	}
+
+	var r0 model.AssignmentMail
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (model.AssignmentMail, bool, error)); ok {
+		return rf(ctx, prID, reviewerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) model.AssignmentMail); ok {
+		r0 = rf(ctx, prID, reviewerID)
+	} else {
+		r0 = ret.Get(0).(model.AssignmentMail)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) bool); ok {
+		r1 = rf(ctx, prID, reviewerID)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, prID, reviewerID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// EnqueueEmail provides a mock function with given fields: ctx, to, subject, body
+func (_m *Queue) EnqueueEmail(ctx context.Context, to string, subject string, body string) error {
+	ret := _m.Called(ctx, to, subject, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, to, subject, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewQueue creates a new instance of Queue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewQueue(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Queue {
+	mock := &Queue{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
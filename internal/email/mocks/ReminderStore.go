@@ -0,0 +1,110 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "pull-request-service/internal/model"
+
+	time "time"
+)
+
+// ReminderStore is an autogenerated mock type for the ReminderStore type
+type ReminderStore struct {
+	mock.Mock
+}
+
+// EnqueueEmail provides a mock function with given fields: ctx, to, subject, body
+func (_m *ReminderStore) EnqueueEmail(ctx context.Context, to string, subject string, body string) error {
+	ret := _m.Called(ctx, to, subject, body)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) error); ok {
+		r0 = rf(ctx, to, subject, body)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReminderRecipients provides a mock function with given fields: ctx, assignedBefore
+func (_m *ReminderStore) ReminderRecipients(ctx context.Context, assignedBefore time.Time) ([]model.ReviewReminder, error) {
+	ret := _m.Called(ctx, assignedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReminderRecipients")
+	}
+
+	var r0 []model.ReviewReminder
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]model.ReviewReminder, error)); ok {
+		return rf(ctx, assignedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []model.ReviewReminder); ok {
+		r0 = rf(ctx, assignedBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ReviewReminder)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, assignedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StalledSummaries provides a mock function with given fields: ctx, createdBefore
+func (_m *ReminderStore) StalledSummaries(ctx context.Context, createdBefore time.Time) ([]model.StalledSummary, error) {
+	ret := _m.Called(ctx, createdBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StalledSummaries")
+	}
+
+	var r0 []model.StalledSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]model.StalledSummary, error)); ok {
+		return rf(ctx, createdBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []model.StalledSummary); ok {
+		r0 = rf(ctx, createdBefore)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StalledSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, createdBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReminderStore creates a new instance of ReminderStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReminderStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReminderStore {
+	mock := &ReminderStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
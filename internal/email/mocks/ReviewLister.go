@@ -0,0 +1,60 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "pull-request-service/internal/model"
+)
+
+// ReviewLister is an autogenerated mock type for the ReviewLister type
+type ReviewLister struct {
+	mock.Mock
+}
+
+// ListPendingAssignedToUser provides a mock function with given fields: ctx, userID
+func (_m *ReviewLister) ListPendingAssignedToUser(ctx context.Context, userID string) ([]model.PendingReview, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPendingAssignedToUser")
+	}
+
+	var r0 []model.PendingReview
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.PendingReview, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.PendingReview); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PendingReview)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReviewLister creates a new instance of ReviewLister. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReviewLister(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewLister {
+	mock := &ReviewLister{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
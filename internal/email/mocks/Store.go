@@ -0,0 +1,116 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "pull-request-service/internal/model"
+
+	time "time"
+)
+
+// Store is an autogenerated mock type for the Store type
+type Store struct {
+	mock.Mock
+}
+
+// ClaimEmails provides a mock function with given fields: ctx, limit, lease
+func (_m *Store) ClaimEmails(ctx context.Context, limit int, lease time.Duration) ([]model.EmailNotification, error) {
+	ret := _m.Called(ctx, limit, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimEmails")
+	}
+
+	var r0 []model.EmailNotification
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) ([]model.EmailNotification, error)); ok {
+		return rf(ctx, limit, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, time.Duration) []model.EmailNotification); ok {
+		r0 = rf(ctx, limit, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.EmailNotification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, time.Duration) error); ok {
+		r1 = rf(ctx, limit, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteEmail provides a mock function with given fields: ctx, id
+func (_m *Store) CompleteEmail(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// FailEmail provides a mock function with given fields: ctx, id, lastErr
+func (_m *Store) FailEmail(ctx context.Context, id int64, lastErr string) error {
+	ret := _m.Called(ctx, id, lastErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string) error); ok {
+		r0 = rf(ctx, id, lastErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RetryEmail provides a mock function with given fields: ctx, id, nextAttemptAt, lastErr
+func (_m *Store) RetryEmail(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string) error {
+	ret := _m.Called(ctx, id, nextAttemptAt, lastErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, time.Time, string) error); ok {
+		r0 = rf(ctx, id, nextAttemptAt, lastErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewStore creates a new instance of Store. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Store {
+	mock := &Store{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,78 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"pull-request-service/internal/model"
+)
+
+// ReminderStore описывает выбор адресатов напоминаний и сводок по зависшим PR и очередь писем.
+type ReminderStore interface {
+	ReminderRecipients(ctx context.Context, assignedBefore time.Time) ([]model.ReviewReminder, error)
+	StalledSummaries(ctx context.Context, createdBefore time.Time) ([]model.StalledSummary, error)
+	EnqueueEmail(ctx context.Context, to, subject, body string) error
+}
+
+// Reminders — задачи планировщика, ставящие в очередь напоминания ревьюверам
+// и сводки по зависшим PR руководителям команд. Методы подходят как scheduler.JobFunc.
+type Reminders struct {
+	store        ReminderStore
+	templates    Templates
+	pendingHours int
+	stalledHours int
+}
+
+// NewReminders создаёт задачи напоминаний: ревьюверу напоминают о ревью, ожидающих
+// дольше pendingHours часов, руководителю — о PR, открытых дольше stalledHours часов.
+func NewReminders(store ReminderStore, templates Templates, pendingHours, stalledHours int) *Reminders {
+	return &Reminders{store: store, templates: templates, pendingHours: pendingHours, stalledHours: stalledHours}
+}
+
+// RemindPending ставит в очередь по письму каждому ревьюверу с ревью, назначенными
+// раньше чем за pendingHours часов до scheduledAt. Напоминание получают и пользователи
+// со сводками: оно приходит по расписанию и касается только давно ожидающих ревью.
+func (r *Reminders) RemindPending(ctx context.Context, scheduledAt time.Time) (string, error) {
+	cutoff := scheduledAt.Add(-time.Duration(r.pendingHours) * time.Hour)
+	reminders, err := r.store.ReminderRecipients(ctx, cutoff)
+	if err != nil {
+		return "", err
+	}
+
+	reviews := 0
+	for _, rem := range reminders {
+		subject, body, err := render(r.templates.Reminder, Reminder{ReviewReminder: rem, Hours: r.pendingHours})
+		if err != nil {
+			return "", err
+		}
+		if err := r.store.EnqueueEmail(ctx, rem.Email, subject, body); err != nil {
+			return "", err
+		}
+		reviews += len(rem.Reviews)
+	}
+	return fmt.Sprintf("reminded %d reviewers about %d reviews", len(reminders), reviews), nil
+}
+
+// SummarizeStalled ставит в очередь руководителям команд сводки по открытым PR участников,
+// созданным раньше чем за stalledHours часов до scheduledAt и всё ещё ждущим ревью.
+func (r *Reminders) SummarizeStalled(ctx context.Context, scheduledAt time.Time) (string, error) {
+	cutoff := scheduledAt.Add(-time.Duration(r.stalledHours) * time.Hour)
+	summaries, err := r.store.StalledSummaries(ctx, cutoff)
+	if err != nil {
+		return "", err
+	}
+
+	prs := 0
+	for _, s := range summaries {
+		subject, body, err := render(r.templates.Stalled, Stalled{StalledSummary: s, Hours: r.stalledHours})
+		if err != nil {
+			return "", err
+		}
+		if err := r.store.EnqueueEmail(ctx, s.LeadEmail, subject, body); err != nil {
+			return "", err
+		}
+		prs += len(s.PullRequests)
+	}
+	return fmt.Sprintf("sent %d team summaries covering %d pull requests", len(summaries), prs), nil
+}
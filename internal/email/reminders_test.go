@@ -0,0 +1,70 @@
+package email_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/email"
+	"pull-request-service/internal/email/mocks"
+	"pull-request-service/internal/model"
+)
+
+func TestReminders_RemindPending(t *testing.T) {
+	templates, err := email.LoadTemplates(templatesDir)
+	require.NoError(t, err)
+
+	at := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	store := new(mocks.ReminderStore)
+	store.On("ReminderRecipients", mock.Anything, at.Add(-24*time.Hour)).Return([]model.ReviewReminder{{
+		UserID: "u2", Username: "bob", Email: "bob@example.com",
+		Reviews: []model.PendingReview{{
+			PullRequestShort: model.PullRequestShort{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u1", Status: model.StatusOpen, Priority: model.PriorityHigh},
+			AssignedAt:       at.Add(-30 * time.Hour),
+		}},
+	}}, nil)
+	var subject, body string
+	store.On("EnqueueEmail", mock.Anything, "bob@example.com", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { subject, body = args.String(2), args.String(3) }).
+		Return(nil).Once()
+
+	summary, err := email.NewReminders(store, templates, 24, 72).RemindPending(context.Background(), at)
+
+	require.NoError(t, err)
+	assert.Equal(t, "reminded 1 reviewers about 1 reviews", summary)
+	assert.Equal(t, "Reminder: 1 review waiting over 24h", subject)
+	assert.Contains(t, body, "Add search (pr-1) by u1, priority high, assigned 2025-11-02 03:00 UTC")
+	store.AssertExpectations(t)
+}
+
+func TestReminders_SummarizeStalled(t *testing.T) {
+	templates, err := email.LoadTemplates(templatesDir)
+	require.NoError(t, err)
+
+	at := time.Date(2025, 11, 3, 10, 0, 0, 0, time.UTC)
+	store := new(mocks.ReminderStore)
+	store.On("StalledSummaries", mock.Anything, at.Add(-72*time.Hour)).Return([]model.StalledSummary{{
+		TeamName: "backend", LeadUsername: "alice", LeadEmail: "alice@example.com",
+		PullRequests: []model.StalledPR{
+			{PullRequestID: "pr-1", PullRequestName: "Add search", AuthorID: "u2", CreatedAt: at.Add(-96 * time.Hour), PendingReviewers: []string{"u3", "u4"}},
+			{PullRequestID: "pr-2", PullRequestName: "Fix login", AuthorID: "u3", CreatedAt: at.Add(-80 * time.Hour)},
+		},
+	}}, nil)
+	var subject, body string
+	store.On("EnqueueEmail", mock.Anything, "alice@example.com", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { subject, body = args.String(2), args.String(3) }).
+		Return(nil).Once()
+
+	summary, err := email.NewReminders(store, templates, 24, 72).SummarizeStalled(context.Background(), at)
+
+	require.NoError(t, err)
+	assert.Equal(t, "sent 1 team summaries covering 2 pull requests", summary)
+	assert.Equal(t, "backend: 2 stalled pull requests", subject)
+	assert.Contains(t, body, "Add search (pr-1) by u2, opened 2025-10-30 10:00 UTC, waiting for u3, u4")
+	assert.Contains(t, body, "Fix login (pr-2) by u3, opened 2025-10-31 02:00 UTC, no reviewers assigned")
+	store.AssertExpectations(t)
+}
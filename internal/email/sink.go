@@ -0,0 +1,54 @@
+package email
+
+import (
+	"context"
+	"fmt"
+
+	"pull-request-service/internal/model"
+)
+
+// Queue описывает выбор получателей писем о назначениях и очередь писем, в которую пишет Sink.
+type Queue interface {
+	AssignmentMail(ctx context.Context, prID, reviewerID string) (model.AssignmentMail, bool, error)
+	EnqueueEmail(ctx context.Context, to, subject, body string) error
+}
+
+// Sink — sink relay outbox, ставящий в очередь письма о назначениях ревьюверам с режимом immediate.
+// Письма пишутся в транзакции relay, поэтому на каждое назначение уходит одно письмо.
+type Sink struct {
+	queue     Queue
+	templates Templates
+}
+
+// NewSink создаёт sink писем о назначениях.
+func NewSink(queue Queue, templates Templates) *Sink {
+	return &Sink{queue: queue, templates: templates}
+}
+
+// Name реализует outbox.Sink.
+func (s *Sink) Name() string { return "email" }
+
+// Publish реализует outbox.Sink: на каждое событие reviewer.assigned — письмо новому ревьюверу,
+// если он выбрал режим immediate.
+func (s *Sink) Publish(ctx context.Context, events []model.Event) error {
+	for _, ev := range events {
+		if ev.Type != model.EventReviewerAssigned {
+			continue
+		}
+		mail, ok, err := s.queue.AssignmentMail(ctx, ev.PullRequestID, ev.ReviewerID)
+		if err != nil {
+			return fmt.Errorf("event %s: %w", ev.ID, err)
+		}
+		if !ok {
+			continue
+		}
+		subject, body, err := render(s.templates.Assigned, mail)
+		if err != nil {
+			return fmt.Errorf("event %s: %w", ev.ID, err)
+		}
+		if err := s.queue.EnqueueEmail(ctx, mail.Email, subject, body); err != nil {
+			return fmt.Errorf("event %s: %w", ev.ID, err)
+		}
+	}
+	return nil
+}
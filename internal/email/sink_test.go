@@ -0,0 +1,40 @@
+package email_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/email"
+	"pull-request-service/internal/email/mocks"
+	"pull-request-service/internal/model"
+)
+
+func TestSink_Publish(t *testing.T) {
+	templates, err := email.LoadTemplates(templatesDir)
+	require.NoError(t, err)
+
+	q := new(mocks.Queue)
+	q.On("AssignmentMail", mock.Anything, "pr-1", "u2").Return(model.AssignmentMail{
+		Email: "bob@example.com", Username: "bob", PullRequestID: "pr-1", PullRequestName: "Add search", Author: "alice",
+	}, true, nil)
+	// u3 не выбрал режим immediate
+	q.On("AssignmentMail", mock.Anything, "pr-1", "u3").Return(model.AssignmentMail{}, false, nil)
+	q.On("EnqueueEmail", mock.Anything, "bob@example.com", "Review requested: Add search",
+		mock.MatchedBy(func(body string) bool {
+			return assert.Contains(t, body, `alice is waiting for your review on "Add search" (pr-1).`)
+		})).Return(nil).Once()
+
+	err = email.NewSink(q, templates).Publish(context.Background(), []model.Event{
+		{ID: "ev-1", Type: model.EventPRCreated, PullRequestID: "pr-1", Reviewers: []string{"u2", "u3"}},
+		{ID: "ev-2", Type: model.EventReviewerAssigned, PullRequestID: "pr-1", ReviewerID: "u2"},
+		{ID: "ev-3", Type: model.EventReviewerAssigned, PullRequestID: "pr-1", ReviewerID: "u3"},
+		{ID: "ev-4", Type: model.EventPRMerged, PullRequestID: "pr-1"},
+	})
+
+	require.NoError(t, err)
+	q.AssertExpectations(t)
+}
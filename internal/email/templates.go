@@ -0,0 +1,108 @@
+// Package email отправляет ревьюверам письма о назначенных ревью по SMTP: сразу
+// на каждое назначение (режим immediate) или сводкой ожидающих ревью раз в час или в сутки,
+// а также напоминания о давно ожидающих ревью и сводки по зависшим PR для руководителей команд.
+// Письма формируются по шаблонам с диска, ставятся в очередь в БД и отправляются фоновым Mailer.
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"pull-request-service/internal/model"
+)
+
+// Файлы шаблонов в каталоге шаблонов. Каждый определяет шаблоны "subject" и "body".
+const (
+	assignedTemplateFile = "assigned.tmpl"
+	digestTemplateFile   = "digest.tmpl"
+	reminderTemplateFile = "reminder.tmpl"
+	stalledTemplateFile  = "stalled.tmpl"
+)
+
+// Digest — данные шаблона сводки. NewCount — сколько ревью назначено после предыдущей сводки.
+type Digest struct {
+	Username string
+	Mode     model.EmailMode
+	Reviews  []DigestItem
+	NewCount int
+}
+
+// DigestItem — ожидающее ревью в сводке; New — назначено после предыдущей сводки.
+type DigestItem struct {
+	model.PendingReview
+	New bool
+}
+
+// Reminder — данные шаблона напоминания о ревью, ожидающих дольше Hours часов.
+type Reminder struct {
+	model.ReviewReminder
+	Hours int
+}
+
+// Stalled — данные шаблона сводки для руководителя по PR, открытым дольше Hours часов.
+type Stalled struct {
+	model.StalledSummary
+	Hours int
+}
+
+// Templates — шаблоны письма о назначении (данные model.AssignmentMail), сводки (Digest),
+// напоминания (Reminder) и сводки по зависшим PR (Stalled).
+type Templates struct {
+	Assigned *template.Template
+	Digest   *template.Template
+	Reminder *template.Template
+	Stalled  *template.Template
+}
+
+// LoadTemplates читает шаблоны text/template из каталога dir.
+func LoadTemplates(dir string) (Templates, error) {
+	var t Templates
+	var err error
+	if t.Assigned, err = load(filepath.Join(dir, assignedTemplateFile)); err != nil {
+		return Templates{}, err
+	}
+	if t.Digest, err = load(filepath.Join(dir, digestTemplateFile)); err != nil {
+		return Templates{}, err
+	}
+	if t.Reminder, err = load(filepath.Join(dir, reminderTemplateFile)); err != nil {
+		return Templates{}, err
+	}
+	if t.Stalled, err = load(filepath.Join(dir, stalledTemplateFile)); err != nil {
+		return Templates{}, err
+	}
+	return t, nil
+}
+
+func load(path string) (*template.Template, error) {
+	tpl, err := template.New(filepath.Base(path)).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"join": strings.Join}).
+		ParseFiles(path)
+	if err != nil {
+		return nil, fmt.Errorf("email template: %w", err)
+	}
+	for _, name := range []string{"subject", "body"} {
+		if tpl.Lookup(name) == nil {
+			return nil, fmt.Errorf("email template %s: %q is not defined", path, name)
+		}
+	}
+	return tpl, nil
+}
+
+// render исполняет шаблоны "subject" и "body". Тема приводится к одной строке.
+func render(tpl *template.Template, data any) (subject, body string, err error) {
+	var buf bytes.Buffer
+	if err := tpl.ExecuteTemplate(&buf, "subject", data); err != nil {
+		return "", "", err
+	}
+	subject = strings.Join(strings.Fields(buf.String()), " ")
+
+	buf.Reset()
+	if err := tpl.ExecuteTemplate(&buf, "body", data); err != nil {
+		return "", "", err
+	}
+	return subject, strings.TrimLeft(buf.String(), "\n"), nil
+}
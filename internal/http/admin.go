@@ -0,0 +1,133 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/roster"
+	"pull-request-service/internal/service"
+)
+
+// maxRosterSize ограничивает размер загружаемого файла состава.
+const maxRosterSize = 10 << 20
+
+// decodeRoster читает и валидирует файл состава из тела запроса.
+// Формат берётся из query-параметра format, а если его нет — из Content-Type.
+func decodeRoster(w http.ResponseWriter, r *http.Request) (model.Roster, error) {
+	format := roster.FormatFromContentType(r.Header.Get("Content-Type"))
+	if f := r.URL.Query().Get("format"); f != "" {
+		var err error
+		if format, err = roster.ParseFormat(f); err != nil {
+			return model.Roster{}, service.ErrBadRequest(err.Error())
+		}
+	}
+
+	rs, err := roster.Decode(http.MaxBytesReader(w, r.Body, maxRosterSize), format)
+	if err != nil {
+		return model.Roster{}, service.ErrBadRequest(err.Error())
+	}
+	if err := ValidateRoster(rs); err != nil {
+		return model.Roster{}, err
+	}
+	return rs, nil
+}
+
+func (h *Handler) handleAdminImport(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "admin_import"
+
+	rs, err := decodeRoster(w, r)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	diff, err := h.Teams.ImportRoster(ctx, rs)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := importRosterResponse{Status: "ok", Diff: diff}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) handleAdminSync(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "admin_sync"
+
+	planOnly, err := parseBoolQuery("plan_only", r.URL.Query().Get("plan_only"))
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	rs, err := decodeRoster(w, r)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	diff, err := h.Teams.SyncRoster(ctx, rs, planOnly)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := importRosterResponse{Status: "ok", Diff: diff}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) handleAdminExport(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "admin_export"
+
+	format := roster.FormatJSON
+	if f := r.URL.Query().Get("format"); f != "" {
+		var err error
+		if format, err = roster.ParseFormat(f); err != nil {
+			h.writeError(w, handlerName, service.ErrBadRequest(err.Error()))
+			return
+		}
+	}
+
+	ctx := r.Context()
+	rs, err := h.Teams.ExportRoster(ctx)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	// Кодируем в буфер, чтобы при ошибке ещё можно было ответить корректным JSON
+	var buf bytes.Buffer
+	if err := roster.Encode(&buf, format, rs); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", `attachment; filename="roster.`+string(format)+`"`)
+	_, _ = w.Write(buf.Bytes())
+}
+
+func (h *Handler) handleAdminJobRuns(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "admin_job_runs"
+
+	limit, err := parseLimitQuery(r.URL.Query().Get("limit"))
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	runs, err := h.JobRuns.ListRuns(ctx, r.URL.Query().Get("job"), limit)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(listJobRunsResponse{Runs: runs})
+}
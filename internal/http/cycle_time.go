@@ -0,0 +1,26 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+func (h *Handler) handleCycleTime(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "get_cycle_time"
+
+	filter, err := ParseCycleTimeQuery(r.URL.Query())
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	report, err := h.CycleTime.GetCycleTime(ctx, filter)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,89 @@
+package http
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// web — шаблоны и статика дашборда, встроенные в бинарник: страница работает без внешних CDN.
+//
+//go:embed web
+var web embed.FS
+
+var dashboardTemplates = template.Must(template.New("dashboard").Funcs(template.FuncMap{
+	"bar":   barWidth,
+	"age":   age,
+	"clock": func(t time.Time) string { return t.Format("2006-01-02 15:04 MST") },
+	"join":  strings.Join,
+}).ParseFS(web, "web/dashboard.html"))
+
+// dashboardRoutes регистрирует страницу /dashboard, её HTML-фрагмент для автообновления и статику.
+func (h *Handler) dashboardRoutes(r chi.Router) {
+	assets, err := fs.Sub(web, "web/assets")
+	if err != nil {
+		panic(err)
+	}
+
+	r.Get("/", h.handleDashboard("page"))
+	r.Get("/content", h.handleDashboard("content"))
+	r.Handle("/assets/*", http.StripPrefix("/dashboard/assets/", http.FileServer(http.FS(assets))))
+}
+
+// handleDashboard отдаёт страницу целиком (page) или только содержимое (content), которое
+// страница периодически запрашивает и подставляет вместо текущего.
+func (h *Handler) handleDashboard(tpl string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const handlerName = "dashboard"
+
+		ctx := r.Context()
+		d, err := h.Dashboard.GetDashboard(ctx)
+		if err != nil {
+			h.writeError(w, handlerName, err)
+			return
+		}
+
+		var buf bytes.Buffer
+		if err := dashboardTemplates.ExecuteTemplate(&buf, tpl, d); err != nil {
+			h.writeError(w, handlerName, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-store")
+		_, _ = buf.WriteTo(w)
+	}
+}
+
+// barWidth возвращает длину полосы нагрузки в процентах от наибольшей.
+func barWidth(open, maxOpen int) int {
+	if maxOpen == 0 {
+		return 0
+	}
+	return open * 100 / maxOpen
+}
+
+// age форматирует прошедшее с from до now время: "2d 5h", "3h 20m" или "15m".
+func age(from, now time.Time) string {
+	d := now.Sub(from)
+	if d < 0 {
+		d = 0
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
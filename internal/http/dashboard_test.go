@@ -0,0 +1,121 @@
+package http_test
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	httpapi "pull-request-service/internal/http"
+	"pull-request-service/internal/http/mocks"
+	"pull-request-service/internal/model"
+)
+
+func TestHandler_Dashboard(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	now := time.Date(2025, 11, 5, 12, 0, 0, 0, time.UTC)
+	dashboard := model.Dashboard{
+		GeneratedAt:     now,
+		StaleAfterHours: 48,
+		MaxOpenReviews:  4,
+		Teams: []model.DashboardTeam{{
+			TeamName:    "backend",
+			OpenReviews: 5,
+			Members: []model.DashboardMember{
+				{UserID: "u2", Username: "Bob", IsActive: true, OpenReviews: 4},
+				{UserID: "u3", Username: "<Carol>", IsActive: false, OpenReviews: 1},
+			},
+		}},
+		StalePRs: []model.StalledPR{
+			{PullRequestID: "pr-7", PullRequestName: "Add search", AuthorID: "u1", TeamName: "backend", CreatedAt: now.Add(-75 * time.Hour), PendingReviewers: []string{"u2", "u3"}},
+		},
+		Reassignments: []model.ReviewerReassignment{
+			{PullRequestID: "pr-7", PullRequestName: "Add search", ReviewerID: "u4", ReplacedBy: "u3", ReassignedAt: now.Add(-90 * time.Minute)},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		path           string
+		mockBehavior   func(ds *mocks.DashboardService)
+		expectedStatus int
+		contains       []string
+		notContains    []string
+	}{
+		{
+			name: "Page: Full HTML with load bars, stale PRs and reassignments",
+			path: "/dashboard",
+			mockBehavior: func(ds *mocks.DashboardService) {
+				ds.On("GetDashboard", mock.Anything).Return(dashboard, nil)
+			},
+			expectedStatus: http.StatusOK,
+			contains: []string{
+				"<!DOCTYPE html>",
+				`<script src="/dashboard/assets/dashboard.js">`,
+				"backend",
+				"width: 100%",
+				"width: 25%",
+				"&lt;Carol&gt; <small>(inactive)</small>",
+				"3d 3h",
+				"u2, u3",
+				"1h 30m ago",
+				"Updated 2025-11-05 12:00 UTC",
+			},
+		},
+		{
+			name: "Content: Fragment for auto-refresh",
+			path: "/dashboard/content",
+			mockBehavior: func(ds *mocks.DashboardService) {
+				ds.On("GetDashboard", mock.Anything).Return(model.Dashboard{GeneratedAt: now, StaleAfterHours: 48}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			contains:       []string{"No teams yet.", "Nothing is stuck.", "No reassignments yet."},
+			notContains:    []string{"<html"},
+		},
+		{
+			name:           "Assets: Served from the binary",
+			path:           "/dashboard/assets/dashboard.css",
+			mockBehavior:   func(ds *mocks.DashboardService) {},
+			expectedStatus: http.StatusOK,
+			contains:       []string{".load .bar span"},
+		},
+		{
+			name: "Error: Service failure",
+			path: "/dashboard",
+			mockBehavior: func(ds *mocks.DashboardService) {
+				ds.On("GetDashboard", mock.Anything).Return(model.Dashboard{}, errors.New("db down"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ds := new(mocks.DashboardService)
+			tt.mockBehavior(ds)
+
+			h := httpapi.NewHandler(new(mocks.TeamService), new(mocks.UserService), new(mocks.PRService), logger)
+			h.EnableDashboard(ds)
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+
+			h.Router().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			for _, s := range tt.contains {
+				assert.Contains(t, w.Body.String(), s)
+			}
+			for _, s := range tt.notContains {
+				assert.NotContains(t, w.Body.String(), s)
+			}
+			ds.AssertExpectations(t)
+		})
+	}
+}
@@ -10,12 +10,88 @@ type errorResponse struct {
 type errorBody struct {
 	Code    string `json:"code"`
 	Message string `json:"message"`
+	Details any    `json:"details,omitempty"`
+}
+
+type createTeamRequest struct {
+	model.Team
+	MoveExisting bool `json:"move_existing"`
 }
 
 type createTeamResponse struct {
 	Team model.Team `json:"team"`
 }
 
+type teamResponse struct {
+	Team model.Team `json:"team"`
+}
+
+type listTeamsResponse struct {
+	Teams      []model.TeamSummary `json:"teams"`
+	NextCursor string              `json:"next_cursor,omitempty"`
+}
+
+type teamNameRequest struct {
+	TeamName string `json:"team_name"`
+}
+
+type moveSubtreeRequest struct {
+	TeamName       string `json:"team_name"`
+	ParentTeamName string `json:"parent_team_name"`
+}
+
+type setNotificationChannelRequest struct {
+	TeamName   string `json:"team_name"`
+	WebhookURL string `json:"webhook_url"`
+}
+
+type setLeadRequest struct {
+	TeamName string `json:"team_name"`
+	UserID   string `json:"user_id"`
+}
+
+type teamDescendantsResponse struct {
+	TeamName    string           `json:"team_name"`
+	Descendants []model.TeamNode `json:"descendants"`
+}
+
+type teamStatsResponse struct {
+	Teams []model.TeamStatsDTO `json:"teams"`
+}
+
+type importRosterResponse struct {
+	Status string           `json:"status"`
+	Diff   model.RosterDiff `json:"diff"`
+}
+
+type removeMembersRequest struct {
+	TeamName string   `json:"team_name"`
+	UserIDs  []string `json:"user_ids"`
+}
+
+type moveTeamRequest struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+}
+
+type setChatHandleRequest struct {
+	UserID     string `json:"user_id"`
+	ChatHandle string `json:"chat_handle"`
+}
+
+type setEmailPreferencesRequest struct {
+	UserID string          `json:"user_id"`
+	Email  string          `json:"email"`
+	Mode   model.EmailMode `json:"mode"`
+}
+
+type setWorkingHoursRequest struct {
+	UserID    string `json:"user_id"`
+	TimeZone  string `json:"timezone"`
+	StartHour *int   `json:"start_hour"`
+	EndHour   *int   `json:"end_hour"`
+}
+
 type setIsActiveRequest struct {
 	UserID   string `json:"user_id"`
 	IsActive bool   `json:"is_active"`
@@ -25,15 +101,36 @@ type userResponse struct {
 	User model.User `json:"user"`
 }
 
+type setIsActiveResponse struct {
+	User          model.User                 `json:"user"`
+	Reassignments []model.ReviewReassignment `json:"reassignments,omitempty"`
+}
+
+type listUsersResponse struct {
+	Users      []model.User `json:"users"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
 type getUserReviewResponse struct {
 	UserID       string                   `json:"user_id"`
 	PullRequests []model.PullRequestShort `json:"pull_requests"`
 }
 
+type userActivityResponse struct {
+	UserID string                    `json:"user_id"`
+	Events []model.UserActivityEvent `json:"events"`
+}
+
+type getUserAuthoredResponse struct {
+	UserID       string                      `json:"user_id"`
+	PullRequests []model.AuthoredPullRequest `json:"pull_requests"`
+}
+
 type createPRRequest struct {
 	PullRequestID   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 	AuthorID        string `json:"author_id"`
+	Priority        string `json:"priority"`
 }
 
 type mergePRRequest struct {
@@ -45,10 +142,21 @@ type reassignRequest struct {
 	OldUserID     string `json:"old_user_id"`
 }
 
+type reviewRequest struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReviewerID    string `json:"reviewer_id"`
+	State         string `json:"state"`
+}
+
 type prResponse struct {
 	PR model.PullRequest `json:"pr"`
 }
 
+type listPRsResponse struct {
+	PullRequests []model.PullRequest `json:"pull_requests"`
+	NextCursor   string              `json:"next_cursor,omitempty"`
+}
+
 type reassignResponse struct {
 	PR         model.PullRequest `json:"pr"`
 	ReplacedBy string            `json:"replaced_by"`
@@ -56,4 +164,47 @@ type reassignResponse struct {
 
 type massDeactivateRequest struct {
 	UserIDs []string `json:"user_ids"`
+	Preview bool     `json:"preview"`
+}
+
+type massDeactivateResponse struct {
+	Status string `json:"status"`
+	model.DeactivationReport
+}
+
+type webhookResponse struct {
+	Status        string `json:"status"`
+	Action        string `json:"action,omitempty"`
+	PullRequestID string `json:"pull_request_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+type createSubscriptionRequest struct {
+	URL        string   `json:"url"`
+	Secret     string   `json:"secret"`
+	EventTypes []string `json:"event_types"`
+}
+
+type subscriptionResponse struct {
+	Subscription model.WebhookSubscription `json:"subscription"`
+}
+
+type listSubscriptionsResponse struct {
+	Subscriptions []model.WebhookSubscription `json:"subscriptions"`
+}
+
+type subscriptionIDRequest struct {
+	ID int64 `json:"id"`
+}
+
+type listJobRunsResponse struct {
+	Runs []model.JobRun `json:"runs"`
+}
+
+type listDeadLettersResponse struct {
+	DeadLetters []model.WebhookDeadLetter `json:"dead_letters"`
+}
+
+type redeliverRequest struct {
+	DeadLetterID int64 `json:"dead_letter_id"`
 }
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"pull-request-service/internal/model"
 	"pull-request-service/internal/service"
+	"pull-request-service/internal/webhook"
+	"sync"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/cors"
@@ -14,31 +16,118 @@ import (
 
 // TeamService описывает методы сервиса команд, используемые HTTP-слоем.
 type TeamService interface {
-	CreateTeam(ctx context.Context, team model.Team) (model.Team, error)
+	CreateTeam(ctx context.Context, team model.Team, moveExisting bool) (model.Team, error)
 	GetTeam(ctx context.Context, name string) (model.Team, error)
-	MassDeactivate(ctx context.Context, userIDs []string) error
-	GetStats(ctx context.Context) (interface{}, error)
+	ListTeams(ctx context.Context, filter model.TeamFilter, cursor string) ([]model.TeamSummary, string, error)
+	MassDeactivate(ctx context.Context, userIDs []string, preview bool) (model.DeactivationReport, error)
+	AddMembers(ctx context.Context, teamName string, members []model.TeamMember) (model.Team, error)
+	RemoveMembers(ctx context.Context, teamName string, userIDs []string) (model.Team, error)
+	MoveUser(ctx context.Context, userID, teamName string) (model.User, error)
+	ArchiveTeam(ctx context.Context, teamName string) (model.Team, error)
+	DeleteTeam(ctx context.Context, teamName string) error
+	MoveTeam(ctx context.Context, teamName, parentTeamName string) (model.Team, error)
+	ListDescendants(ctx context.Context, teamName string) ([]model.TeamNode, error)
+	GetTeamStats(ctx context.Context) ([]model.TeamStatsDTO, error)
+	ImportRoster(ctx context.Context, roster model.Roster) (model.RosterDiff, error)
+	ExportRoster(ctx context.Context) (model.Roster, error)
+	SyncRoster(ctx context.Context, roster model.Roster, planOnly bool) (model.RosterDiff, error)
+	GetStats(ctx context.Context) ([]model.StatsDTO, error)
+	GetReviewStats(ctx context.Context, filter model.StatsFilter) (model.ReviewStats, error)
+	SetNotificationChannel(ctx context.Context, teamName, webhookURL string) error
+	SetLead(ctx context.Context, teamName, userID string) error
 }
 
 // UserService описывает методы сервиса пользователей, используемые HTTP-слоем.
 type UserService interface {
-	SetIsActive(ctx context.Context, userID string, isActive bool) (model.User, error)
+	SetIsActive(ctx context.Context, userID string, isActive bool) (model.User, []model.ReviewReassignment, error)
+	ListUsers(ctx context.Context, filter model.UserFilter, cursor string) ([]model.User, string, error)
+	ListActivity(ctx context.Context, userID string) ([]model.UserActivityEvent, error)
+	SetChatHandle(ctx context.Context, userID, handle string) error
+	SetEmailPreferences(ctx context.Context, userID, email string, mode model.EmailMode) error
+	SetWorkingHours(ctx context.Context, userID string, hours *model.WorkingHours) error
 }
 
 // PRService описывает методы сервиса pr, используемые HTTP-слоем.
 type PRService interface {
 	CreatePR(ctx context.Context, input model.PullRequest) (model.PullRequest, error)
 	MergePR(ctx context.Context, prID string) (model.PullRequest, error)
+	ClosePR(ctx context.Context, prID string) (model.PullRequest, error)
+	ReopenPR(ctx context.Context, prID string) (model.PullRequest, error)
 	ReassignReviewer(ctx context.Context, prID, oldUserID string) (model.PullRequest, string, error)
 	ListAssignedToUser(ctx context.Context, userID string) ([]model.PullRequestShort, error)
+	ListPullRequests(ctx context.Context, filter model.PullRequestFilter, cursor string) ([]model.PullRequest, string, error)
+	ListAuthoredByUser(ctx context.Context, userID string) ([]model.AuthoredPullRequest, error)
+	SubmitReview(ctx context.Context, prID, reviewerID string, state model.ReviewState) (model.PullRequest, error)
+}
+
+// ProvisioningService описывает методы сервиса провижининга, используемые SCIM-эндпоинтами.
+type ProvisioningService interface {
+	ListUsers(ctx context.Context, filter model.UserFilter) ([]model.User, int, error)
+	GetUser(ctx context.Context, userID string) (model.User, error)
+	CreateUser(ctx context.Context, u model.User) (model.User, error)
+	ReplaceUser(ctx context.Context, u model.User) (model.User, []model.ReviewReassignment, error)
+	ListGroups(ctx context.Context, name string, offset, limit int) ([]model.Team, int, error)
+	GetGroup(ctx context.Context, name string) (model.Team, error)
+	CreateGroup(ctx context.Context, name string, userIDs []string) (model.Team, error)
+	SetGroupMembers(ctx context.Context, name string, userIDs []string) (model.Team, error)
+	DeleteGroup(ctx context.Context, name string) error
+}
+
+// WebhookSubscriptionService описывает методы управления подписками на исходящие вебхуки.
+type WebhookSubscriptionService interface {
+	CreateSubscription(ctx context.Context, sub model.WebhookSubscription) (model.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	ListDeadLetters(ctx context.Context, limit int) ([]model.WebhookDeadLetter, error)
+	Redeliver(ctx context.Context, deadLetterID int64) error
+}
+
+// ReviewStreamService описывает подписку на поток событий ревью пользователя.
+type ReviewStreamService interface {
+	Subscribe(ctx context.Context, userID string, lastEventID int64) (model.ReviewSubscription, error)
+}
+
+// CycleTimeService описывает отчёт о времени цикла ревью.
+type CycleTimeService interface {
+	GetCycleTime(ctx context.Context, filter model.CycleTimeFilter) (model.CycleTimeReport, error)
+}
+
+// DashboardService описывает сбор данных страницы нагрузки ревьюверов.
+type DashboardService interface {
+	GetDashboard(ctx context.Context) (model.Dashboard, error)
+}
+
+// JobRunService описывает чтение истории запусков периодических задач.
+type JobRunService interface {
+	ListRuns(ctx context.Context, job string, limit int) ([]model.JobRun, error)
 }
 
 // Handler агрегирует зависимости HTTP-слоя
 type Handler struct {
-	Teams TeamService
-	Users UserService
-	PRs   PRService
-	Log   *slog.Logger
+	Teams         TeamService
+	Users         UserService
+	PRs           PRService
+	Provisioning  ProvisioningService
+	Subscriptions WebhookSubscriptionService
+	ReviewStream  ReviewStreamService
+	JobRuns       JobRunService
+	CycleTime     CycleTimeService
+	Dashboard     DashboardService
+	Log           *slog.Logger
+
+	scimToken string
+
+	webhooks []webhookRoute
+
+	// streamsDone закрывается при остановке сервера и завершает открытые SSE-потоки
+	streamsDone      chan struct{}
+	closeStreamsOnce sync.Once
+}
+
+// webhookRoute — подключённый хостинг вебхуков и сопоставление его логинов с user_id.
+type webhookRoute struct {
+	provider webhook.Provider
+	logins   webhook.LoginMap
 }
 
 // NewHandler создаёт и возвращает HTTP-обработчик c маршрутизатором и зависимостями сервисного слоя.
@@ -48,9 +137,56 @@ func NewHandler(teams TeamService, users UserService, prs PRService, log *slog.L
 		Users: users,
 		PRs:   prs,
 		Log:   log,
+
+		streamsDone: make(chan struct{}),
 	}
 }
 
+// CloseStreams завершает открытые SSE-потоки. http.Server.Shutdown не прерывает
+// активные запросы, а поток сам не заканчивается, поэтому вызов регистрируется через
+// http.Server.RegisterOnShutdown. Повторные вызовы ничего не делают.
+func (h *Handler) CloseStreams() {
+	h.closeStreamsOnce.Do(func() { close(h.streamsDone) })
+}
+
+// EnableSCIM подключает SCIM 2.0 эндпоинты /scim/v2, доступные по Bearer-токену token.
+func (h *Handler) EnableSCIM(svc ProvisioningService, token string) {
+	h.Provisioning = svc
+	h.scimToken = token
+}
+
+// EnableWebhook подключает приём вебхуков хостинга p на /webhooks/<p.Name()>.
+// Авторы PR сопоставляются с user_id через logins.
+func (h *Handler) EnableWebhook(p webhook.Provider, logins webhook.LoginMap) {
+	h.webhooks = append(h.webhooks, webhookRoute{provider: p, logins: logins})
+}
+
+// EnableWebhookSubscriptions подключает управление подписками на исходящие вебхуки
+// (/webhooks/subscriptions) и недоставленными событиями.
+func (h *Handler) EnableWebhookSubscriptions(svc WebhookSubscriptionService) {
+	h.Subscriptions = svc
+}
+
+// EnableReviewStream подключает SSE-поток событий ревью /users/reviewStream.
+func (h *Handler) EnableReviewStream(svc ReviewStreamService) {
+	h.ReviewStream = svc
+}
+
+// EnableJobRuns подключает историю запусков задач планировщика /admin/jobRuns.
+func (h *Handler) EnableJobRuns(svc JobRunService) {
+	h.JobRuns = svc
+}
+
+// EnableCycleTime подключает аналитику времени цикла ревью /stats/cycleTime.
+func (h *Handler) EnableCycleTime(svc CycleTimeService) {
+	h.CycleTime = svc
+}
+
+// EnableDashboard подключает HTML-страницу нагрузки ревьюверов /dashboard.
+func (h *Handler) EnableDashboard(svc DashboardService) {
+	h.Dashboard = svc
+}
+
 // Router настраивает HTTP-маршруты и middleware, включая CORS, и возвращает корневой роутер chi.
 func (h *Handler) Router() http.Handler {
 	r := chi.NewRouter()
@@ -59,7 +195,7 @@ func (h *Handler) Router() http.Handler {
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:7002"},
 		AllowedMethods:   []string{"GET", "POST", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "Last-Event-ID"},
 		ExposedHeaders:   []string{"Link"},
 		AllowCredentials: false,
 		MaxAge:           300,
@@ -71,20 +207,74 @@ func (h *Handler) Router() http.Handler {
 		r.Post("/add", h.handleTeamAdd)
 		r.Get("/get", h.handleTeamGet)
 		r.Post("/deactivate", h.handleMassDeactivate)
+		r.Post("/addMembers", h.handleTeamAddMembers)
+		r.Post("/removeMembers", h.handleTeamRemoveMembers)
+		r.Post("/archive", h.handleTeamArchive)
+		r.Post("/delete", h.handleTeamDelete)
+		r.Post("/move", h.handleTeamMove)
+		r.Get("/descendants", h.handleTeamDescendants)
+		r.Post("/setNotificationChannel", h.handleTeamSetNotificationChannel)
+		r.Post("/setLead", h.handleTeamSetLead)
 	})
 
+	r.Get("/teams", h.handleTeamsList)
+
 	r.Route("/users", func(r chi.Router) {
+		r.Get("/", h.handleUsersList)
 		r.Post("/setIsActive", h.handleUserSetIsActive)
 		r.Get("/getReview", h.handleUserGetReview)
+		r.Get("/getAuthored", h.handleUserGetAuthored)
+		r.Get("/activity", h.handleUserActivity)
+		r.Post("/moveTeam", h.handleUserMoveTeam)
+		r.Post("/setChatHandle", h.handleUserSetChatHandle)
+		r.Post("/setEmailPreferences", h.handleUserSetEmailPreferences)
+		r.Post("/setWorkingHours", h.handleUserSetWorkingHours)
+		if h.ReviewStream != nil {
+			r.Get("/reviewStream", h.handleUserReviewStream)
+		}
 	})
 
+	r.Get("/pullRequests", h.handlePRList)
+
 	r.Route("/pullRequest", func(r chi.Router) {
 		r.Post("/create", h.handlePRCreate)
 		r.Post("/merge", h.handlePRMerge)
 		r.Post("/reassign", h.handlePRReassign)
+		r.Post("/review", h.handlePRReview)
+	})
+
+	r.Route("/admin", func(r chi.Router) {
+		r.Post("/import", h.handleAdminImport)
+		r.Post("/sync", h.handleAdminSync)
+		r.Get("/export", h.handleAdminExport)
+		if h.JobRuns != nil {
+			r.Get("/jobRuns", h.handleAdminJobRuns)
+		}
 	})
 
 	r.Get("/stats", h.handleStats)
+	r.Get("/stats/reviewers", h.handleReviewerStats)
+	r.Get("/stats/teams", h.handleTeamStats)
+	if h.CycleTime != nil {
+		r.Get("/stats/cycleTime", h.handleCycleTime)
+	}
+
+	if h.Dashboard != nil {
+		r.Route("/dashboard", h.dashboardRoutes)
+	}
+
+	if h.Provisioning != nil {
+		r.Route("/scim/v2", h.scimRoutes)
+	}
+
+	r.Route("/webhooks", func(r chi.Router) {
+		if h.Subscriptions != nil {
+			h.subscriptionRoutes(r)
+		}
+		for _, wh := range h.webhooks {
+			r.Post("/"+wh.provider.Name(), h.webhookHandler(wh))
+		}
+	})
 
 	return r
 }
@@ -113,6 +303,7 @@ func (h *Handler) writeError(w http.ResponseWriter, handlerName string, err erro
 	resp := errorResponse{}
 	resp.Error.Code = appErr.Code
 	resp.Error.Message = appErr.Message
+	resp.Error.Details = appErr.Details
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
@@ -0,0 +1,58 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "pull-request-service/internal/model"
+)
+
+// CycleTimeService is an autogenerated mock type for the CycleTimeService type
+type CycleTimeService struct {
+	mock.Mock
+}
+
+// GetCycleTime provides a mock function with given fields: ctx, filter
+func (_m *CycleTimeService) GetCycleTime(ctx context.Context, filter model.CycleTimeFilter) (model.CycleTimeReport, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCycleTime")
+	}
+
+	var r0 model.CycleTimeReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.CycleTimeFilter) (model.CycleTimeReport, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.CycleTimeFilter) model.CycleTimeReport); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(model.CycleTimeReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.CycleTimeFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCycleTimeService creates a new instance of CycleTimeService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCycleTimeService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CycleTimeService {
+	mock := &CycleTimeService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
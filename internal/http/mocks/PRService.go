@@ -15,6 +15,34 @@ type PRService struct {
 	mock.Mock
 }
 
+// ClosePR provides a mock function with given fields: ctx, prID
+func (_m *PRService) ClosePR(ctx context.Context, prID string) (model.PullRequest, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClosePR")
+	}
+
+	var r0 model.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.PullRequest, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.PullRequest); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		r0 = ret.Get(0).(model.PullRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreatePR provides a mock function with given fields: ctx, input
 func (_m *PRService) CreatePR(ctx context.Context, input model.PullRequest) (model.PullRequest, error) {
 	ret := _m.Called(ctx, input)
@@ -73,6 +101,73 @@ func (_m *PRService) ListAssignedToUser(ctx context.Context, userID string) ([]m
 	return r0, r1
 }
 
+// ListAuthoredByUser provides a mock function with given fields: ctx, userID
+func (_m *PRService) ListAuthoredByUser(ctx context.Context, userID string) ([]model.AuthoredPullRequest, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuthoredByUser")
+	}
+
+	var r0 []model.AuthoredPullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.AuthoredPullRequest, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.AuthoredPullRequest); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AuthoredPullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPullRequests provides a mock function with given fields: ctx, filter, cursor
+func (_m *PRService) ListPullRequests(ctx context.Context, filter model.PullRequestFilter, cursor string) ([]model.PullRequest, string, error) {
+	ret := _m.Called(ctx, filter, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPullRequests")
+	}
+
+	var r0 []model.PullRequest
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.PullRequestFilter, string) ([]model.PullRequest, string, error)); ok {
+		return rf(ctx, filter, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.PullRequestFilter, string) []model.PullRequest); ok {
+		r0 = rf(ctx, filter, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.PullRequestFilter, string) string); ok {
+		r1 = rf(ctx, filter, cursor)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.PullRequestFilter, string) error); ok {
+		r2 = rf(ctx, filter, cursor)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // MergePR provides a mock function with given fields: ctx, prID
 func (_m *PRService) MergePR(ctx context.Context, prID string) (model.PullRequest, error) {
 	ret := _m.Called(ctx, prID)
@@ -136,6 +231,62 @@ func (_m *PRService) ReassignReviewer(ctx context.Context, prID string, oldUserI
 	return r0, r1, r2
 }
 
+// ReopenPR provides a mock function with given fields: ctx, prID
+func (_m *PRService) ReopenPR(ctx context.Context, prID string) (model.PullRequest, error) {
+	ret := _m.Called(ctx, prID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReopenPR")
+	}
+
+	var r0 model.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.PullRequest, error)); ok {
+		return rf(ctx, prID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.PullRequest); ok {
+		r0 = rf(ctx, prID)
+	} else {
+		r0 = ret.Get(0).(model.PullRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SubmitReview provides a mock function with given fields: ctx, prID, reviewerID, state
+func (_m *PRService) SubmitReview(ctx context.Context, prID string, reviewerID string, state model.ReviewState) (model.PullRequest, error) {
+	ret := _m.Called(ctx, prID, reviewerID, state)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitReview")
+	}
+
+	var r0 model.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.ReviewState) (model.PullRequest, error)); ok {
+		return rf(ctx, prID, reviewerID, state)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.ReviewState) model.PullRequest); ok {
+		r0 = rf(ctx, prID, reviewerID, state)
+	} else {
+		r0 = ret.Get(0).(model.PullRequest)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, model.ReviewState) error); ok {
+		r1 = rf(ctx, prID, reviewerID, state)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewPRService creates a new instance of PRService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPRService(t interface {
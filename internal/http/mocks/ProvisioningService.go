@@ -0,0 +1,299 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "pull-request-service/internal/model"
+)
+
+// ProvisioningService is an autogenerated mock type for the ProvisioningService type
+type ProvisioningService struct {
+	mock.Mock
+}
+
+// CreateGroup provides a mock function with given fields: ctx, name, userIDs
+func (_m *ProvisioningService) CreateGroup(ctx context.Context, name string, userIDs []string) (model.Team, error) {
+	ret := _m.Called(ctx, name, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGroup")
+	}
+
+	var r0 model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (model.Team, error)); ok {
+		return rf(ctx, name, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) model.Team); ok {
+		r0 = rf(ctx, name, userIDs)
+	} else {
+		r0 = ret.Get(0).(model.Team)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, name, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUser provides a mock function with given fields: ctx, u
+func (_m *ProvisioningService) CreateUser(ctx context.Context, u model.User) (model.User, error) {
+	ret := _m.Called(ctx, u)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.User) (model.User, error)); ok {
+		return rf(ctx, u)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.User) model.User); ok {
+		r0 = rf(ctx, u)
+	} else {
+		r0 = ret.Get(0).(model.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.User) error); ok {
+		r1 = rf(ctx, u)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteGroup provides a mock function with given fields: ctx, name
+func (_m *ProvisioningService) DeleteGroup(ctx context.Context, name string) error {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteGroup")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetGroup provides a mock function with given fields: ctx, name
+func (_m *ProvisioningService) GetGroup(ctx context.Context, name string) (model.Team, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGroup")
+	}
+
+	var r0 model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.Team, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.Team); ok {
+		r0 = rf(ctx, name)
+	} else {
+		r0 = ret.Get(0).(model.Team)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetUser provides a mock function with given fields: ctx, userID
+func (_m *ProvisioningService) GetUser(ctx context.Context, userID string) (model.User, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUser")
+	}
+
+	var r0 model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.User, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.User); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		r0 = ret.Get(0).(model.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGroups provides a mock function with given fields: ctx, name, offset, limit
+func (_m *ProvisioningService) ListGroups(ctx context.Context, name string, offset int, limit int) ([]model.Team, int, error) {
+	ret := _m.Called(ctx, name, offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListGroups")
+	}
+
+	var r0 []model.Team
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]model.Team, int, error)); ok {
+		return rf(ctx, name, offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []model.Team); ok {
+		r0 = rf(ctx, name, offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int); ok {
+		r1 = rf(ctx, name, offset, limit)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, name, offset, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListUsers provides a mock function with given fields: ctx, filter
+func (_m *ProvisioningService) ListUsers(ctx context.Context, filter model.UserFilter) ([]model.User, int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []model.User
+	var r1 int
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserFilter) ([]model.User, int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserFilter) []model.User); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.UserFilter) int); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Get(1).(int)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.UserFilter) error); ok {
+		r2 = rf(ctx, filter)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ReplaceUser provides a mock function with given fields: ctx, u
+func (_m *ProvisioningService) ReplaceUser(ctx context.Context, u model.User) (model.User, []model.ReviewReassignment, error) {
+	ret := _m.Called(ctx, u)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceUser")
+	}
+
+	var r0 model.User
+	var r1 []model.ReviewReassignment
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.User) (model.User, []model.ReviewReassignment, error)); ok {
+		return rf(ctx, u)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.User) model.User); ok {
+		r0 = rf(ctx, u)
+	} else {
+		r0 = ret.Get(0).(model.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.User) []model.ReviewReassignment); ok {
+		r1 = rf(ctx, u)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]model.ReviewReassignment)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.User) error); ok {
+		r2 = rf(ctx, u)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SetGroupMembers provides a mock function with given fields: ctx, name, userIDs
+func (_m *ProvisioningService) SetGroupMembers(ctx context.Context, name string, userIDs []string) (model.Team, error) {
+	ret := _m.Called(ctx, name, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetGroupMembers")
+	}
+
+	var r0 model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (model.Team, error)); ok {
+		return rf(ctx, name, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) model.Team); ok {
+		r0 = rf(ctx, name, userIDs)
+	} else {
+		r0 = ret.Get(0).(model.Team)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, name, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewProvisioningService creates a new instance of ProvisioningService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewProvisioningService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ProvisioningService {
+	mock := &ProvisioningService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,58 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "pull-request-service/internal/model"
+)
+
+// ReviewStreamService is an autogenerated mock type for the ReviewStreamService type
+type ReviewStreamService struct {
+	mock.Mock
+}
+
+// Subscribe provides a mock function with given fields: ctx, userID, lastEventID
+func (_m *ReviewStreamService) Subscribe(ctx context.Context, userID string, lastEventID int64) (model.ReviewSubscription, error) {
+	ret := _m.Called(ctx, userID, lastEventID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Subscribe")
+	}
+
+	var r0 model.ReviewSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) (model.ReviewSubscription, error)); ok {
+		return rf(ctx, userID, lastEventID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) model.ReviewSubscription); ok {
+		r0 = rf(ctx, userID, lastEventID)
+	} else {
+		r0 = ret.Get(0).(model.ReviewSubscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, userID, lastEventID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewReviewStreamService creates a new instance of ReviewStreamService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewReviewStreamService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ReviewStreamService {
+	mock := &ReviewStreamService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
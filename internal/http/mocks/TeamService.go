@@ -15,9 +15,65 @@ type TeamService struct {
 	mock.Mock
 }
 
-// CreateTeam provides a mock function with given fields: ctx, team
-func (_m *TeamService) CreateTeam(ctx context.Context, team model.Team) (model.Team, error) {
-	ret := _m.Called(ctx, team)
+// AddMembers provides a mock function with given fields: ctx, teamName, members
+func (_m *TeamService) AddMembers(ctx context.Context, teamName string, members []model.TeamMember) (model.Team, error) {
+	ret := _m.Called(ctx, teamName, members)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMembers")
+	}
+
+	var r0 model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []model.TeamMember) (model.Team, error)); ok {
+		return rf(ctx, teamName, members)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []model.TeamMember) model.Team); ok {
+		r0 = rf(ctx, teamName, members)
+	} else {
+		r0 = ret.Get(0).(model.Team)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []model.TeamMember) error); ok {
+		r1 = rf(ctx, teamName, members)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ArchiveTeam provides a mock function with given fields: ctx, teamName
+func (_m *TeamService) ArchiveTeam(ctx context.Context, teamName string) (model.Team, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveTeam")
+	}
+
+	var r0 model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (model.Team, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) model.Team); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		r0 = ret.Get(0).(model.Team)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateTeam provides a mock function with given fields: ctx, team, moveExisting
+func (_m *TeamService) CreateTeam(ctx context.Context, team model.Team, moveExisting bool) (model.Team, error) {
+	ret := _m.Called(ctx, team, moveExisting)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateTeam")
@@ -25,17 +81,91 @@ func (_m *TeamService) CreateTeam(ctx context.Context, team model.Team) (model.T
 
 	var r0 model.Team
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, model.Team) (model.Team, error)); ok {
-		return rf(ctx, team)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Team, bool) (model.Team, error)); ok {
+		return rf(ctx, team, moveExisting)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, model.Team) model.Team); ok {
-		r0 = rf(ctx, team)
+	if rf, ok := ret.Get(0).(func(context.Context, model.Team, bool) model.Team); ok {
+		r0 = rf(ctx, team, moveExisting)
 	} else {
 		r0 = ret.Get(0).(model.Team)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, model.Team) error); ok {
-		r1 = rf(ctx, team)
+	if rf, ok := ret.Get(1).(func(context.Context, model.Team, bool) error); ok {
+		r1 = rf(ctx, team, moveExisting)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTeam provides a mock function with given fields: ctx, teamName
+func (_m *TeamService) DeleteTeam(ctx context.Context, teamName string) error {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTeam")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ExportRoster provides a mock function with given fields: ctx
+func (_m *TeamService) ExportRoster(ctx context.Context) (model.Roster, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportRoster")
+	}
+
+	var r0 model.Roster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (model.Roster, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) model.Roster); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(model.Roster)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReviewStats provides a mock function with given fields: ctx, filter
+func (_m *TeamService) GetReviewStats(ctx context.Context, filter model.StatsFilter) (model.ReviewStats, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReviewStats")
+	}
+
+	var r0 model.ReviewStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.StatsFilter) (model.ReviewStats, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.StatsFilter) model.ReviewStats); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(model.ReviewStats)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.StatsFilter) error); ok {
+		r1 = rf(ctx, filter)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -44,23 +174,23 @@ func (_m *TeamService) CreateTeam(ctx context.Context, team model.Team) (model.T
 }
 
 // GetStats provides a mock function with given fields: ctx
-func (_m *TeamService) GetStats(ctx context.Context) (interface{}, error) {
+func (_m *TeamService) GetStats(ctx context.Context) ([]model.StatsDTO, error) {
 	ret := _m.Called(ctx)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetStats")
 	}
 
-	var r0 interface{}
+	var r0 []model.StatsDTO
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) (interface{}, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.StatsDTO, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) interface{}); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) []model.StatsDTO); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(interface{})
+			r0 = ret.Get(0).([]model.StatsDTO)
 		}
 	}
 
@@ -101,17 +231,272 @@ func (_m *TeamService) GetTeam(ctx context.Context, name string) (model.Team, er
 	return r0, r1
 }
 
-// MassDeactivate provides a mock function with given fields: ctx, userIDs
-func (_m *TeamService) MassDeactivate(ctx context.Context, userIDs []string) error {
-	ret := _m.Called(ctx, userIDs)
+// GetTeamStats provides a mock function with given fields: ctx
+func (_m *TeamService) GetTeamStats(ctx context.Context) ([]model.TeamStatsDTO, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTeamStats")
+	}
+
+	var r0 []model.TeamStatsDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.TeamStatsDTO, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.TeamStatsDTO); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TeamStatsDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportRoster provides a mock function with given fields: ctx, roster
+func (_m *TeamService) ImportRoster(ctx context.Context, roster model.Roster) (model.RosterDiff, error) {
+	ret := _m.Called(ctx, roster)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportRoster")
+	}
+
+	var r0 model.RosterDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Roster) (model.RosterDiff, error)); ok {
+		return rf(ctx, roster)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Roster) model.RosterDiff); ok {
+		r0 = rf(ctx, roster)
+	} else {
+		r0 = ret.Get(0).(model.RosterDiff)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Roster) error); ok {
+		r1 = rf(ctx, roster)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDescendants provides a mock function with given fields: ctx, teamName
+func (_m *TeamService) ListDescendants(ctx context.Context, teamName string) ([]model.TeamNode, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDescendants")
+	}
+
+	var r0 []model.TeamNode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.TeamNode, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.TeamNode); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TeamNode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTeams provides a mock function with given fields: ctx, filter, cursor
+func (_m *TeamService) ListTeams(ctx context.Context, filter model.TeamFilter, cursor string) ([]model.TeamSummary, string, error) {
+	ret := _m.Called(ctx, filter, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTeams")
+	}
+
+	var r0 []model.TeamSummary
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TeamFilter, string) ([]model.TeamSummary, string, error)); ok {
+		return rf(ctx, filter, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.TeamFilter, string) []model.TeamSummary); ok {
+		r0 = rf(ctx, filter, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TeamSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.TeamFilter, string) string); ok {
+		r1 = rf(ctx, filter, cursor)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.TeamFilter, string) error); ok {
+		r2 = rf(ctx, filter, cursor)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MassDeactivate provides a mock function with given fields: ctx, userIDs, preview
+func (_m *TeamService) MassDeactivate(ctx context.Context, userIDs []string, preview bool) (model.DeactivationReport, error) {
+	ret := _m.Called(ctx, userIDs, preview)
 
 	if len(ret) == 0 {
 		panic("no return value specified for MassDeactivate")
 	}
 
+	var r0 model.DeactivationReport
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, bool) (model.DeactivationReport, error)); ok {
+		return rf(ctx, userIDs, preview)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, bool) model.DeactivationReport); ok {
+		r0 = rf(ctx, userIDs, preview)
+	} else {
+		r0 = ret.Get(0).(model.DeactivationReport)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, bool) error); ok {
+		r1 = rf(ctx, userIDs, preview)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MoveTeam provides a mock function with given fields: ctx, teamName, parentTeamName
+func (_m *TeamService) MoveTeam(ctx context.Context, teamName string, parentTeamName string) (model.Team, error) {
+	ret := _m.Called(ctx, teamName, parentTeamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveTeam")
+	}
+
+	var r0 model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (model.Team, error)); ok {
+		return rf(ctx, teamName, parentTeamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) model.Team); ok {
+		r0 = rf(ctx, teamName, parentTeamName)
+	} else {
+		r0 = ret.Get(0).(model.Team)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, teamName, parentTeamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MoveUser provides a mock function with given fields: ctx, userID, teamName
+func (_m *TeamService) MoveUser(ctx context.Context, userID string, teamName string) (model.User, error) {
+	ret := _m.Called(ctx, userID, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveUser")
+	}
+
+	var r0 model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (model.User, error)); ok {
+		return rf(ctx, userID, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) model.User); ok {
+		r0 = rf(ctx, userID, teamName)
+	} else {
+		r0 = ret.Get(0).(model.User)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userID, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMembers provides a mock function with given fields: ctx, teamName, userIDs
+func (_m *TeamService) RemoveMembers(ctx context.Context, teamName string, userIDs []string) (model.Team, error) {
+	ret := _m.Called(ctx, teamName, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMembers")
+	}
+
+	var r0 model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (model.Team, error)); ok {
+		return rf(ctx, teamName, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) model.Team); ok {
+		r0 = rf(ctx, teamName, userIDs)
+	} else {
+		r0 = ret.Get(0).(model.Team)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, teamName, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetLead provides a mock function with given fields: ctx, teamName, userID
+func (_m *TeamService) SetLead(ctx context.Context, teamName string, userID string) error {
+	ret := _m.Called(ctx, teamName, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLead")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, teamName, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetNotificationChannel provides a mock function with given fields: ctx, teamName, webhookURL
+func (_m *TeamService) SetNotificationChannel(ctx context.Context, teamName string, webhookURL string) error {
+	ret := _m.Called(ctx, teamName, webhookURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNotificationChannel")
+	}
+
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
-		r0 = rf(ctx, userIDs)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, teamName, webhookURL)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -119,6 +504,34 @@ func (_m *TeamService) MassDeactivate(ctx context.Context, userIDs []string) err
 	return r0
 }
 
+// SyncRoster provides a mock function with given fields: ctx, roster, planOnly
+func (_m *TeamService) SyncRoster(ctx context.Context, roster model.Roster, planOnly bool) (model.RosterDiff, error) {
+	ret := _m.Called(ctx, roster, planOnly)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SyncRoster")
+	}
+
+	var r0 model.RosterDiff
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.Roster, bool) (model.RosterDiff, error)); ok {
+		return rf(ctx, roster, planOnly)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.Roster, bool) model.RosterDiff); ok {
+		r0 = rf(ctx, roster, planOnly)
+	} else {
+		r0 = ret.Get(0).(model.RosterDiff)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.Roster, bool) error); ok {
+		r1 = rf(ctx, roster, planOnly)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTeamService creates a new instance of TeamService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTeamService(t interface {
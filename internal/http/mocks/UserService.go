@@ -15,8 +15,111 @@ type UserService struct {
 	mock.Mock
 }
 
+// ListActivity provides a mock function with given fields: ctx, userID
+func (_m *UserService) ListActivity(ctx context.Context, userID string) ([]model.UserActivityEvent, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActivity")
+	}
+
+	var r0 []model.UserActivityEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.UserActivityEvent, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.UserActivityEvent); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserActivityEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsers provides a mock function with given fields: ctx, filter, cursor
+func (_m *UserService) ListUsers(ctx context.Context, filter model.UserFilter, cursor string) ([]model.User, string, error) {
+	ret := _m.Called(ctx, filter, cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []model.User
+	var r1 string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserFilter, string) ([]model.User, string, error)); ok {
+		return rf(ctx, filter, cursor)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserFilter, string) []model.User); ok {
+		r0 = rf(ctx, filter, cursor)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.UserFilter, string) string); ok {
+		r1 = rf(ctx, filter, cursor)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.UserFilter, string) error); ok {
+		r2 = rf(ctx, filter, cursor)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SetChatHandle provides a mock function with given fields: ctx, userID, handle
+func (_m *UserService) SetChatHandle(ctx context.Context, userID string, handle string) error {
+	ret := _m.Called(ctx, userID, handle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetChatHandle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, handle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetEmailPreferences provides a mock function with given fields: ctx, userID, email, mode
+func (_m *UserService) SetEmailPreferences(ctx context.Context, userID string, email string, mode model.EmailMode) error {
+	ret := _m.Called(ctx, userID, email, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEmailPreferences")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.EmailMode) error); ok {
+		r0 = rf(ctx, userID, email, mode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetIsActive provides a mock function with given fields: ctx, userID, isActive
-func (_m *UserService) SetIsActive(ctx context.Context, userID string, isActive bool) (model.User, error) {
+func (_m *UserService) SetIsActive(ctx context.Context, userID string, isActive bool) (model.User, []model.ReviewReassignment, error) {
 	ret := _m.Called(ctx, userID, isActive)
 
 	if len(ret) == 0 {
@@ -24,8 +127,9 @@ func (_m *UserService) SetIsActive(ctx context.Context, userID string, isActive
 	}
 
 	var r0 model.User
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (model.User, error)); ok {
+	var r1 []model.ReviewReassignment
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (model.User, []model.ReviewReassignment, error)); ok {
 		return rf(ctx, userID, isActive)
 	}
 	if rf, ok := ret.Get(0).(func(context.Context, string, bool) model.User); ok {
@@ -34,13 +138,39 @@ func (_m *UserService) SetIsActive(ctx context.Context, userID string, isActive
 		r0 = ret.Get(0).(model.User)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) []model.ReviewReassignment); ok {
 		r1 = rf(ctx, userID, isActive)
 	} else {
-		r1 = ret.Error(1)
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]model.ReviewReassignment)
+		}
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(context.Context, string, bool) error); ok {
+		r2 = rf(ctx, userID, isActive)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SetWorkingHours provides a mock function with given fields: ctx, userID, hours
+func (_m *UserService) SetWorkingHours(ctx context.Context, userID string, hours *model.WorkingHours) error {
+	ret := _m.Called(ctx, userID, hours)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWorkingHours")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.WorkingHours) error); ok {
+		r0 = rf(ctx, userID, hours)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
 }
 
 // NewUserService creates a new instance of UserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
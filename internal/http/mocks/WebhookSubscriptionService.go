@@ -0,0 +1,154 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	model "pull-request-service/internal/model"
+)
+
+// WebhookSubscriptionService is an autogenerated mock type for the WebhookSubscriptionService type
+type WebhookSubscriptionService struct {
+	mock.Mock
+}
+
+// CreateSubscription provides a mock function with given fields: ctx, sub
+func (_m *WebhookSubscriptionService) CreateSubscription(ctx context.Context, sub model.WebhookSubscription) (model.WebhookSubscription, error) {
+	ret := _m.Called(ctx, sub)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateSubscription")
+	}
+
+	var r0 model.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.WebhookSubscription) (model.WebhookSubscription, error)); ok {
+		return rf(ctx, sub)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.WebhookSubscription) model.WebhookSubscription); ok {
+		r0 = rf(ctx, sub)
+	} else {
+		r0 = ret.Get(0).(model.WebhookSubscription)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.WebhookSubscription) error); ok {
+		r1 = rf(ctx, sub)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSubscription provides a mock function with given fields: ctx, id
+func (_m *WebhookSubscriptionService) DeleteSubscription(ctx context.Context, id int64) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteSubscription")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListDeadLetters provides a mock function with given fields: ctx, limit
+func (_m *WebhookSubscriptionService) ListDeadLetters(ctx context.Context, limit int) ([]model.WebhookDeadLetter, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDeadLetters")
+	}
+
+	var r0 []model.WebhookDeadLetter
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]model.WebhookDeadLetter, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.WebhookDeadLetter); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.WebhookDeadLetter)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSubscriptions provides a mock function with given fields: ctx
+func (_m *WebhookSubscriptionService) ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListSubscriptions")
+	}
+
+	var r0 []model.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.WebhookSubscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.WebhookSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Redeliver provides a mock function with given fields: ctx, deadLetterID
+func (_m *WebhookSubscriptionService) Redeliver(ctx context.Context, deadLetterID int64) error {
+	ret := _m.Called(ctx, deadLetterID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Redeliver")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64) error); ok {
+		r0 = rf(ctx, deadLetterID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewWebhookSubscriptionService creates a new instance of WebhookSubscriptionService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookSubscriptionService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookSubscriptionService {
+	mock := &WebhookSubscriptionService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -27,6 +27,7 @@ func (h *Handler) handlePRCreate(w http.ResponseWriter, r *http.Request) {
 		PullRequestName: req.PullRequestName,
 		AuthorID:        req.AuthorID,
 		Status:          model.StatusOpen,
+		Priority:        model.PullRequestPriority(req.Priority),
 	}
 
 	ctx := r.Context()
@@ -99,3 +100,54 @@ func (h *Handler) handlePRReassign(w http.ResponseWriter, r *http.Request) {
 	}
 	_ = json.NewEncoder(w).Encode(resp)
 }
+
+func (h *Handler) handlePRReview(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "pr_review"
+
+	var req reviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateReviewRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	pr, err := h.PRs.SubmitReview(ctx, req.PullRequestID, req.ReviewerID, model.ReviewState(req.State))
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	resp := prResponse{PR: pr}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) handlePRList(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "pr_list"
+
+	filter, cursor, err := ParseListPullRequestsQuery(r.URL.Query())
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	prs, next, err := h.PRs.ListPullRequests(ctx, filter, cursor)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := listPRsResponse{
+		PullRequests: prs,
+		NextCursor:   next,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
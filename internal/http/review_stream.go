@@ -0,0 +1,126 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/service"
+	"strconv"
+	"time"
+)
+
+const (
+	// sseHeartbeat — период комментариев-пингов, не дающих прокси закрыть простаивающее соединение.
+	sseHeartbeat = 15 * time.Second
+	// sseRetryMillis — через сколько клиенту переподключаться после обрыва.
+	sseRetryMillis = 3000
+)
+
+// handleUserReviewStream отдаёт поток Server-Sent Events об изменениях ревью пользователя:
+// event — вид изменения (assigned, unassigned, pr_merged, review_submitted), data — доменное событие,
+// id — номер публикации, по которому клиент продолжает поток через Last-Event-ID.
+func (h *Handler) handleUserReviewStream(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "user_review_stream"
+
+	userID := r.URL.Query().Get("user_id")
+	if err := ValidateUserIDQuery(userID); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	// EventSource передаёт Last-Event-ID заголовком; query-параметр — для клиентов, которые не умеют его задавать
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		lastID = r.URL.Query().Get("last_event_id")
+	}
+	lastEventID, err := parseLastEventID(lastID)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.writeError(w, handlerName, &service.AppError{
+			Code:    "INTERNAL",
+			Message: "streaming is not supported",
+			Status:  http.StatusInternalServerError,
+		})
+		return
+	}
+
+	ctx := r.Context()
+	sub, err := h.ReviewStream.Subscribe(ctx, userID, lastEventID)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+	defer sub.Close()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	_, _ = fmt.Fprintf(w, "retry: %d\n\n", sseRetryMillis)
+
+	sent := lastEventID
+	send := func(ev model.Event) error {
+		// Событие могло прийти и из журнала, и из брокера — повторно не отправляем
+		if ev.Seq <= sent {
+			return nil
+		}
+		kind := ev.ReviewStreamKind(userID)
+		if kind == "" {
+			return nil
+		}
+		if err := writeSSE(w, ev.Seq, kind, ev); err != nil {
+			return err
+		}
+		sent = ev.Seq
+		return nil
+	}
+
+	for _, ev := range sub.Backlog {
+		if err := send(ev); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-h.streamsDone:
+			// Сервер останавливается: клиент переподключится к другому экземпляру и дочитает журнал
+			return
+		case ev, ok := <-sub.Events:
+			if !ok {
+				// Клиент не успевал читать и отключён брокером: он переподключится и дочитает журнал
+				return
+			}
+			if err := send(ev); err != nil {
+				return
+			}
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": ping\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeSSE пишет одно событие SSE.
+func writeSSE(w io.Writer, id int64, event string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: %s\ndata: %s\n\n", strconv.FormatInt(id, 10), event, payload)
+	return err
+}
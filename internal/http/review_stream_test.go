@@ -0,0 +1,123 @@
+package http_test
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	httpapi "pull-request-service/internal/http"
+	"pull-request-service/internal/http/mocks"
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/service"
+)
+
+func TestHandler_ReviewStream(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	tests := []struct {
+		name           string
+		query          string
+		lastEventID    string
+		closeStreams   bool
+		mockBehavior   func(rs *mocks.ReviewStreamService)
+		expectedStatus int
+		expectedBody   []string
+		unexpectedBody []string
+	}{
+		{
+			name:        "Resume: Backlog then live events, duplicates skipped",
+			query:       "user_id=u1",
+			lastEventID: "4",
+			mockBehavior: func(rs *mocks.ReviewStreamService) {
+				live := make(chan model.Event, 2)
+				live <- model.Event{Seq: 5, ID: "ev-5", Type: model.EventReviewerAssigned, PullRequestID: "pr-1", ReviewerID: "u1"}
+				live <- model.Event{Seq: 7, ID: "ev-7", Type: model.EventReviewerRemoved, PullRequestID: "pr-2", ReviewerID: "u1"}
+				close(live)
+				rs.On("Subscribe", mock.Anything, "u1", int64(4)).Return(model.ReviewSubscription{
+					Backlog: []model.Event{
+						{Seq: 5, ID: "ev-5", Type: model.EventReviewerAssigned, PullRequestID: "pr-1", ReviewerID: "u1"},
+						{Seq: 6, ID: "ev-6", Type: model.EventPRMerged, PullRequestID: "pr-3", Reviewers: []string{"u1", "u2"}},
+					},
+					Events: live,
+					Close:  func() {},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: []string{
+				"retry: 3000\n\n",
+				"id: 5\nevent: assigned\ndata: {\"id\":\"ev-5\"",
+				"id: 6\nevent: pr_merged\n",
+				"id: 7\nevent: unassigned\n",
+			},
+		},
+		{
+			name:         "Success: Stream ends on server shutdown",
+			query:        "user_id=u1",
+			closeStreams: true,
+			mockBehavior: func(rs *mocks.ReviewStreamService) {
+				rs.On("Subscribe", mock.Anything, "u1", int64(0)).Return(model.ReviewSubscription{
+					Backlog: []model.Event{
+						{Seq: 5, ID: "ev-5", Type: model.EventReviewerAssigned, PullRequestID: "pr-1", ReviewerID: "u1"},
+					},
+					Events: make(chan model.Event),
+					Close:  func() {},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   []string{"retry: 3000\n\n", "id: 5\nevent: assigned\n"},
+		},
+		{
+			name:           "Fail: Invalid Last-Event-ID",
+			query:          "user_id=u1",
+			lastEventID:    "abc",
+			mockBehavior:   func(rs *mocks.ReviewStreamService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Fail: Unknown user",
+			query: "user_id=u404",
+			mockBehavior: func(rs *mocks.ReviewStreamService) {
+				rs.On("Subscribe", mock.Anything, "u404", int64(0)).
+					Return(model.ReviewSubscription{}, service.ErrNotFound("user not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := new(mocks.ReviewStreamService)
+			tt.mockBehavior(rs)
+
+			h := httpapi.NewHandler(nil, nil, nil, logger)
+			h.EnableReviewStream(rs)
+			if tt.closeStreams {
+				h.CloseStreams()
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/users/reviewStream?"+tt.query, nil)
+			if tt.lastEventID != "" {
+				req.Header.Set("Last-Event-ID", tt.lastEventID)
+			}
+			w := httptest.NewRecorder()
+			h.Router().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			body := w.Body.String()
+			for _, part := range tt.expectedBody {
+				assert.Contains(t, body, part)
+			}
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+				assert.Equal(t, 1, strings.Count(body, "id: 5\n"), "duplicate from broker must be skipped")
+			}
+			rs.AssertExpectations(t)
+		})
+	}
+}
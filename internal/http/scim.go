@@ -0,0 +1,502 @@
+package http
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"pull-request-service/internal/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxSCIMBody ограничивает размер тела SCIM-запроса.
+const maxSCIMBody = 1 << 20
+
+// scimRoutes регистрирует SCIM 2.0 эндпоинты: пользователи соответствуют users, группы — teams.
+func (h *Handler) scimRoutes(r chi.Router) {
+	r.Use(h.scimAuth)
+
+	r.Get("/ServiceProviderConfig", h.handleSCIMServiceProviderConfig)
+	r.Get("/ResourceTypes", h.handleSCIMResourceTypes)
+
+	r.Route("/Users", func(r chi.Router) {
+		r.Get("/", h.handleSCIMUsersList)
+		r.Post("/", h.handleSCIMUserCreate)
+		r.Get("/{id}", h.handleSCIMUserGet)
+		r.Put("/{id}", h.handleSCIMUserReplace)
+		r.Patch("/{id}", h.handleSCIMUserPatch)
+		r.Delete("/{id}", h.handleSCIMUserDelete)
+	})
+
+	r.Route("/Groups", func(r chi.Router) {
+		r.Get("/", h.handleSCIMGroupsList)
+		r.Post("/", h.handleSCIMGroupCreate)
+		r.Get("/{id}", h.handleSCIMGroupGet)
+		r.Put("/{id}", h.handleSCIMGroupReplace)
+		r.Patch("/{id}", h.handleSCIMGroupPatch)
+		r.Delete("/{id}", h.handleSCIMGroupDelete)
+	})
+}
+
+// scimAuth пропускает только запросы с заголовком Authorization: Bearer <токен SCIM>.
+func (h *Handler) scimAuth(next http.Handler) http.Handler {
+	expected := []byte("Bearer " + h.scimToken)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, expected) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="scim"`)
+			h.writeSCIMError(w, "scim_auth", &scimError{Status: http.StatusUnauthorized, Detail: "invalid or missing bearer token"})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// writeSCIM отвечает ресурсом SCIM с указанным статусом.
+func writeSCIM(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/scim+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeSCIMError отвечает ошибкой в формате RFC 7644 (раздел 3.12).
+// Доменные ошибки сервиса переводятся в близкие по смыслу статусы и scimType.
+func (h *Handler) writeSCIMError(w http.ResponseWriter, handlerName string, err error) {
+	var (
+		se     *scimError
+		appErr *service.AppError
+	)
+	switch {
+	case errors.As(err, &se):
+	case errors.As(err, &appErr):
+		se = &scimError{Status: appErr.Status, Detail: appErr.Message}
+		switch appErr.Code {
+		case "USER_EXISTS", "TEAM_EXISTS", "USERNAME_TAKEN":
+			se.Status, se.ScimType = http.StatusConflict, "uniqueness"
+		case "BAD_REQUEST":
+			se.ScimType = "invalidValue"
+		}
+	default:
+		se = &scimError{Status: http.StatusInternalServerError, Detail: "internal error"}
+	}
+
+	h.Log.Error("handler error",
+		slog.String("handler", handlerName),
+		slog.Int("status", se.Status),
+		slog.String("scim_type", se.ScimType),
+		slog.String("message", se.Detail),
+		slog.Any("err", err),
+	)
+
+	writeSCIM(w, se.Status, scimErrorResponse{
+		Schemas:  []string{scimSchemaError},
+		Status:   strconv.Itoa(se.Status),
+		ScimType: se.ScimType,
+		Detail:   se.Detail,
+	})
+}
+
+// decodeSCIM читает JSON-тело SCIM-запроса в v.
+func decodeSCIM(w http.ResponseWriter, r *http.Request, v any) error {
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSCIMBody)).Decode(v); err != nil {
+		return scimBadRequest("invalidSyntax", "invalid JSON: %v", err)
+	}
+	return nil
+}
+
+// scimID возвращает раскодированный идентификатор ресурса из пути.
+func scimID(r *http.Request) string {
+	id := chi.URLParam(r, "id")
+	if unescaped, err := url.PathUnescape(id); err == nil {
+		return unescaped
+	}
+	return id
+}
+
+// scimWithMembers сообщает, нужно ли отдавать состав группы: провайдеры часто
+// исключают members при сверке, чтобы не тянуть большие группы.
+func scimWithMembers(r *http.Request) bool {
+	for _, attr := range strings.Split(r.URL.Query().Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attr), "members") {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *Handler) handleSCIMServiceProviderConfig(w http.ResponseWriter, _ *http.Request) {
+	writeSCIM(w, http.StatusOK, map[string]any{
+		"schemas":        []string{"urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"},
+		"patch":          map[string]bool{"supported": true},
+		"bulk":           map[string]any{"supported": false, "maxOperations": 0, "maxPayloadSize": 0},
+		"filter":         map[string]any{"supported": true, "maxResults": scimMaxResults},
+		"changePassword": map[string]bool{"supported": false},
+		"sort":           map[string]bool{"supported": false},
+		"etag":           map[string]bool{"supported": false},
+		"authenticationSchemes": []map[string]any{{
+			"type":        "oauthbearertoken",
+			"name":        "Bearer token",
+			"description": "Static bearer token from SCIM_TOKEN",
+			"primary":     true,
+		}},
+	})
+}
+
+func (h *Handler) handleSCIMResourceTypes(w http.ResponseWriter, _ *http.Request) {
+	const schema = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	types := []any{
+		map[string]any{
+			"schemas":  []string{schema},
+			"id":       "User",
+			"name":     "User",
+			"endpoint": "/Users",
+			"schema":   scimSchemaUser,
+			"schemaExtensions": []map[string]any{
+				{"schema": scimSchemaEnterprise, "required": false},
+			},
+		},
+		map[string]any{
+			"schemas":  []string{schema},
+			"id":       "Group",
+			"name":     "Group",
+			"endpoint": "/Groups",
+			"schema":   scimSchemaGroup,
+		},
+	}
+	writeSCIM(w, http.StatusOK, scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: len(types),
+		StartIndex:   1,
+		ItemsPerPage: len(types),
+		Resources:    types,
+	})
+}
+
+func (h *Handler) handleSCIMUsersList(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "scim_users_list"
+
+	q := r.URL.Query()
+	offset, limit, startIndex, err := parseSCIMPaging(q)
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	filter, err := userFilterFromSCIM(q.Get("filter"))
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	filter.Offset, filter.Limit = offset, limit
+
+	ctx := r.Context()
+	users, total, err := h.Provisioning.ListUsers(ctx, filter)
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+
+	base := scimBaseURL(r)
+	resources := make([]any, 0, len(users))
+	for _, u := range users {
+		resources = append(resources, scimUserResource(base, u))
+	}
+	writeSCIM(w, http.StatusOK, scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (h *Handler) handleSCIMUserCreate(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "scim_user_create"
+
+	var req scimUser
+	if err := decodeSCIM(w, r, &req); err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	u, err := req.toModel()
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	if !reUserID.MatchString(u.UserID) {
+		h.writeSCIMError(w, handlerName, scimBadRequest("invalidValue", "externalId (or userName without it) must match pattern u<digits>, e.g. u1"))
+		return
+	}
+
+	ctx := r.Context()
+	user, err := h.Provisioning.CreateUser(ctx, u)
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+
+	res := scimUserResource(scimBaseURL(r), user)
+	w.Header().Set("Location", res.Meta.Location)
+	writeSCIM(w, http.StatusCreated, res)
+}
+
+func (h *Handler) handleSCIMUserGet(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "scim_user_get"
+
+	ctx := r.Context()
+	user, err := h.Provisioning.GetUser(ctx, scimID(r))
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	writeSCIM(w, http.StatusOK, scimUserResource(scimBaseURL(r), user))
+}
+
+func (h *Handler) handleSCIMUserReplace(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "scim_user_replace"
+
+	var req scimUser
+	if err := decodeSCIM(w, r, &req); err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	u, err := req.toModel()
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	u.UserID = scimID(r)
+
+	ctx := r.Context()
+	// Провайдеры без enterprise-расширения не присылают department: команда при этом не меняется
+	if !req.hasDepartment() {
+		current, err := h.Provisioning.GetUser(ctx, u.UserID)
+		if err != nil {
+			h.writeSCIMError(w, handlerName, err)
+			return
+		}
+		u.TeamName = current.TeamName
+	}
+	user, _, err := h.Provisioning.ReplaceUser(ctx, u)
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	writeSCIM(w, http.StatusOK, scimUserResource(scimBaseURL(r), user))
+}
+
+func (h *Handler) handleSCIMUserPatch(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "scim_user_patch"
+
+	var req scimPatchRequest
+	if err := decodeSCIM(w, r, &req); err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	current, err := h.Provisioning.GetUser(ctx, scimID(r))
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+
+	user := current
+	if err := applyUserPatch(&user, req.Operations); err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	if user != current {
+		if user, _, err = h.Provisioning.ReplaceUser(ctx, user); err != nil {
+			h.writeSCIMError(w, handlerName, err)
+			return
+		}
+	}
+	writeSCIM(w, http.StatusOK, scimUserResource(scimBaseURL(r), user))
+}
+
+// handleSCIMUserDelete деактивирует пользователя вместо удаления: на него ссылаются PR
+// и история ревью. Открытые ревью переназначаются так же, как при active=false.
+func (h *Handler) handleSCIMUserDelete(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "scim_user_delete"
+
+	ctx := r.Context()
+	user, err := h.Provisioning.GetUser(ctx, scimID(r))
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	if user.IsActive {
+		user.IsActive = false
+		if _, _, err := h.Provisioning.ReplaceUser(ctx, user); err != nil {
+			h.writeSCIMError(w, handlerName, err)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) handleSCIMGroupsList(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "scim_groups_list"
+
+	q := r.URL.Query()
+	offset, limit, startIndex, err := parseSCIMPaging(q)
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	name, err := groupNameFromSCIM(q.Get("filter"))
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	teams, total, err := h.Provisioning.ListGroups(ctx, name, offset, limit)
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+
+	base, withMembers := scimBaseURL(r), scimWithMembers(r)
+	resources := make([]any, 0, len(teams))
+	for _, t := range teams {
+		resources = append(resources, scimGroupResource(base, t, withMembers))
+	}
+	writeSCIM(w, http.StatusOK, scimListResponse{
+		Schemas:      []string{scimSchemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	})
+}
+
+func (h *Handler) handleSCIMGroupCreate(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "scim_group_create"
+
+	var req scimGroup
+	if err := decodeSCIM(w, r, &req); err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	if strings.TrimSpace(req.DisplayName) == "" {
+		h.writeSCIMError(w, handlerName, scimBadRequest("invalidValue", "displayName is required"))
+		return
+	}
+	ids, err := scimMemberIDs(req.Members)
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	team, err := h.Provisioning.CreateGroup(ctx, req.DisplayName, ids)
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+
+	res := scimGroupResource(scimBaseURL(r), team, true)
+	w.Header().Set("Location", res.Meta.Location)
+	writeSCIM(w, http.StatusCreated, res)
+}
+
+func (h *Handler) handleSCIMGroupGet(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "scim_group_get"
+
+	ctx := r.Context()
+	team, err := h.Provisioning.GetGroup(ctx, scimID(r))
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	writeSCIM(w, http.StatusOK, scimGroupResource(scimBaseURL(r), team, scimWithMembers(r)))
+}
+
+func (h *Handler) handleSCIMGroupReplace(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "scim_group_replace"
+
+	var req scimGroup
+	if err := decodeSCIM(w, r, &req); err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	name := scimID(r)
+	if req.DisplayName != "" && req.DisplayName != name {
+		h.writeSCIMError(w, handlerName, scimBadRequest("mutability", "displayName cannot be changed"))
+		return
+	}
+	ids, err := scimMemberIDs(req.Members)
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	team, err := h.Provisioning.SetGroupMembers(ctx, name, ids)
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	writeSCIM(w, http.StatusOK, scimGroupResource(scimBaseURL(r), team, true))
+}
+
+func (h *Handler) handleSCIMGroupPatch(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "scim_group_patch"
+
+	var req scimPatchRequest
+	if err := decodeSCIM(w, r, &req); err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	team, err := h.Provisioning.GetGroup(ctx, scimID(r))
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+
+	current := make([]string, 0, len(team.Members))
+	for _, m := range team.Members {
+		current = append(current, m.UserID)
+	}
+	ids, err := applyGroupPatch(team.TeamName, current, req.Operations)
+	if err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	if !sameIDs(current, ids) {
+		if team, err = h.Provisioning.SetGroupMembers(ctx, team.TeamName, ids); err != nil {
+			h.writeSCIMError(w, handlerName, err)
+			return
+		}
+	}
+	writeSCIM(w, http.StatusOK, scimGroupResource(scimBaseURL(r), team, true))
+}
+
+func (h *Handler) handleSCIMGroupDelete(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "scim_group_delete"
+
+	ctx := r.Context()
+	if err := h.Provisioning.DeleteGroup(ctx, scimID(r)); err != nil {
+		h.writeSCIMError(w, handlerName, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// sameIDs сообщает, совпадают ли наборы идентификаторов без учёта порядка.
+func sameIDs(a, b []string) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.Sort(a)
+	slices.Sort(b)
+	return slices.Equal(slices.Compact(a), slices.Compact(b))
+}
@@ -0,0 +1,619 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"pull-request-service/internal/model"
+)
+
+// URN схем и сообщений SCIM 2.0 (RFC 7643, RFC 7644).
+const (
+	scimSchemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	scimSchemaEnterprise   = "urn:ietf:params:scim:schemas:extension:enterprise:2.0:User"
+	scimSchemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	scimSchemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	scimSchemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+)
+
+// scimMaxResults ограничивает размер страницы списков SCIM.
+const scimMaxResults = 100
+
+// scimError — ошибка протокола SCIM с кодом scimType из RFC 7644 (invalidFilter, mutability и т.д.).
+type scimError struct {
+	Status   int
+	ScimType string
+	Detail   string
+}
+
+func (e *scimError) Error() string {
+	return e.Detail
+}
+
+// scimBadRequest конструирует ошибку 400 с указанным scimType.
+func scimBadRequest(scimType, format string, args ...any) *scimError {
+	return &scimError{Status: http.StatusBadRequest, ScimType: scimType, Detail: fmt.Sprintf(format, args...)}
+}
+
+type scimErrorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail"`
+}
+
+type scimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Location     string `json:"location,omitempty"`
+}
+
+type scimRef struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+}
+
+// scimEnterprise — атрибуты enterprise-расширения. Department — указатель, чтобы отличать
+// отсутствующий атрибут от пустого значения.
+type scimEnterprise struct {
+	Department *string `json:"department,omitempty"`
+}
+
+// scimBool принимает как JSON-булево, так и строку "true"/"false":
+// некоторые провайдеры присылают active строкой.
+type scimBool bool
+
+func (b *scimBool) UnmarshalJSON(data []byte) error {
+	var v bool
+	if err := json.Unmarshal(data, &v); err == nil {
+		*b = scimBool(v)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return fmt.Errorf("expected boolean")
+	}
+	v, err := strconv.ParseBool(s)
+	if err != nil {
+		return fmt.Errorf("expected boolean, got %q", s)
+	}
+	*b = scimBool(v)
+	return nil
+}
+
+// scimUser — ресурс User. id и externalId совпадают с user_id, userName — с username,
+// команда передаётся атрибутом department enterprise-расширения.
+type scimUser struct {
+	Schemas    []string        `json:"schemas"`
+	ID         string          `json:"id,omitempty"`
+	ExternalID string          `json:"externalId,omitempty"`
+	UserName   string          `json:"userName"`
+	Active     *scimBool       `json:"active,omitempty"`
+	Groups     []scimRef       `json:"groups,omitempty"`
+	Enterprise *scimEnterprise `json:"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User,omitempty"`
+	Meta       *scimMeta       `json:"meta,omitempty"`
+}
+
+// scimGroup — ресурс Group. id и displayName совпадают с именем команды.
+type scimGroup struct {
+	Schemas     []string  `json:"schemas"`
+	ID          string    `json:"id,omitempty"`
+	DisplayName string    `json:"displayName"`
+	Members     []scimRef `json:"members,omitempty"`
+	Meta        *scimMeta `json:"meta,omitempty"`
+}
+
+type scimListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+type scimPatchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []scimPatchOpItem `json:"Operations"`
+}
+
+type scimPatchOpItem struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// scimBaseURL возвращает абсолютный адрес корня SCIM для ссылок в meta.location.
+func scimBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if p := r.Header.Get("X-Forwarded-Proto"); p != "" {
+		scheme = p
+	}
+	return scheme + "://" + r.Host + "/scim/v2"
+}
+
+func scimUserResource(base string, u model.User) scimUser {
+	active := scimBool(u.IsActive)
+	res := scimUser{
+		Schemas:    []string{scimSchemaUser},
+		ID:         u.UserID,
+		ExternalID: u.UserID,
+		UserName:   u.Username,
+		Active:     &active,
+		Meta: &scimMeta{
+			ResourceType: "User",
+			Location:     base + "/Users/" + url.PathEscape(u.UserID),
+		},
+	}
+	if u.TeamName != "" {
+		res.Schemas = append(res.Schemas, scimSchemaEnterprise)
+		team := u.TeamName
+		res.Enterprise = &scimEnterprise{Department: &team}
+		res.Groups = []scimRef{{
+			Value:   u.TeamName,
+			Display: u.TeamName,
+			Ref:     base + "/Groups/" + url.PathEscape(u.TeamName),
+		}}
+	}
+	return res
+}
+
+func scimGroupResource(base string, t model.Team, withMembers bool) scimGroup {
+	res := scimGroup{
+		Schemas:     []string{scimSchemaGroup},
+		ID:          t.TeamName,
+		DisplayName: t.TeamName,
+		Meta: &scimMeta{
+			ResourceType: "Group",
+			Location:     base + "/Groups/" + url.PathEscape(t.TeamName),
+		},
+	}
+	if withMembers {
+		res.Members = make([]scimRef, 0, len(t.Members))
+		for _, m := range t.Members {
+			res.Members = append(res.Members, scimRef{
+				Value:   m.UserID,
+				Display: m.Username,
+				Ref:     base + "/Users/" + url.PathEscape(m.UserID),
+			})
+		}
+	}
+	return res
+}
+
+// toModel переводит тело POST/PUT /Users в пользователя. user_id берётся из externalId,
+// а без него — из userName. Отсутствующий active означает активного пользователя,
+// отсутствующий department — пользователя без команды (для PUT см. hasDepartment).
+func (u scimUser) toModel() (model.User, error) {
+	if strings.TrimSpace(u.UserName) == "" {
+		return model.User{}, scimBadRequest("invalidValue", "userName is required")
+	}
+	user := model.User{
+		UserID:   u.ExternalID,
+		Username: u.UserName,
+		IsActive: true,
+	}
+	if user.UserID == "" {
+		user.UserID = u.UserName
+	}
+	if u.Active != nil {
+		user.IsActive = bool(*u.Active)
+	}
+	if u.hasDepartment() {
+		user.TeamName = *u.Enterprise.Department
+	}
+	return user, nil
+}
+
+// hasDepartment сообщает, передан ли атрибут department enterprise-расширения.
+func (u scimUser) hasDepartment() bool {
+	return u.Enterprise != nil && u.Enterprise.Department != nil
+}
+
+// scimMemberIDs возвращает user_id участников из ссылок members.
+func scimMemberIDs(members []scimRef) ([]string, error) {
+	ids := make([]string, 0, len(members))
+	for _, m := range members {
+		if m.Value == "" {
+			return nil, scimBadRequest("invalidValue", "member value is required")
+		}
+		ids = append(ids, m.Value)
+	}
+	return ids, nil
+}
+
+// parseSCIMPaging разбирает startIndex (с единицы) и count и возвращает offset, limit и startIndex.
+// Значения вне допустимого диапазона приводятся к ближайшим допустимым, как требует RFC 7644.
+func parseSCIMPaging(q url.Values) (offset, limit, startIndex int, err error) {
+	startIndex, limit = 1, scimMaxResults
+	if v := q.Get("startIndex"); v != "" {
+		if startIndex, err = strconv.Atoi(v); err != nil {
+			return 0, 0, 0, scimBadRequest("invalidValue", "startIndex must be an integer")
+		}
+		if startIndex < 1 {
+			startIndex = 1
+		}
+	}
+	if v := q.Get("count"); v != "" {
+		if limit, err = strconv.Atoi(v); err != nil {
+			return 0, 0, 0, scimBadRequest("invalidValue", "count must be an integer")
+		}
+		limit = min(max(limit, 0), scimMaxResults)
+	}
+	return startIndex - 1, limit, startIndex, nil
+}
+
+// scimCondition — одно сравнение фильтра вида `attr op value`.
+type scimCondition struct {
+	Attr  string
+	Op    string
+	Value any
+}
+
+// parseSCIMFilter разбирает подмножество фильтров RFC 7644: сравнения eq и sw,
+// объединённые через and. Имена атрибутов и операторов приводятся к нижнему регистру.
+func parseSCIMFilter(filter string) ([]scimCondition, error) {
+	tokens, err := scimFilterTokens(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	conds := make([]scimCondition, 0, 2)
+	for i := 0; i < len(tokens); {
+		if i+1 >= len(tokens) {
+			return nil, scimBadRequest("invalidFilter", "incomplete filter expression")
+		}
+		cond := scimCondition{Attr: strings.ToLower(tokens[i]), Op: strings.ToLower(tokens[i+1])}
+		switch cond.Op {
+		case "eq", "sw":
+			if i+2 >= len(tokens) {
+				return nil, scimBadRequest("invalidFilter", "missing value for %s", tokens[i])
+			}
+			if cond.Value, err = scimFilterValue(tokens[i+2]); err != nil {
+				return nil, err
+			}
+			i += 3
+		default:
+			return nil, scimBadRequest("invalidFilter", "unsupported operator %q", tokens[i+1])
+		}
+		conds = append(conds, cond)
+
+		if i < len(tokens) {
+			if !strings.EqualFold(tokens[i], "and") {
+				return nil, scimBadRequest("invalidFilter", "only 'and' is supported between expressions")
+			}
+			i++
+			if i == len(tokens) {
+				return nil, scimBadRequest("invalidFilter", "incomplete filter expression")
+			}
+		}
+	}
+	return conds, nil
+}
+
+// scimFilterTokens делит фильтр по пробелам, сохраняя строки в кавычках целиком.
+func scimFilterTokens(filter string) ([]string, error) {
+	tokens := make([]string, 0, 4)
+	for i := 0; i < len(filter); {
+		switch c := filter[i]; {
+		case c == ' ':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			return nil, scimBadRequest("invalidFilter", "grouping is not supported")
+		case c == '"':
+			j := i + 1
+			for ; j < len(filter) && filter[j] != '"'; j++ {
+				if filter[j] == '\\' {
+					j++
+				}
+			}
+			if j >= len(filter) {
+				return nil, scimBadRequest("invalidFilter", "unterminated string")
+			}
+			tokens = append(tokens, filter[i:j+1])
+			i = j + 1
+		default:
+			j := i
+			for j < len(filter) && filter[j] != ' ' {
+				j++
+			}
+			tokens = append(tokens, filter[i:j])
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+func scimFilterValue(token string) (any, error) {
+	switch strings.ToLower(token) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	var s string
+	if err := json.Unmarshal([]byte(token), &s); err != nil {
+		return nil, scimBadRequest("invalidFilter", "unsupported value %s", token)
+	}
+	return s, nil
+}
+
+// scimAttr приводит путь атрибута к короткому имени в нижнем регистре, убирая URN базовой схемы.
+func scimAttr(path, schema string) string {
+	path = strings.ToLower(strings.TrimSpace(path))
+	return strings.TrimPrefix(path, strings.ToLower(schema)+":")
+}
+
+// scimDepartmentAttr — полный путь атрибута department enterprise-расширения в нижнем регистре.
+var scimDepartmentAttr = strings.ToLower(scimSchemaEnterprise) + ":department"
+
+// userFilterFromSCIM переводит фильтр /Users в model.UserFilter.
+// Поддерживаются userName (eq, sw), id и externalId (eq), active (eq)
+// и команда — department enterprise-расширения или groups.value (eq).
+func userFilterFromSCIM(filter string) (model.UserFilter, error) {
+	conds, err := parseSCIMFilter(filter)
+	if err != nil {
+		return model.UserFilter{}, err
+	}
+
+	var f model.UserFilter
+	for _, c := range conds {
+		attr := scimAttr(c.Attr, scimSchemaUser)
+		s, isString := c.Value.(string)
+		switch {
+		case attr == "username" && c.Op == "eq" && isString:
+			f.Username = s
+		case attr == "username" && c.Op == "sw" && isString:
+			f.UsernamePrefix = s
+		case (attr == "id" || attr == "externalid") && c.Op == "eq" && isString:
+			f.UserID = s
+		case (attr == scimDepartmentAttr || attr == "groups.value") && c.Op == "eq" && isString:
+			f.TeamName = s
+		case attr == "active" && c.Op == "eq":
+			active, ok := c.Value.(bool)
+			if !ok {
+				return model.UserFilter{}, scimBadRequest("invalidFilter", "active must be compared with a boolean")
+			}
+			f.IsActive = &active
+		default:
+			return model.UserFilter{}, scimBadRequest("invalidFilter", "unsupported filter on %s", c.Attr)
+		}
+	}
+	return f, nil
+}
+
+// groupNameFromSCIM извлекает имя команды из фильтра /Groups: displayName, id или externalId (eq).
+func groupNameFromSCIM(filter string) (string, error) {
+	conds, err := parseSCIMFilter(filter)
+	if err != nil {
+		return "", err
+	}
+
+	name := ""
+	for _, c := range conds {
+		attr := scimAttr(c.Attr, scimSchemaGroup)
+		s, isString := c.Value.(string)
+		if (attr != "displayname" && attr != "id" && attr != "externalid") || c.Op != "eq" || !isString {
+			return "", scimBadRequest("invalidFilter", "unsupported filter on %s", c.Attr)
+		}
+		name = s
+	}
+	return name, nil
+}
+
+// applyUserPatch применяет операции PATCH к пользователю. Изменять можно userName, active
+// и команду (department enterprise-расширения); атрибуты, которые сервис не хранит, игнорируются.
+func applyUserPatch(u *model.User, ops []scimPatchOpItem) error {
+	for _, op := range ops {
+		kind := strings.ToLower(op.Op)
+		if kind != "add" && kind != "replace" && kind != "remove" {
+			return scimBadRequest("invalidSyntax", "unsupported op %q", op.Op)
+		}
+		remove := kind == "remove"
+
+		if op.Path != "" {
+			if err := applyUserAttr(u, scimAttr(op.Path, scimSchemaUser), op.Value, remove); err != nil {
+				return err
+			}
+			continue
+		}
+		if remove {
+			return scimBadRequest("noTarget", "remove requires a path")
+		}
+
+		var attrs map[string]json.RawMessage
+		if err := json.Unmarshal(op.Value, &attrs); err != nil {
+			return scimBadRequest("invalidValue", "value must be an object when path is omitted")
+		}
+		for name, value := range attrs {
+			if err := applyUserAttr(u, scimAttr(name, scimSchemaUser), value, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func applyUserAttr(u *model.User, attr string, value json.RawMessage, remove bool) error {
+	switch attr {
+	case "username":
+		if remove {
+			return scimBadRequest("mutability", "userName cannot be removed")
+		}
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil || strings.TrimSpace(s) == "" {
+			return scimBadRequest("invalidValue", "userName must be a non-empty string")
+		}
+		u.Username = s
+	case "active":
+		if remove {
+			return scimBadRequest("mutability", "active cannot be removed")
+		}
+		var b scimBool
+		if err := json.Unmarshal(value, &b); err != nil {
+			return scimBadRequest("invalidValue", "active must be a boolean")
+		}
+		u.IsActive = bool(b)
+	case scimDepartmentAttr:
+		if remove {
+			u.TeamName = ""
+			return nil
+		}
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			return scimBadRequest("invalidValue", "department must be a string")
+		}
+		u.TeamName = s
+	case strings.ToLower(scimSchemaEnterprise):
+		if remove {
+			u.TeamName = ""
+			return nil
+		}
+		var ext map[string]json.RawMessage
+		if err := json.Unmarshal(value, &ext); err != nil {
+			return scimBadRequest("invalidValue", "enterprise extension must be an object")
+		}
+		for name, v := range ext {
+			if err := applyUserAttr(u, attr+":"+strings.ToLower(name), v, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// applyGroupPatch применяет операции PATCH к составу команды name и возвращает новый список user_id.
+// displayName менять нельзя: имя команды служит её идентификатором.
+func applyGroupPatch(name string, members []string, ops []scimPatchOpItem) ([]string, error) {
+	for _, op := range ops {
+		kind := strings.ToLower(op.Op)
+		if kind != "add" && kind != "replace" && kind != "remove" {
+			return nil, scimBadRequest("invalidSyntax", "unsupported op %q", op.Op)
+		}
+
+		// members[value eq "u1"] — удаление участника по фильтру значения внутри пути
+		if raw := strings.TrimSpace(op.Path); len(raw) > len("members[") && strings.EqualFold(raw[:len("members[")], "members[") {
+			if kind != "remove" || !strings.HasSuffix(raw, "]") {
+				return nil, scimBadRequest("invalidPath", "unsupported path %q", op.Path)
+			}
+			ids, err := scimMemberFilterIDs(raw[len("members[") : len(raw)-1])
+			if err != nil {
+				return nil, err
+			}
+			members = removeIDs(members, ids)
+			continue
+		}
+
+		path := scimAttr(op.Path, scimSchemaGroup)
+		if path == "" {
+			if kind == "remove" {
+				return nil, scimBadRequest("noTarget", "remove requires a path")
+			}
+			var attrs map[string]json.RawMessage
+			if err := json.Unmarshal(op.Value, &attrs); err != nil {
+				return nil, scimBadRequest("invalidValue", "value must be an object when path is omitted")
+			}
+			for attr, value := range attrs {
+				var err error
+				if members, err = applyGroupAttr(name, members, kind, scimAttr(attr, scimSchemaGroup), value); err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		var err error
+		if members, err = applyGroupAttr(name, members, kind, path, op.Value); err != nil {
+			return nil, err
+		}
+	}
+	return members, nil
+}
+
+func applyGroupAttr(name string, members []string, kind, path string, value json.RawMessage) ([]string, error) {
+	switch {
+	case path == "displayname":
+		var s string
+		if kind == "remove" || json.Unmarshal(value, &s) != nil || s != name {
+			return nil, scimBadRequest("mutability", "displayName cannot be changed")
+		}
+		return members, nil
+	case path == "members":
+		var refs []scimRef
+		if len(value) > 0 && string(value) != "null" {
+			if err := json.Unmarshal(value, &refs); err != nil {
+				return nil, scimBadRequest("invalidValue", "members must be an array")
+			}
+		}
+		ids, err := scimMemberIDs(refs)
+		if err != nil {
+			return nil, err
+		}
+		switch kind {
+		case "add":
+			return appendMissing(members, ids), nil
+		case "replace":
+			return appendMissing(nil, ids), nil
+		}
+		if len(ids) == 0 {
+			return []string{}, nil
+		}
+		return removeIDs(members, ids), nil
+	}
+	// Прочие атрибуты (externalId, id) сервис не хранит
+	return members, nil
+}
+
+// scimMemberFilterIDs извлекает user_id из фильтра вида `value eq "u1"` в пути members[...].
+func scimMemberFilterIDs(expr string) ([]string, error) {
+	conds, err := parseSCIMFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(conds))
+	for _, c := range conds {
+		s, ok := c.Value.(string)
+		if c.Attr != "value" || c.Op != "eq" || !ok {
+			return nil, scimBadRequest("invalidPath", `only members[value eq "..."] is supported`)
+		}
+		ids = append(ids, s)
+	}
+	return ids, nil
+}
+
+// appendMissing добавляет к ids те из extra, которых в нём ещё нет.
+func appendMissing(ids, extra []string) []string {
+	seen := make(map[string]struct{}, len(ids)+len(extra))
+	out := make([]string, 0, len(ids)+len(extra))
+	for _, id := range append(append([]string{}, ids...), extra...) {
+		if _, ok := seen[id]; ok {
+			continue
+		}
+		seen[id] = struct{}{}
+		out = append(out, id)
+	}
+	return out
+}
+
+// removeIDs возвращает ids без элементов drop.
+func removeIDs(ids, drop []string) []string {
+	skip := make(map[string]struct{}, len(drop))
+	for _, id := range drop {
+		skip[id] = struct{}{}
+	}
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if _, ok := skip[id]; !ok {
+			out = append(out, id)
+		}
+	}
+	return out
+}
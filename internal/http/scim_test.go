@@ -0,0 +1,358 @@
+package http_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	httpapi "pull-request-service/internal/http"
+	"pull-request-service/internal/http/mocks"
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/service"
+)
+
+const scimTestToken = "secret"
+
+// scimClient — минимальный SCIM-клиент, который ходит в поднятый локально сервер
+// так же, как это делает провайдер учётных записей.
+type scimClient struct {
+	t     *testing.T
+	base  string
+	token string
+}
+
+func newSCIMServer(t *testing.T, ps *mocks.ProvisioningService) *scimClient {
+	t.Helper()
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+
+	h := httpapi.NewHandler(new(mocks.TeamService), new(mocks.UserService), new(mocks.PRService), logger)
+	h.EnableSCIM(ps, scimTestToken)
+
+	srv := httptest.NewServer(h.Router())
+	t.Cleanup(srv.Close)
+	return &scimClient{t: t, base: srv.URL + "/scim/v2", token: scimTestToken}
+}
+
+// do отправляет запрос и возвращает статус и разобранное тело ответа (nil для пустого).
+func (c *scimClient) do(method, path, body string) (int, map[string]any) {
+	c.t.Helper()
+
+	req, err := http.NewRequest(method, c.base+path, bytes.NewBufferString(body))
+	require.NoError(c.t, err)
+	req.Header.Set("Content-Type", "application/scim+json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(c.t, err)
+	defer resp.Body.Close()
+
+	var out map[string]any
+	if resp.StatusCode != http.StatusNoContent {
+		require.NoError(c.t, json.NewDecoder(resp.Body).Decode(&out))
+		assert.Equal(c.t, "application/scim+json", resp.Header.Get("Content-Type"))
+	}
+	return resp.StatusCode, out
+}
+
+func TestSCIM_Users(t *testing.T) {
+	alice := model.User{UserID: "u1", Username: "alice", TeamName: "backend", IsActive: true}
+
+	tests := []struct {
+		name         string
+		token        string
+		method       string
+		path         string
+		body         string
+		mockBehavior func(ps *mocks.ProvisioningService)
+		wantStatus   int
+		check        func(t *testing.T, resp map[string]any)
+	}{
+		{
+			name:         "Unauthorized: Wrong token",
+			token:        "wrong",
+			method:       http.MethodGet,
+			path:         "/Users",
+			mockBehavior: func(ps *mocks.ProvisioningService) {},
+			wantStatus:   http.StatusUnauthorized,
+		},
+		{
+			name:   "Create: externalId becomes user_id, department becomes team",
+			method: http.MethodPost,
+			path:   "/Users",
+			body: `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"externalId":"u1","userName":"alice",
+				"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User":{"department":"backend"}}`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				ps.On("CreateUser", mock.Anything, alice).Return(alice, nil)
+			},
+			wantStatus: http.StatusCreated,
+			check: func(t *testing.T, resp map[string]any) {
+				assert.Equal(t, "u1", resp["id"])
+				assert.Equal(t, true, resp["active"])
+				assert.Equal(t, "backend", resp["groups"].([]any)[0].(map[string]any)["value"])
+			},
+		},
+		{
+			name:   "Create: Duplicate is a uniqueness conflict",
+			method: http.MethodPost,
+			path:   "/Users",
+			body:   `{"userName":"u1","active":false}`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				ps.On("CreateUser", mock.Anything, model.User{UserID: "u1", Username: "u1"}).
+					Return(model.User{}, service.ErrDomain("USER_EXISTS", "user_id already exists"))
+			},
+			wantStatus: http.StatusConflict,
+			check: func(t *testing.T, resp map[string]any) {
+				assert.Equal(t, "uniqueness", resp["scimType"])
+			},
+		},
+		{
+			name:         "Create: user_id outside u<digits> is rejected",
+			method:       http.MethodPost,
+			path:         "/Users",
+			body:         `{"userName":"alice@example.com"}`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {},
+			wantStatus:   http.StatusBadRequest,
+			check: func(t *testing.T, resp map[string]any) {
+				assert.Equal(t, "invalidValue", resp["scimType"])
+			},
+		},
+		{
+			name:   "Replace: Missing department keeps team",
+			method: http.MethodPut,
+			path:   "/Users/u1",
+			body:   `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"alice","active":true}`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				ps.On("GetUser", mock.Anything, "u1").Return(alice, nil)
+				ps.On("ReplaceUser", mock.Anything, alice).Return(alice, []model.ReviewReassignment{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "Replace: Empty department leaves team",
+			method: http.MethodPut,
+			path:   "/Users/u1",
+			body: `{"userName":"alice",
+				"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User":{"department":""}}`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				teamless := alice
+				teamless.TeamName = ""
+				ps.On("ReplaceUser", mock.Anything, teamless).Return(teamless, []model.ReviewReassignment{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "List: Filter and paging are passed to service",
+			method: http.MethodGet,
+			path:   `/Users?filter=userName+eq+%22Alice%22+and+active+eq+true&startIndex=3&count=2`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				active := true
+				ps.On("ListUsers", mock.Anything, model.UserFilter{Username: "Alice", IsActive: &active, Offset: 2, Limit: 2}).
+					Return([]model.User{alice}, 3, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, resp map[string]any) {
+				assert.EqualValues(t, 3, resp["totalResults"])
+				assert.EqualValues(t, 3, resp["startIndex"])
+				assert.EqualValues(t, 1, resp["itemsPerPage"])
+			},
+		},
+		{
+			name:         "List: Unsupported operator",
+			method:       http.MethodGet,
+			path:         `/Users?filter=userName+co+%22al%22`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {},
+			wantStatus:   http.StatusBadRequest,
+			check: func(t *testing.T, resp map[string]any) {
+				assert.Equal(t, "invalidFilter", resp["scimType"])
+			},
+		},
+		{
+			name:   "Patch: active=false as string deactivates",
+			method: http.MethodPatch,
+			path:   "/Users/u1",
+			body:   `{"Operations":[{"op":"Replace","path":"active","value":"False"}]}`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				ps.On("GetUser", mock.Anything, "u1").Return(alice, nil)
+				inactive := alice
+				inactive.IsActive = false
+				ps.On("ReplaceUser", mock.Anything, inactive).Return(inactive, []model.ReviewReassignment{}, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, resp map[string]any) {
+				assert.Equal(t, false, resp["active"])
+			},
+		},
+		{
+			name:   "Patch: Value object without path moves team",
+			method: http.MethodPatch,
+			path:   "/Users/u1",
+			body:   `{"Operations":[{"op":"replace","value":{"urn:ietf:params:scim:schemas:extension:enterprise:2.0:User:department":"frontend","name.givenName":"Alice"}}]}`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				ps.On("GetUser", mock.Anything, "u1").Return(alice, nil)
+				moved := alice
+				moved.TeamName = "frontend"
+				ps.On("ReplaceUser", mock.Anything, moved).Return(moved, []model.ReviewReassignment{}, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "Patch: No changes skip the update",
+			method: http.MethodPatch,
+			path:   "/Users/u1",
+			body:   `{"Operations":[{"op":"replace","path":"userName","value":"alice"}]}`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				ps.On("GetUser", mock.Anything, "u1").Return(alice, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "Delete: Deactivates instead of deleting",
+			method: http.MethodDelete,
+			path:   "/Users/u1",
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				ps.On("GetUser", mock.Anything, "u1").Return(alice, nil)
+				inactive := alice
+				inactive.IsActive = false
+				ps.On("ReplaceUser", mock.Anything, inactive).Return(inactive, []model.ReviewReassignment{}, nil)
+			},
+			wantStatus: http.StatusNoContent,
+		},
+		{
+			name:   "Get: Not found",
+			method: http.MethodGet,
+			path:   "/Users/u404",
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				ps.On("GetUser", mock.Anything, "u404").Return(model.User{}, service.ErrNotFound("user not found"))
+			},
+			wantStatus: http.StatusNotFound,
+			check: func(t *testing.T, resp map[string]any) {
+				assert.Equal(t, "404", resp["status"])
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := new(mocks.ProvisioningService)
+			tt.mockBehavior(ps)
+
+			client := newSCIMServer(t, ps)
+			if tt.token != "" {
+				client.token = tt.token
+			}
+			status, resp := client.do(tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.check != nil {
+				tt.check(t, resp)
+			}
+			ps.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSCIM_Groups(t *testing.T) {
+	backend := model.Team{TeamName: "backend", Members: []model.TeamMember{
+		{UserID: "u1", Username: "alice", IsActive: true},
+		{UserID: "u2", Username: "bob", IsActive: true},
+	}}
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		body         string
+		mockBehavior func(ps *mocks.ProvisioningService)
+		wantStatus   int
+		check        func(t *testing.T, resp map[string]any)
+	}{
+		{
+			name:   "Create: Members are resolved by id",
+			method: http.MethodPost,
+			path:   "/Groups",
+			body:   `{"displayName":"backend","members":[{"value":"u1"},{"value":"u2"}]}`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				ps.On("CreateGroup", mock.Anything, "backend", []string{"u1", "u2"}).Return(backend, nil)
+			},
+			wantStatus: http.StatusCreated,
+			check: func(t *testing.T, resp map[string]any) {
+				assert.Len(t, resp["members"], 2)
+			},
+		},
+		{
+			name:   "List: displayName filter, members excluded",
+			method: http.MethodGet,
+			path:   `/Groups?filter=displayName+eq+%22backend%22&excludedAttributes=members`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				ps.On("ListGroups", mock.Anything, "backend", 0, 100).Return([]model.Team{backend}, 1, nil)
+			},
+			wantStatus: http.StatusOK,
+			check: func(t *testing.T, resp map[string]any) {
+				group := resp["Resources"].([]any)[0].(map[string]any)
+				assert.Equal(t, "backend", group["id"])
+				assert.NotContains(t, group, "members")
+			},
+		},
+		{
+			name:   "Patch: Add and remove members",
+			method: http.MethodPatch,
+			path:   "/Groups/backend",
+			body: `{"Operations":[
+				{"op":"add","path":"members","value":[{"value":"u3"}]},
+				{"op":"remove","path":"members[value eq \"u1\"]"}]}`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				ps.On("GetGroup", mock.Anything, "backend").Return(backend, nil)
+				ps.On("SetGroupMembers", mock.Anything, "backend", []string{"u2", "u3"}).Return(backend, nil)
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:   "Patch: Rename is rejected",
+			method: http.MethodPatch,
+			path:   "/Groups/backend",
+			body:   `{"Operations":[{"op":"replace","path":"displayName","value":"platform"}]}`,
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				ps.On("GetGroup", mock.Anything, "backend").Return(backend, nil)
+			},
+			wantStatus: http.StatusBadRequest,
+			check: func(t *testing.T, resp map[string]any) {
+				assert.Equal(t, "mutability", resp["scimType"])
+			},
+		},
+		{
+			name:   "Delete: Team with sub-teams",
+			method: http.MethodDelete,
+			path:   "/Groups/backend",
+			mockBehavior: func(ps *mocks.ProvisioningService) {
+				ps.On("DeleteGroup", mock.Anything, "backend").
+					Return(service.ErrDomain("TEAM_NOT_EMPTY", "team still has members or sub-teams"))
+			},
+			wantStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ps := new(mocks.ProvisioningService)
+			tt.mockBehavior(ps)
+
+			status, resp := newSCIMServer(t, ps).do(tt.method, tt.path, tt.body)
+
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.check != nil {
+				tt.check(t, resp)
+			}
+			ps.AssertExpectations(t)
+		})
+	}
+}
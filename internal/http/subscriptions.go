@@ -0,0 +1,134 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/service"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// subscriptionRoutes регистрирует управление подписками на исходящие вебхуки и dead letters.
+func (h *Handler) subscriptionRoutes(r chi.Router) {
+	r.Get("/subscriptions", h.handleSubscriptionsList)
+	r.Post("/subscriptions", h.handleSubscriptionCreate)
+	r.Post("/subscriptions/delete", h.handleSubscriptionDelete)
+	r.Get("/deadLetters", h.handleDeadLettersList)
+	r.Post("/redeliver", h.handleRedeliver)
+}
+
+func (h *Handler) handleSubscriptionCreate(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "subscription_create"
+
+	var req createSubscriptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateCreateSubscriptionRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	sub := model.WebhookSubscription{URL: req.URL, Secret: req.Secret}
+	for _, t := range req.EventTypes {
+		sub.EventTypes = append(sub.EventTypes, model.EventType(t))
+	}
+
+	ctx := r.Context()
+	created, err := h.Subscriptions.CreateSubscription(ctx, sub)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(subscriptionResponse{Subscription: created})
+}
+
+func (h *Handler) handleSubscriptionsList(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "subscriptions_list"
+
+	ctx := r.Context()
+	subs, err := h.Subscriptions.ListSubscriptions(ctx)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(listSubscriptionsResponse{Subscriptions: subs})
+}
+
+func (h *Handler) handleSubscriptionDelete(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "subscription_delete"
+
+	var req subscriptionIDRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateSubscriptionIDRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	if err := h.Subscriptions.DeleteSubscription(ctx, req.ID); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`{"status":"ok"}`))
+}
+
+func (h *Handler) handleDeadLettersList(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "dead_letters_list"
+
+	limit, err := parseLimitQuery(r.URL.Query().Get("limit"))
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	letters, err := h.Subscriptions.ListDeadLetters(ctx, limit)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(listDeadLettersResponse{DeadLetters: letters})
+}
+
+func (h *Handler) handleRedeliver(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "webhook_redeliver"
+
+	var req redeliverRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateRedeliverRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	if err := h.Subscriptions.Redeliver(ctx, req.DeadLetterID); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	_, _ = w.Write([]byte(`{"status":"ok"}`))
+}
@@ -7,12 +7,14 @@ import (
 	"net/http/httptest"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	httpapi "pull-request-service/internal/http"
 	"pull-request-service/internal/http/mocks"
+	"pull-request-service/internal/model"
 	"pull-request-service/internal/service"
 )
 
@@ -29,7 +31,17 @@ func TestHandler_MassDeactivate(t *testing.T) {
 			name: "Success",
 			body: `{"user_ids": ["u1", "u2"]}`,
 			mockBehavior: func(ts *mocks.TeamService) {
-				ts.On("MassDeactivate", mock.Anything, []string{"u1", "u2"}).Return(nil)
+				ts.On("MassDeactivate", mock.Anything, []string{"u1", "u2"}, false).
+					Return(model.DeactivationReport{UserIDs: []string{"u1", "u2"}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Success: Preview",
+			body: `{"user_ids": ["u1"], "preview": true}`,
+			mockBehavior: func(ts *mocks.TeamService) {
+				ts.On("MassDeactivate", mock.Anything, []string{"u1"}, true).
+					Return(model.DeactivationReport{Preview: true, UserIDs: []string{"u1"}}, nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
@@ -51,8 +63,8 @@ func TestHandler_MassDeactivate(t *testing.T) {
 			name: "Internal Error",
 			body: `{"user_ids": ["u1"]}`,
 			mockBehavior: func(ts *mocks.TeamService) {
-				ts.On("MassDeactivate", mock.Anything, []string{"u1"}).
-					Return(service.ErrDomain("INTERNAL", "db error"))
+				ts.On("MassDeactivate", mock.Anything, []string{"u1"}, false).
+					Return(model.DeactivationReport{}, service.ErrDomain("INTERNAL", "db error"))
 			},
 			expectedStatus: http.StatusConflict,
 		},
@@ -77,3 +89,123 @@ func TestHandler_MassDeactivate(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_ListUsers(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	active := true
+
+	tests := []struct {
+		name           string
+		query          string
+		mockBehavior   func(us *mocks.UserService)
+		expectedStatus int
+	}{
+		{
+			name:  "Success: Filters are passed to service",
+			query: "?team_name=backend&is_active=true&username_prefix=al&limit=10&cursor=dTE",
+			mockBehavior: func(us *mocks.UserService) {
+				us.On("ListUsers", mock.Anything, model.UserFilter{
+					TeamName:       "backend",
+					IsActive:       &active,
+					UsernamePrefix: "al",
+					Limit:          10,
+				}, "dTE").Return([]model.User{{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}}, "", nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "Bad Request: Invalid is_active",
+			query: "?is_active=maybe",
+			mockBehavior: func(us *mocks.UserService) {
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "Bad Request: Invalid limit",
+			query: "?limit=-1",
+			mockBehavior: func(us *mocks.UserService) {
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teamSvc := new(mocks.TeamService)
+			userSvc := new(mocks.UserService)
+			prSvc := new(mocks.PRService)
+			tt.mockBehavior(userSvc)
+
+			h := httpapi.NewHandler(teamSvc, userSvc, prSvc, logger)
+
+			req := httptest.NewRequest("GET", "/users"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			h.Router().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			userSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_Stats(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		path           string
+		mockBehavior   func(ts *mocks.TeamService)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "Success: /stats keeps bare array",
+			path: "/stats",
+			mockBehavior: func(ts *mocks.TeamService) {
+				ts.On("GetStats", mock.Anything).Return([]model.StatsDTO{
+					{ReviewerID: "u2", ReviewCount: 1, ByPriority: map[model.PullRequestPriority]int{model.PriorityNormal: 1}},
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `[{"reviewer_id":"u2","review_count":1,"by_priority":{"normal":1}}]`,
+		},
+		{
+			name: "Success: /stats/reviewers passes filter",
+			path: "/stats/reviewers?team_name=backend&status=OPEN&from=2025-01-01T00:00:00Z",
+			mockBehavior: func(ts *mocks.TeamService) {
+				ts.On("GetReviewStats", mock.Anything, model.StatsFilter{TeamName: "backend", Status: model.StatusOpen, From: &from}).
+					Return(model.ReviewStats{Reviewers: []model.ReviewerStats{}, Teams: []model.TeamLoadStats{}}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"reviewers":[],"teams":[]}`,
+		},
+		{
+			name:           "Bad Request: Invalid from",
+			path:           "/stats/reviewers?from=yesterday",
+			mockBehavior:   func(ts *mocks.TeamService) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			teamSvc := new(mocks.TeamService)
+			tt.mockBehavior(teamSvc)
+
+			h := httpapi.NewHandler(teamSvc, new(mocks.UserService), new(mocks.PRService), logger)
+
+			req := httptest.NewRequest("GET", tt.path, nil)
+			w := httptest.NewRecorder()
+
+			h.Router().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedBody != "" {
+				assert.JSONEq(t, tt.expectedBody, w.Body.String())
+			}
+			teamSvc.AssertExpectations(t)
+		})
+	}
+}
@@ -10,19 +10,19 @@ import (
 func (h *Handler) handleTeamAdd(w http.ResponseWriter, r *http.Request) {
 	const handlerName = "team_add"
 
-	var req model.Team
+	var req createTeamRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
 		return
 	}
 
-	if err := ValidateTeam(req); err != nil {
+	if err := ValidateTeam(req.Team); err != nil {
 		h.writeError(w, handlerName, err)
 		return
 	}
 
 	ctx := r.Context()
-	team, err := h.Teams.CreateTeam(ctx, req)
+	team, err := h.Teams.CreateTeam(ctx, req.Team, req.MoveExisting)
 	if err != nil {
 		h.writeError(w, handlerName, err)
 		return
@@ -55,6 +55,30 @@ func (h *Handler) handleTeamGet(w http.ResponseWriter, r *http.Request) {
 	_ = json.NewEncoder(w).Encode(team)
 }
 
+func (h *Handler) handleTeamsList(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "teams_list"
+
+	filter, cursor, err := ParseListTeamsQuery(r.URL.Query())
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	teams, next, err := h.Teams.ListTeams(ctx, filter, cursor)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := listTeamsResponse{
+		Teams:      teams,
+		NextCursor: next,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
 func (h *Handler) handleMassDeactivate(w http.ResponseWriter, r *http.Request) {
 	const handlerName = "team_mass_deactivate"
 
@@ -72,7 +96,111 @@ func (h *Handler) handleMassDeactivate(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
 	// Вызываем метод сервиса
-	if err := h.Teams.MassDeactivate(ctx, req.UserIDs); err != nil {
+	report, err := h.Teams.MassDeactivate(ctx, req.UserIDs, req.Preview)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := massDeactivateResponse{Status: "ok", DeactivationReport: report}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) handleTeamAddMembers(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "team_add_members"
+
+	var req model.Team
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateTeam(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	team, err := h.Teams.AddMembers(ctx, req.TeamName, req.Members)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := teamResponse{Team: team}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) handleTeamRemoveMembers(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "team_remove_members"
+
+	var req removeMembersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateRemoveMembersRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	team, err := h.Teams.RemoveMembers(ctx, req.TeamName, req.UserIDs)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := teamResponse{Team: team}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) handleTeamArchive(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "team_archive"
+
+	var req teamNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateTeamNameRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	team, err := h.Teams.ArchiveTeam(ctx, req.TeamName)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := teamResponse{Team: team}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) handleTeamDelete(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "team_delete"
+
+	var req teamNameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateTeamNameRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	if err := h.Teams.DeleteTeam(ctx, req.TeamName); err != nil {
 		h.writeError(w, handlerName, err)
 		return
 	}
@@ -82,11 +210,81 @@ func (h *Handler) handleMassDeactivate(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write([]byte(`{"status":"ok"}`))
 }
 
+func (h *Handler) handleTeamMove(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "team_move"
+
+	var req moveSubtreeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateMoveSubtreeRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	team, err := h.Teams.MoveTeam(ctx, req.TeamName, req.ParentTeamName)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := teamResponse{Team: team}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) handleTeamDescendants(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "team_descendants"
+
+	teamName := r.URL.Query().Get("team_name")
+	if err := ValidateTeamNameQuery(teamName); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	nodes, err := h.Teams.ListDescendants(ctx, teamName)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := teamDescendantsResponse{TeamName: teamName, Descendants: nodes}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// handleStats отдаёт число назначений по каждому ревьюверу в прежнем формате массива.
 func (h *Handler) handleStats(w http.ResponseWriter, r *http.Request) {
 	const handlerName = "get_stats"
 
 	ctx := r.Context()
-	stats, err := h.Teams.GetStats(ctx) // Или вынести в отдельный AnalyticsService
+	stats, err := h.Teams.GetStats(ctx)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(stats)
+}
+
+// handleReviewerStats отдаёт нагрузку ревьюверов с фильтрами, снятиями с ревью и равномерностью по командам.
+// /stats сохраняет прежний формат ответа для существующих клиентов.
+func (h *Handler) handleReviewerStats(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "get_reviewer_stats"
+
+	filter, err := ParseStatsQuery(r.URL.Query())
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	stats, err := h.Teams.GetReviewStats(ctx, filter)
 	if err != nil {
 		h.writeError(w, handlerName, err)
 		return
@@ -95,3 +293,67 @@ func (h *Handler) handleStats(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(stats)
 }
+
+func (h *Handler) handleTeamStats(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "get_team_stats"
+
+	ctx := r.Context()
+	stats, err := h.Teams.GetTeamStats(ctx)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(teamStatsResponse{Teams: stats})
+}
+
+func (h *Handler) handleTeamSetNotificationChannel(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "team_set_notification_channel"
+
+	var req setNotificationChannelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateSetNotificationChannelRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	if err := h.Teams.SetNotificationChannel(ctx, req.TeamName, req.WebhookURL); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`{"status":"ok"}`))
+}
+
+func (h *Handler) handleTeamSetLead(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "team_set_lead"
+
+	var req setLeadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateSetLeadRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	if err := h.Teams.SetLead(ctx, req.TeamName, req.UserID); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`{"status":"ok"}`))
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"encoding/json"
 	"net/http"
+	"pull-request-service/internal/model"
 	"pull-request-service/internal/service"
 )
 
@@ -21,14 +22,38 @@ func (h *Handler) handleUserSetIsActive(w http.ResponseWriter, r *http.Request)
 	}
 
 	ctx := r.Context()
-	user, err := h.Users.SetIsActive(ctx, req.UserID, req.IsActive)
+	user, reassignments, err := h.Users.SetIsActive(ctx, req.UserID, req.IsActive)
 	if err != nil {
 		h.writeError(w, handlerName, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	resp := userResponse{User: user}
+	resp := setIsActiveResponse{User: user, Reassignments: reassignments}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) handleUsersList(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "users_list"
+
+	filter, cursor, err := ParseListUsersQuery(r.URL.Query())
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	users, next, err := h.Users.ListUsers(ctx, filter, cursor)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := listUsersResponse{
+		Users:      users,
+		NextCursor: next,
+	}
 	_ = json.NewEncoder(w).Encode(resp)
 }
 
@@ -55,3 +80,157 @@ func (h *Handler) handleUserGetReview(w http.ResponseWriter, r *http.Request) {
 	}
 	_ = json.NewEncoder(w).Encode(resp)
 }
+
+func (h *Handler) handleUserMoveTeam(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "user_move_team"
+
+	var req moveTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateMoveTeamRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	user, err := h.Teams.MoveUser(ctx, req.UserID, req.TeamName)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := userResponse{User: user}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) handleUserGetAuthored(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "user_get_authored"
+
+	userID := r.URL.Query().Get("user_id")
+	if err := ValidateUserIDQuery(userID); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	prs, err := h.PRs.ListAuthoredByUser(ctx, userID)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := getUserAuthoredResponse{
+		UserID:       userID,
+		PullRequests: prs,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) handleUserActivity(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "user_activity"
+
+	userID := r.URL.Query().Get("user_id")
+	if err := ValidateUserIDQuery(userID); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	events, err := h.Users.ListActivity(ctx, userID)
+	if err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	resp := userActivityResponse{
+		UserID: userID,
+		Events: events,
+	}
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+func (h *Handler) handleUserSetChatHandle(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "user_set_chat_handle"
+
+	var req setChatHandleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateSetChatHandleRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	if err := h.Users.SetChatHandle(ctx, req.UserID, req.ChatHandle); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`{"status":"ok"}`))
+}
+
+func (h *Handler) handleUserSetEmailPreferences(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "user_set_email_preferences"
+
+	var req setEmailPreferencesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateSetEmailPreferencesRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	ctx := r.Context()
+	if err := h.Users.SetEmailPreferences(ctx, req.UserID, req.Email, req.Mode); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`{"status":"ok"}`))
+}
+
+func (h *Handler) handleUserSetWorkingHours(w http.ResponseWriter, r *http.Request) {
+	const handlerName = "user_set_working_hours"
+
+	var req setWorkingHoursRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.writeError(w, handlerName, service.ErrBadRequest("invalid JSON"))
+		return
+	}
+
+	if err := ValidateSetWorkingHoursRequest(req); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	var hours *model.WorkingHours
+	if req.StartHour != nil {
+		hours = &model.WorkingHours{TimeZone: req.TimeZone, StartHour: *req.StartHour, EndHour: *req.EndHour}
+	}
+
+	ctx := r.Context()
+	if err := h.Users.SetWorkingHours(ctx, req.UserID, hours); err != nil {
+		h.writeError(w, handlerName, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte(`{"status":"ok"}`))
+}
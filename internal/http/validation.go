@@ -2,17 +2,24 @@ package http
 
 import (
 	"fmt"
+	"net/url"
 	"pull-request-service/internal/model"
 	"pull-request-service/internal/service"
 	"regexp"
+	"strconv"
+	"time"
 )
 
-// Регулярки для проверки корректности u_id и pr_id
+// Регулярки для проверки корректности u_id и pr_id. PR из вебхуков хостингов получают id вида
+// `<owner>/<repo>#<номер>` (GitHub) или `<группа>/<проект>!<iid>` (GitLab), поэтому они тоже допустимы.
 var (
 	reUserID        = regexp.MustCompile(`^u[0-9]+$`)
-	rePullRequestID = regexp.MustCompile(`^pr-[0-9]+$`)
+	rePullRequestID = regexp.MustCompile(`^(pr-[0-9]+|[A-Za-z0-9_.-]+(/[A-Za-z0-9_.-]+)+[#!][0-9]+)$`)
 )
 
+// pullRequestIDFormat — подсказка о формате pull_request_id в сообщениях об ошибках.
+const pullRequestIDFormat = "pull_request_id must match pattern pr-<digits>, <owner>/<repo>#<number> or <group>/<project>!<iid>, e.g. pr-1001"
+
 // Teams
 
 // ValidateTeam Валидация команды для /team/add
@@ -39,6 +46,66 @@ func ValidateTeam(team model.Team) error {
 	return nil
 }
 
+// ValidateRoster Валидация файла состава для /admin/import и /admin/sync: каждая команда проверяется по правилам
+// ValidateTeam, дополнительно — уникальность команд и пользователей в файле. Команда может быть
+// без участников, только если она родитель другой команды файла (отдел). Возвращает все найденные
+// ошибки сразу: сообщение — первая, в Details — полный список.
+func ValidateRoster(roster model.Roster) error {
+	if len(roster.Teams) == 0 {
+		return service.ErrBadRequest("roster must contain at least one team")
+	}
+
+	parents := make(map[string]bool, len(roster.Teams))
+	for _, t := range roster.Teams {
+		if t.ParentTeamName != "" {
+			parents[t.ParentTeamName] = true
+		}
+	}
+
+	problems := make([]string, 0)
+	teamSeen := make(map[string]bool, len(roster.Teams))
+	userTeam := make(map[string]string)
+	for i, t := range roster.Teams {
+		prefix := fmt.Sprintf("teams[%d]", i)
+		if t.TeamName != "" {
+			prefix = fmt.Sprintf("teams[%d] (%s)", i, t.TeamName)
+		}
+
+		isDepartment := len(t.Members) == 0 && t.TeamName != "" && parents[t.TeamName]
+		if !isDepartment {
+			if err := ValidateTeam(t); err != nil {
+				problems = append(problems, prefix+": "+err.Error())
+			}
+		}
+
+		if t.TeamName != "" && t.TeamName == t.ParentTeamName {
+			problems = append(problems, prefix+": parent_team_name must differ from team_name")
+		}
+		if teamSeen[t.TeamName] {
+			problems = append(problems, prefix+": duplicate team_name")
+		}
+		teamSeen[t.TeamName] = true
+
+		for _, m := range t.Members {
+			if m.UserID == "" {
+				continue
+			}
+			if other, ok := userTeam[m.UserID]; ok {
+				problems = append(problems, fmt.Sprintf("%s: user %s is already listed in team %s", prefix, m.UserID, other))
+				continue
+			}
+			userTeam[m.UserID] = t.TeamName
+		}
+	}
+
+	if len(problems) > 0 {
+		appErr := service.ErrBadRequest(problems[0])
+		appErr.Details = problems
+		return appErr
+	}
+	return nil
+}
+
 // ValidateTeamNameQuery Валидация query-параметра team_name для /team/get
 func ValidateTeamNameQuery(teamName string) error {
 	if teamName == "" {
@@ -47,6 +114,82 @@ func ValidateTeamNameQuery(teamName string) error {
 	return nil
 }
 
+// ParseListTeamsQuery разбирает query-параметры GET /teams: limit, cursor, include_archived
+func ParseListTeamsQuery(q url.Values) (model.TeamFilter, string, error) {
+	var filter model.TeamFilter
+
+	limit, err := parseLimitQuery(q.Get("limit"))
+	if err != nil {
+		return model.TeamFilter{}, "", err
+	}
+	filter.Limit = limit
+
+	if v := q.Get("include_archived"); v != "" {
+		includeArchived, err := strconv.ParseBool(v)
+		if err != nil {
+			return model.TeamFilter{}, "", service.ErrBadRequest("include_archived must be true or false")
+		}
+		filter.IncludeArchived = includeArchived
+	}
+
+	return filter, q.Get("cursor"), nil
+}
+
+// ValidateTeamNameRequest /team/archive, /team/delete — тело запроса
+func ValidateTeamNameRequest(req teamNameRequest) error {
+	if req.TeamName == "" {
+		return service.ErrBadRequest("team_name is required")
+	}
+	return nil
+}
+
+// ValidateMoveSubtreeRequest /team/move — тело запроса
+func ValidateMoveSubtreeRequest(req moveSubtreeRequest) error {
+	if req.TeamName == "" {
+		return service.ErrBadRequest("team_name is required")
+	}
+	if req.TeamName == req.ParentTeamName {
+		return service.ErrBadRequest("parent_team_name must differ from team_name")
+	}
+	return nil
+}
+
+// ValidateSetNotificationChannelRequest /team/setNotificationChannel — тело запроса.
+// Пустой webhook_url допустим: он выключает уведомления команды.
+func ValidateSetNotificationChannelRequest(req setNotificationChannelRequest) error {
+	if req.TeamName == "" {
+		return service.ErrBadRequest("team_name is required")
+	}
+	return nil
+}
+
+// ValidateSetLeadRequest /team/setLead — тело запроса. Пустой user_id снимает руководителя.
+func ValidateSetLeadRequest(req setLeadRequest) error {
+	if req.TeamName == "" {
+		return service.ErrBadRequest("team_name is required")
+	}
+	if req.UserID != "" && !reUserID.MatchString(req.UserID) {
+		return service.ErrBadRequest("user_id must match pattern u<digits>, e.g. u1")
+	}
+	return nil
+}
+
+// ValidateRemoveMembersRequest /team/removeMembers — тело запроса
+func ValidateRemoveMembersRequest(req removeMembersRequest) error {
+	if req.TeamName == "" {
+		return service.ErrBadRequest("team_name is required")
+	}
+	if len(req.UserIDs) == 0 {
+		return service.ErrBadRequest("user_ids must not be empty")
+	}
+	for i, id := range req.UserIDs {
+		if !reUserID.MatchString(id) {
+			return service.ErrBadRequest(fmt.Sprintf("user_ids[%d] must match pattern u<digits>, e.g. u1", i))
+		}
+	}
+	return nil
+}
+
 //Users
 
 // ValidateSetIsActiveRequest /users/setIsActive — тело запроса
@@ -61,6 +204,30 @@ func ValidateSetIsActiveRequest(req setIsActiveRequest) error {
 	return nil
 }
 
+// ParseListUsersQuery разбирает query-параметры GET /users: limit, cursor, team_name, is_active, username_prefix
+func ParseListUsersQuery(q url.Values) (model.UserFilter, string, error) {
+	filter := model.UserFilter{
+		TeamName:       q.Get("team_name"),
+		UsernamePrefix: q.Get("username_prefix"),
+	}
+
+	limit, err := parseLimitQuery(q.Get("limit"))
+	if err != nil {
+		return model.UserFilter{}, "", err
+	}
+	filter.Limit = limit
+
+	if v := q.Get("is_active"); v != "" {
+		isActive, err := strconv.ParseBool(v)
+		if err != nil {
+			return model.UserFilter{}, "", service.ErrBadRequest("is_active must be true or false")
+		}
+		filter.IsActive = &isActive
+	}
+
+	return filter, q.Get("cursor"), nil
+}
+
 // ValidateUserIDQuery Валидация query-параметра user_id для /users/getReview
 func ValidateUserIDQuery(userID string) error {
 	if userID == "" {
@@ -72,6 +239,73 @@ func ValidateUserIDQuery(userID string) error {
 	return nil
 }
 
+// ValidateMoveTeamRequest /users/moveTeam — тело запроса
+func ValidateMoveTeamRequest(req moveTeamRequest) error {
+	if req.UserID == "" {
+		return service.ErrBadRequest("user_id is required")
+	}
+	if !reUserID.MatchString(req.UserID) {
+		return service.ErrBadRequest("user_id must match pattern u<digits>, e.g. u1")
+	}
+	if req.TeamName == "" {
+		return service.ErrBadRequest("team_name is required")
+	}
+	return nil
+}
+
+// ValidateSetChatHandleRequest /users/setChatHandle — тело запроса
+func ValidateSetChatHandleRequest(req setChatHandleRequest) error {
+	if req.UserID == "" {
+		return service.ErrBadRequest("user_id is required")
+	}
+	if !reUserID.MatchString(req.UserID) {
+		return service.ErrBadRequest("user_id must match pattern u<digits>, e.g. u1")
+	}
+	if len(req.ChatHandle) > 255 {
+		return service.ErrBadRequest("chat_handle must be at most 255 characters")
+	}
+	return nil
+}
+
+// ValidateSetEmailPreferencesRequest /users/setEmailPreferences — тело запроса.
+// Адрес проверяет сервис: без него допустим только режим off.
+func ValidateSetEmailPreferencesRequest(req setEmailPreferencesRequest) error {
+	if req.UserID == "" {
+		return service.ErrBadRequest("user_id is required")
+	}
+	if !reUserID.MatchString(req.UserID) {
+		return service.ErrBadRequest("user_id must match pattern u<digits>, e.g. u1")
+	}
+	if !req.Mode.IsValid() {
+		return service.ErrBadRequest("mode must be one of off, immediate, hourly, daily")
+	}
+	return nil
+}
+
+// ValidateSetWorkingHoursRequest /users/setWorkingHours — тело запроса.
+// Часы задаются парой или не задаются вовсе (снять расписание); часовой пояс проверяет сервис.
+func ValidateSetWorkingHoursRequest(req setWorkingHoursRequest) error {
+	if req.UserID == "" {
+		return service.ErrBadRequest("user_id is required")
+	}
+	if !reUserID.MatchString(req.UserID) {
+		return service.ErrBadRequest("user_id must match pattern u<digits>, e.g. u1")
+	}
+	if (req.StartHour == nil) != (req.EndHour == nil) {
+		return service.ErrBadRequest("start_hour and end_hour must be set together")
+	}
+	if req.StartHour == nil {
+		return nil
+	}
+	if *req.StartHour < 0 || *req.StartHour > 23 || *req.EndHour < 0 || *req.EndHour > 23 {
+		return service.ErrBadRequest("start_hour and end_hour must be from 0 to 23")
+	}
+	if *req.StartHour == *req.EndHour {
+		return service.ErrBadRequest("start_hour and end_hour must differ")
+	}
+	return nil
+}
+
 // Pull Requests
 
 // ValidateCreatePRRequest /pullRequest/create — тело запроса
@@ -80,7 +314,7 @@ func ValidateCreatePRRequest(req createPRRequest) error {
 		return service.ErrBadRequest("pull_request_id is required")
 	}
 	if !rePullRequestID.MatchString(req.PullRequestID) {
-		return service.ErrBadRequest("pull_request_id must match pattern pr-<digits>, e.g. pr-1001")
+		return service.ErrBadRequest(pullRequestIDFormat)
 	}
 
 	if req.PullRequestName == "" {
@@ -94,6 +328,11 @@ func ValidateCreatePRRequest(req createPRRequest) error {
 		return service.ErrBadRequest("author_id must match pattern u<digits>, e.g. u1")
 	}
 
+	// priority необязателен: пустое значение сервис заменит на normal
+	if req.Priority != "" && !model.PullRequestPriority(req.Priority).IsValid() {
+		return service.ErrBadRequest("priority must be one of low, normal, high, hotfix")
+	}
+
 	return nil
 }
 
@@ -103,7 +342,7 @@ func ValidateMergePRRequest(req mergePRRequest) error {
 		return service.ErrBadRequest("pull_request_id is required")
 	}
 	if !rePullRequestID.MatchString(req.PullRequestID) {
-		return service.ErrBadRequest("pull_request_id must match pattern pr-<digits>, e.g. pr-1001")
+		return service.ErrBadRequest(pullRequestIDFormat)
 	}
 	return nil
 }
@@ -114,7 +353,7 @@ func ValidateReassignRequest(req reassignRequest) error {
 		return service.ErrBadRequest("pull_request_id is required")
 	}
 	if !rePullRequestID.MatchString(req.PullRequestID) {
-		return service.ErrBadRequest("pull_request_id must match pattern pr-<digits>, e.g. pr-1001")
+		return service.ErrBadRequest(pullRequestIDFormat)
 	}
 
 	if req.OldUserID == "" {
@@ -126,3 +365,185 @@ func ValidateReassignRequest(req reassignRequest) error {
 
 	return nil
 }
+
+// ValidateReviewRequest /pullRequest/review — тело запроса
+func ValidateReviewRequest(req reviewRequest) error {
+	if req.PullRequestID == "" {
+		return service.ErrBadRequest("pull_request_id is required")
+	}
+	if !rePullRequestID.MatchString(req.PullRequestID) {
+		return service.ErrBadRequest(pullRequestIDFormat)
+	}
+
+	if req.ReviewerID == "" {
+		return service.ErrBadRequest("reviewer_id is required")
+	}
+	if !reUserID.MatchString(req.ReviewerID) {
+		return service.ErrBadRequest("reviewer_id must match pattern u<digits>, e.g. u1")
+	}
+
+	if !model.ReviewState(req.State).IsValid() {
+		return service.ErrBadRequest("state must be one of PENDING, APPROVED, CHANGES_REQUESTED")
+	}
+
+	return nil
+}
+
+// ParseListPullRequestsQuery разбирает query-параметры GET /pullRequests
+func ParseListPullRequestsQuery(q url.Values) (model.PullRequestFilter, string, error) {
+	filter := model.PullRequestFilter{
+		Status:       model.PullRequestStatus(q.Get("status")),
+		Priority:     model.PullRequestPriority(q.Get("priority")),
+		AuthorID:     q.Get("author_id"),
+		ReviewerID:   q.Get("reviewer_id"),
+		TeamName:     q.Get("team_name"),
+		NameContains: q.Get("name"),
+		Sort:         model.PullRequestSort(q.Get("sort")),
+	}
+
+	if filter.AuthorID != "" && !reUserID.MatchString(filter.AuthorID) {
+		return model.PullRequestFilter{}, "", service.ErrBadRequest("author_id must match pattern u<digits>, e.g. u1")
+	}
+	if filter.ReviewerID != "" && !reUserID.MatchString(filter.ReviewerID) {
+		return model.PullRequestFilter{}, "", service.ErrBadRequest("reviewer_id must match pattern u<digits>, e.g. u1")
+	}
+
+	limit, err := parseLimitQuery(q.Get("limit"))
+	if err != nil {
+		return model.PullRequestFilter{}, "", err
+	}
+	filter.Limit = limit
+
+	dates := []struct {
+		name string
+		dst  **time.Time
+	}{
+		{"created_from", &filter.CreatedFrom},
+		{"created_to", &filter.CreatedTo},
+		{"merged_from", &filter.MergedFrom},
+		{"merged_to", &filter.MergedTo},
+	}
+	for _, d := range dates {
+		t, err := parseTimeQuery(d.name, q.Get(d.name))
+		if err != nil {
+			return model.PullRequestFilter{}, "", err
+		}
+		*d.dst = t
+	}
+
+	return filter, q.Get("cursor"), nil
+}
+
+// ParseStatsQuery разбирает query-параметры GET /stats
+func ParseStatsQuery(q url.Values) (model.StatsFilter, error) {
+	filter := model.StatsFilter{
+		TeamName: q.Get("team_name"),
+		Status:   model.PullRequestStatus(q.Get("status")),
+	}
+
+	var err error
+	if filter.From, err = parseTimeQuery("from", q.Get("from")); err != nil {
+		return model.StatsFilter{}, err
+	}
+	if filter.To, err = parseTimeQuery("to", q.Get("to")); err != nil {
+		return model.StatsFilter{}, err
+	}
+	return filter, nil
+}
+
+// ParseCycleTimeQuery разбирает query-параметры GET /stats/cycleTime
+func ParseCycleTimeQuery(q url.Values) (model.CycleTimeFilter, error) {
+	filter := model.CycleTimeFilter{
+		Dimension: model.CycleTimeDimension(q.Get("dimension")),
+		Subject:   q.Get("subject"),
+	}
+	if v := q.Get("window_days"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil || days <= 0 {
+			return model.CycleTimeFilter{}, service.ErrBadRequest("window_days must be a positive integer")
+		}
+		filter.WindowDays = days
+	}
+	return filter, nil
+}
+
+// Pagination
+
+// parseLimitQuery разбирает query-параметр limit; пустое значение означает размер страницы по умолчанию
+func parseLimitQuery(v string) (int, error) {
+	if v == "" {
+		return 0, nil
+	}
+	limit, err := strconv.Atoi(v)
+	if err != nil || limit <= 0 {
+		return 0, service.ErrBadRequest("limit must be a positive integer")
+	}
+	return limit, nil
+}
+
+// parseTimeQuery разбирает query-параметр с датой в формате RFC 3339; пустое значение — nil
+func parseTimeQuery(name, v string) (*time.Time, error) {
+	if v == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return nil, service.ErrBadRequest(name + " must be an RFC 3339 timestamp, e.g. 2025-10-24T12:00:00Z")
+	}
+	return &t, nil
+}
+
+// parseBoolQuery разбирает булев query-параметр; пустое значение — false
+func parseBoolQuery(name, v string) (bool, error) {
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, service.ErrBadRequest(name + " must be true or false")
+	}
+	return b, nil
+}
+
+// Outbound webhooks
+
+// ValidateCreateSubscriptionRequest /webhooks/subscriptions — тело запроса
+func ValidateCreateSubscriptionRequest(req createSubscriptionRequest) error {
+	if req.URL == "" {
+		return service.ErrBadRequest("url is required")
+	}
+	for _, t := range req.EventTypes {
+		if !model.EventType(t).IsValid() {
+			return service.ErrBadRequest("event_types must contain only pull_request.created, pull_request.merged, reviewer.assigned, reviewer.removed, review.submitted")
+		}
+	}
+	return nil
+}
+
+// ValidateSubscriptionIDRequest /webhooks/subscriptions/delete — тело запроса
+func ValidateSubscriptionIDRequest(req subscriptionIDRequest) error {
+	if req.ID <= 0 {
+		return service.ErrBadRequest("id must be a positive integer")
+	}
+	return nil
+}
+
+// ValidateRedeliverRequest /webhooks/redeliver — тело запроса
+func ValidateRedeliverRequest(req redeliverRequest) error {
+	if req.DeadLetterID <= 0 {
+		return service.ErrBadRequest("dead_letter_id must be a positive integer")
+	}
+	return nil
+}
+
+// parseLastEventID разбирает Last-Event-ID потока SSE; пустое значение — 0 (без досылки)
+func parseLastEventID(v string) (int64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || id < 0 {
+		return 0, service.ErrBadRequest("Last-Event-ID must be a non-negative integer")
+	}
+	return id, nil
+}
@@ -0,0 +1,32 @@
+// Перерисовывает содержимое дашборда, запрашивая свежий HTML-фрагмент раз в 30 секунд.
+(function () {
+  "use strict";
+
+  var interval = 30000;
+  var content = document.getElementById("content");
+  var status = document.getElementById("status");
+
+  function refresh() {
+    fetch("/dashboard/content", { cache: "no-store" })
+      .then(function (resp) {
+        if (!resp.ok) {
+          throw new Error("HTTP " + resp.status);
+        }
+        return resp.text();
+      })
+      .then(function (html) {
+        content.innerHTML = html;
+        status.textContent = "Auto-refresh every 30s";
+        status.classList.remove("error");
+      })
+      .catch(function (err) {
+        status.textContent = "Refresh failed (" + err.message + "), retrying";
+        status.classList.add("error");
+      })
+      .then(function () {
+        setTimeout(refresh, interval);
+      });
+  }
+
+  setTimeout(refresh, interval);
+})();
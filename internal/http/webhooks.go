@@ -0,0 +1,117 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/service"
+	"pull-request-service/internal/webhook"
+)
+
+// maxWebhookBody — предельный размер тела вебхука (GitHub не присылает больше 25 МБ).
+const maxWebhookBody = 25 << 20
+
+// webhookHandler возвращает обработчик вебхуков хостинга: проверка подлинности и разбор
+// события делегируются провайдеру, а применение события к PR общее для всех хостингов.
+func (h *Handler) webhookHandler(wh webhookRoute) http.HandlerFunc {
+	handlerName := wh.provider.Name() + "_webhook"
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+		if err != nil {
+			h.writeError(w, handlerName, service.ErrBadRequest("failed to read body"))
+			return
+		}
+		if err := wh.provider.Verify(r.Header, body); err != nil {
+			h.writeError(w, handlerName, &service.AppError{
+				Code:    "UNAUTHORIZED",
+				Message: err.Error(),
+				Status:  http.StatusUnauthorized,
+				Err:     err,
+			})
+			return
+		}
+
+		ev, err := wh.provider.Parse(r.Header, body)
+		if err != nil {
+			h.writeError(w, handlerName, service.ErrBadRequest(err.Error()))
+			return
+		}
+
+		ctx := r.Context()
+		resp, err := h.applyWebhookEvent(ctx, ev, wh.logins)
+		if err != nil {
+			h.writeError(w, handlerName, err)
+			return
+		}
+		h.Log.Info("webhook processed",
+			slog.String("handler", handlerName),
+			slog.String("delivery", wh.provider.DeliveryID(r.Header)),
+			slog.String("status", resp.Status),
+			slog.String("action", resp.Action),
+			slog.String("pull_request_id", resp.PullRequestID),
+			slog.String("reason", resp.Reason),
+		)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+// applyWebhookEvent выполняет операцию над PR, к которой сводится событие.
+// Открытие уже известного закрытого PR открывает его снова. Хостинги повторяют доставку при сбоях,
+// поэтому повторное создание открытого PR, а также слияние и закрытие PR, которого сервис не видел,
+// считаются проигнорированными, а не ошибкой. Событие от автора, чей логин не сопоставлен с user_id,
+// тоже игнорируется.
+func (h *Handler) applyWebhookEvent(ctx context.Context, ev webhook.Event, logins webhook.LoginMap) (webhookResponse, error) {
+	resp := webhookResponse{Status: "ok", Action: string(ev.Action), PullRequestID: ev.PullRequestID}
+
+	var err error
+	switch ev.Action {
+	case webhook.ActionCreate:
+		authorID := logins.UserID(ev.AuthorLogin)
+		if !reUserID.MatchString(authorID) {
+			resp.Status, resp.Reason = "ignored", "author login "+ev.AuthorLogin+" is not mapped to a user_id"
+			return resp, nil
+		}
+		_, err = h.PRs.CreatePR(ctx, model.PullRequest{
+			PullRequestID:   ev.PullRequestID,
+			PullRequestName: ev.PullRequestName,
+			AuthorID:        authorID,
+		})
+		if errorCode(err) == "PR_EXISTS" {
+			_, err = h.PRs.ReopenPR(ctx, ev.PullRequestID)
+		}
+	case webhook.ActionMerge:
+		_, err = h.PRs.MergePR(ctx, ev.PullRequestID)
+	case webhook.ActionClose:
+		_, err = h.PRs.ClosePR(ctx, ev.PullRequestID)
+	default:
+		return webhookResponse{Status: "ignored", Reason: ev.Reason}, nil
+	}
+
+	switch {
+	case err == nil:
+		return resp, nil
+	case errorCode(err) == "PR_NOT_CLOSED":
+		resp.Status, resp.Reason = "ignored", "pull request already exists"
+		return resp, nil
+	case ev.Action != webhook.ActionCreate && service.IsNotFound(err):
+		resp.Status, resp.Reason = "ignored", "pull request is not tracked"
+		return resp, nil
+	}
+	return webhookResponse{}, err
+}
+
+// errorCode возвращает код AppError или пустую строку для прочих ошибок.
+func errorCode(err error) string {
+	var appErr *service.AppError
+	if errors.As(err, &appErr) {
+		return appErr.Code
+	}
+	return ""
+}
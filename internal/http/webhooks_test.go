@@ -0,0 +1,304 @@
+package http_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	httpapi "pull-request-service/internal/http"
+	"pull-request-service/internal/http/mocks"
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/service"
+	"pull-request-service/internal/webhook"
+)
+
+func TestHandler_GitHubWebhook(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	const secret = "webhook-secret"
+	logins := webhook.LoginMap{"alice-gh": "u1"}
+
+	opened := model.PullRequest{
+		PullRequestID:   "octo-org/api#42",
+		PullRequestName: "Add rate limiting to /pullRequest/create",
+		AuthorID:        "u1",
+	}
+
+	tests := []struct {
+		name           string
+		event          string
+		fixture        string
+		signWith       string
+		logins         webhook.LoginMap
+		mockBehavior   func(ps *mocks.PRService)
+		expectedStatus int
+		expectedResult string
+	}{
+		{
+			name:    "Opened: Creates PR for mapped author",
+			event:   "pull_request",
+			fixture: "pull_request_opened.json",
+			mockBehavior: func(ps *mocks.PRService) {
+				ps.On("CreatePR", mock.Anything, opened).Return(opened, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: "ok",
+		},
+		{
+			name:    "Opened: Redelivery is ignored",
+			event:   "pull_request",
+			fixture: "pull_request_opened.json",
+			mockBehavior: func(ps *mocks.PRService) {
+				ps.On("CreatePR", mock.Anything, opened).
+					Return(model.PullRequest{}, service.ErrDomain("PR_EXISTS", "PR id already exists"))
+				ps.On("ReopenPR", mock.Anything, "octo-org/api#42").
+					Return(model.PullRequest{}, service.ErrDomain("PR_NOT_CLOSED", "only closed PR can be reopened"))
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: "ignored",
+		},
+		{
+			name:    "Reopened: Closed PR is reopened",
+			event:   "pull_request",
+			fixture: "pull_request_reopened.json",
+			mockBehavior: func(ps *mocks.PRService) {
+				ps.On("CreatePR", mock.Anything, opened).
+					Return(model.PullRequest{}, service.ErrDomain("PR_EXISTS", "PR id already exists"))
+				ps.On("ReopenPR", mock.Anything, "octo-org/api#42").Return(opened, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: "ok",
+		},
+		{
+			name:           "Opened: Unmapped author is ignored",
+			event:          "pull_request",
+			fixture:        "pull_request_opened.json",
+			logins:         webhook.LoginMap{},
+			mockBehavior:   func(ps *mocks.PRService) {},
+			expectedStatus: http.StatusOK,
+			expectedResult: "ignored",
+		},
+		{
+			name:           "Opened: Draft is ignored",
+			event:          "pull_request",
+			fixture:        "pull_request_opened_draft.json",
+			mockBehavior:   func(ps *mocks.PRService) {},
+			expectedStatus: http.StatusOK,
+			expectedResult: "ignored",
+		},
+		{
+			name:    "Closed: Merge",
+			event:   "pull_request",
+			fixture: "pull_request_closed_merged.json",
+			mockBehavior: func(ps *mocks.PRService) {
+				ps.On("MergePR", mock.Anything, "octo-org/api#42").Return(model.PullRequest{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: "ok",
+		},
+		{
+			name:    "Closed: Without merge closes PR",
+			event:   "pull_request",
+			fixture: "pull_request_closed_unmerged.json",
+			mockBehavior: func(ps *mocks.PRService) {
+				ps.On("ClosePR", mock.Anything, "octo-org/api#42").Return(model.PullRequest{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: "ok",
+		},
+		{
+			name:    "Closed: Untracked PR is ignored",
+			event:   "pull_request",
+			fixture: "pull_request_closed_merged.json",
+			mockBehavior: func(ps *mocks.PRService) {
+				ps.On("MergePR", mock.Anything, "octo-org/api#42").
+					Return(model.PullRequest{}, service.ErrNotFound("pull request not found"))
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: "ignored",
+		},
+		{
+			name:    "Opened: Unknown author",
+			event:   "pull_request",
+			fixture: "pull_request_opened.json",
+			mockBehavior: func(ps *mocks.PRService) {
+				ps.On("CreatePR", mock.Anything, opened).
+					Return(model.PullRequest{}, service.ErrNotFound("author not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Unauthorized: Wrong secret",
+			event:          "pull_request",
+			fixture:        "pull_request_opened.json",
+			signWith:       "other-secret",
+			mockBehavior:   func(ps *mocks.PRService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("..", "webhook", "testdata", "github", tt.fixture))
+			require.NoError(t, err)
+
+			prSvc := new(mocks.PRService)
+			tt.mockBehavior(prSvc)
+
+			h := httpapi.NewHandler(new(mocks.TeamService), new(mocks.UserService), prSvc, logger)
+			if tt.logins != nil {
+				h.EnableWebhook(webhook.GitHub{Secret: secret}, tt.logins)
+			} else {
+				h.EnableWebhook(webhook.GitHub{Secret: secret}, logins)
+			}
+
+			signWith := secret
+			if tt.signWith != "" {
+				signWith = tt.signWith
+			}
+			mac := hmac.New(sha256.New, []byte(signWith))
+			mac.Write(body)
+
+			req := httptest.NewRequest("POST", "/webhooks/github", bytes.NewReader(body))
+			req.Header.Set(webhook.GitHubEventHeader, tt.event)
+			req.Header.Set(webhook.GitHubSignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+			w := httptest.NewRecorder()
+
+			h.Router().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedResult != "" {
+				var resp struct {
+					Status string `json:"status"`
+				}
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, tt.expectedResult, resp.Status)
+			}
+			prSvc.AssertExpectations(t)
+		})
+	}
+}
+
+func TestHandler_GitLabWebhook(t *testing.T) {
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, nil))
+	const token = "gitlab-token"
+	// Ключи для GitLab — логины и числовые author_id
+	logins := webhook.LoginMap{"dana": "u7", "317": "u7"}
+
+	tests := []struct {
+		name           string
+		fixture        string
+		token          string
+		logins         webhook.LoginMap
+		mockBehavior   func(ps *mocks.PRService)
+		expectedStatus int
+		expectedResult string
+	}{
+		{
+			name:    "Open: Creates PR",
+			fixture: "mr_open.json",
+			token:   token,
+			mockBehavior: func(ps *mocks.PRService) {
+				ps.On("CreatePR", mock.Anything, model.PullRequest{
+					PullRequestID:   "payments/billing!7",
+					PullRequestName: "Switch invoices to decimal amounts",
+					AuthorID:        "u7",
+				}).Return(model.PullRequest{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: "ok",
+		},
+		{
+			name:           "Open: Unmapped author login is ignored",
+			fixture:        "mr_open.json",
+			token:          token,
+			logins:         webhook.LoginMap{"317": "u7"},
+			mockBehavior:   func(ps *mocks.PRService) {},
+			expectedStatus: http.StatusOK,
+			expectedResult: "ignored",
+		},
+		{
+			name:    "Merge",
+			fixture: "mr_merge.json",
+			token:   token,
+			mockBehavior: func(ps *mocks.PRService) {
+				ps.On("MergePR", mock.Anything, "payments/billing!7").Return(model.PullRequest{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: "ok",
+		},
+		{
+			name:    "Close",
+			fixture: "mr_close.json",
+			token:   token,
+			mockBehavior: func(ps *mocks.PRService) {
+				ps.On("ClosePR", mock.Anything, "payments/billing!7").Return(model.PullRequest{}, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: "ok",
+		},
+		{
+			name:    "Close: Untracked MR is ignored",
+			fixture: "mr_close.json",
+			token:   token,
+			mockBehavior: func(ps *mocks.PRService) {
+				ps.On("ClosePR", mock.Anything, "payments/billing!7").
+					Return(model.PullRequest{}, service.ErrNotFound("pull request not found"))
+			},
+			expectedStatus: http.StatusOK,
+			expectedResult: "ignored",
+		},
+		{
+			name:           "Unauthorized: Wrong token",
+			fixture:        "mr_open.json",
+			token:          "wrong",
+			mockBehavior:   func(ps *mocks.PRService) {},
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("..", "webhook", "testdata", "gitlab", tt.fixture))
+			require.NoError(t, err)
+
+			prSvc := new(mocks.PRService)
+			tt.mockBehavior(prSvc)
+
+			h := httpapi.NewHandler(new(mocks.TeamService), new(mocks.UserService), prSvc, logger)
+			if tt.logins != nil {
+				h.EnableWebhook(webhook.GitLab{Token: token}, tt.logins)
+			} else {
+				h.EnableWebhook(webhook.GitLab{Token: token}, logins)
+			}
+
+			req := httptest.NewRequest("POST", "/webhooks/gitlab", bytes.NewReader(body))
+			req.Header.Set(webhook.GitLabEventHeader, "Merge Request Hook")
+			req.Header.Set(webhook.GitLabTokenHeader, tt.token)
+			w := httptest.NewRecorder()
+
+			h.Router().ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedResult != "" {
+				var resp struct {
+					Status string `json:"status"`
+				}
+				require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+				assert.Equal(t, tt.expectedResult, resp.Status)
+			}
+			prSvc.AssertExpectations(t)
+		})
+	}
+}
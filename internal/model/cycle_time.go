@@ -0,0 +1,83 @@
+package model
+
+import "time"
+
+// CycleTimeDimension — разрез аналитики времени цикла.
+type CycleTimeDimension string
+
+const (
+	// CycleTimeByTeam — по текущей команде автора PR.
+	CycleTimeByTeam CycleTimeDimension = "team"
+	// CycleTimeByReviewer — по ревьюверу, отсчёт от его назначения.
+	CycleTimeByReviewer CycleTimeDimension = "reviewer"
+	// CycleTimeByAuthor — по автору PR.
+	CycleTimeByAuthor CycleTimeDimension = "author"
+)
+
+// IsValid сообщает, является ли значение одним из известных разрезов.
+func (d CycleTimeDimension) IsValid() bool {
+	switch d {
+	case CycleTimeByTeam, CycleTimeByReviewer, CycleTimeByAuthor:
+		return true
+	}
+	return false
+}
+
+// CycleTimeMetric — измеряемый интервал цикла ревью.
+type CycleTimeMetric string
+
+const (
+	// MetricFirstReview — до первого ревью с состоянием, отличным от PENDING.
+	MetricFirstReview CycleTimeMetric = "first_review"
+	// MetricApproval — до первого одобрения.
+	MetricApproval CycleTimeMetric = "approval"
+	// MetricMerge — до слияния PR.
+	MetricMerge CycleTimeMetric = "merge"
+)
+
+// Percentiles — процентили интервала в часах по Samples замерам.
+type Percentiles struct {
+	Samples  int     `json:"samples"`
+	P50Hours float64 `json:"p50_hours"`
+	P90Hours float64 `json:"p90_hours"`
+	P99Hours float64 `json:"p99_hours"`
+}
+
+// CycleTimeRollup — строка rollup-таблицы: процентили метрики для субъекта разреза
+// за окно или за неделю, начинающуюся WeekStart.
+type CycleTimeRollup struct {
+	Subject     string
+	WeekStart   time.Time
+	Metric      CycleTimeMetric
+	Percentiles Percentiles
+}
+
+// CycleTimeFilter — параметры отчёта: разрез, окно в днях и, при необходимости,
+// один субъект (команда или user_id).
+type CycleTimeFilter struct {
+	Dimension  CycleTimeDimension
+	WindowDays int
+	Subject    string
+}
+
+// CycleTimeWeek — процентили метрик за неделю, начинающуюся WeekStart (понедельник, UTC).
+type CycleTimeWeek struct {
+	WeekStart string                          `json:"week_start"`
+	Metrics   map[CycleTimeMetric]Percentiles `json:"metrics"`
+}
+
+// CycleTimeItem — процентили метрик субъекта за окно и их недельный тренд.
+type CycleTimeItem struct {
+	Subject string                          `json:"subject"`
+	Metrics map[CycleTimeMetric]Percentiles `json:"metrics"`
+	Trend   []CycleTimeWeek                 `json:"trend"`
+}
+
+// CycleTimeReport — ответ /stats/cycleTime. RefreshedAt — время последнего пересчёта rollup-таблиц,
+// отсутствует, если они ещё не пересчитывались.
+type CycleTimeReport struct {
+	Dimension   CycleTimeDimension `json:"dimension"`
+	WindowDays  int                `json:"window_days"`
+	RefreshedAt *time.Time         `json:"refreshed_at,omitempty"`
+	Items       []CycleTimeItem    `json:"items"`
+}
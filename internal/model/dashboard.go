@@ -0,0 +1,39 @@
+package model
+
+import "time"
+
+// ReviewerReassignment — снятие ревьювера с PR: замена на ReplacedBy или снятие без замены (ReplacedBy пуст).
+type ReviewerReassignment struct {
+	PullRequestID   string
+	PullRequestName string
+	ReviewerID      string
+	ReplacedBy      string
+	ReassignedAt    time.Time
+}
+
+// DashboardMember — участник команды и число его ревью на открытых PR.
+type DashboardMember struct {
+	UserID      string
+	Username    string
+	IsActive    bool
+	OpenReviews int
+}
+
+// DashboardTeam — участники команды по убыванию нагрузки и их суммарная нагрузка.
+type DashboardTeam struct {
+	TeamName    string
+	OpenReviews int
+	Members     []DashboardMember
+}
+
+// Dashboard — данные страницы нагрузки ревьюверов. MaxOpenReviews — наибольшая нагрузка
+// участника, относительно неё рисуются полосы. StalePRs — открытые PR старше StaleAfterHours часов,
+// которые всё ещё ждут ревью.
+type Dashboard struct {
+	GeneratedAt     time.Time
+	StaleAfterHours int
+	MaxOpenReviews  int
+	Teams           []DashboardTeam
+	StalePRs        []StalledPR
+	Reassignments   []ReviewerReassignment
+}
@@ -0,0 +1,140 @@
+package model
+
+import "time"
+
+// EventType — тип доменного события, которое рассылается подписчикам исходящих вебхуков.
+type EventType string
+
+const (
+	// EventPRCreated — создан PR; в Reviewers перечислены назначенные ревьюверы.
+	EventPRCreated EventType = "pull_request.created"
+	// EventPRMerged — PR влит. Повторное слияние уже влитого PR события не порождает.
+	EventPRMerged EventType = "pull_request.merged"
+	// EventReviewerAssigned — ревьювер ReviewerID назначен на PR вместо ReplacedReviewerID.
+	EventReviewerAssigned EventType = "reviewer.assigned"
+	// EventReviewerRemoved — ревьювер ReviewerID снят с PR без замены.
+	EventReviewerRemoved EventType = "reviewer.removed"
+	// EventReviewSubmitted — ревьювер ReviewerID выставил ревью в состояние ReviewState.
+	EventReviewSubmitted EventType = "review.submitted"
+)
+
+// EventTypes перечисляет все типы событий.
+var EventTypes = []EventType{EventPRCreated, EventPRMerged, EventReviewerAssigned, EventReviewerRemoved, EventReviewSubmitted}
+
+// IsValid сообщает, является ли значение одним из известных типов событий.
+func (t EventType) IsValid() bool {
+	for _, v := range EventTypes {
+		if t == v {
+			return true
+		}
+	}
+	return false
+}
+
+// Event описывает доменное событие. ID уникален для события и не меняется
+// при повторных доставках, поэтому получатели могут по нему отбрасывать дубликаты.
+// Seq — номер публикации события (заполняется при чтении опубликованных событий).
+type Event struct {
+	Seq                int64       `json:"-"`
+	ID                 string      `json:"id"`
+	Type               EventType   `json:"type"`
+	OccurredAt         time.Time   `json:"occurred_at"`
+	PullRequestID      string      `json:"pull_request_id"`
+	PullRequestName    string      `json:"pull_request_name,omitempty"`
+	AuthorID           string      `json:"author_id,omitempty"`
+	Reviewers          []string    `json:"reviewers,omitempty"`
+	ReviewerID         string      `json:"reviewer_id,omitempty"`
+	ReplacedReviewerID string      `json:"replaced_reviewer_id,omitempty"`
+	ReviewState        ReviewState `json:"review_state,omitempty"`
+	Reason             string      `json:"reason,omitempty"`
+}
+
+// Виды изменений в потоке ревью пользователя (см. Event.ReviewStreamKind).
+const (
+	ReviewStreamAssigned   = "assigned"
+	ReviewStreamUnassigned = "unassigned"
+	ReviewStreamPRMerged   = "pr_merged"
+	ReviewStreamSubmitted  = "review_submitted"
+)
+
+// ReviewStreamKind сообщает, чем событие является для пользователя userID:
+// назначением, снятием с PR, слиянием PR, который он ревьюит, или ревью в PR, где он
+// ревьювер или автор. Для остальных событий — "".
+func (e Event) ReviewStreamKind(userID string) string {
+	switch {
+	case e.Type == EventReviewerAssigned && e.ReviewerID == userID:
+		return ReviewStreamAssigned
+	case e.Type == EventReviewerAssigned && e.ReplacedReviewerID == userID,
+		e.Type == EventReviewerRemoved && e.ReviewerID == userID:
+		return ReviewStreamUnassigned
+	case e.Type == EventPRMerged:
+		for _, id := range e.Reviewers {
+			if id == userID {
+				return ReviewStreamPRMerged
+			}
+		}
+	case e.Type == EventReviewSubmitted:
+		if e.AuthorID == userID {
+			return ReviewStreamSubmitted
+		}
+		for _, id := range e.Reviewers {
+			if id == userID {
+				return ReviewStreamSubmitted
+			}
+		}
+	}
+	return ""
+}
+
+// ReviewSubscription — подписка на поток событий ревью пользователя. Backlog — пропущенные
+// с момента переподключения события, Events — новые; канал закрывается, если получатель
+// не успевает читать. Close отменяет подписку.
+type ReviewSubscription struct {
+	Backlog []Event
+	Events  <-chan Event
+	Close   func()
+}
+
+// WebhookSubscription описывает подписку на исходящие вебхуки. Пустой EventTypes
+// означает подписку на все события. Secret используется для подписи доставок
+// и отдаётся клиенту только при создании подписки.
+type WebhookSubscription struct {
+	ID         int64       `json:"id"`
+	URL        string      `json:"url"`
+	Secret     string      `json:"secret,omitempty"`
+	EventTypes []EventType `json:"event_types"`
+	CreatedAt  time.Time   `json:"created_at"`
+}
+
+// WebhookDelivery — доставка одного события одному подписчику, ожидающая отправки.
+// Attempts — число уже сделанных неудачных попыток.
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	URL            string
+	Secret         string
+	EventID        string
+	EventType      EventType
+	Payload        []byte
+	Attempts       int
+}
+
+// WebhookDeadLetter — доставка, исчерпавшая попытки. Её можно отправить заново через redeliver.
+type WebhookDeadLetter struct {
+	ID             int64     `json:"id"`
+	SubscriptionID int64     `json:"subscription_id"`
+	EventID        string    `json:"event_id"`
+	EventType      EventType `json:"event_type"`
+	Attempts       int       `json:"attempts"`
+	LastError      string    `json:"last_error"`
+	FailedAt       time.Time `json:"failed_at"`
+}
+
+// SinkCursor — позиция внешнего sink outbox в журнале опубликованных событий:
+// LastSeq — номер последнего принятого им события, Attempts — число неудачных попыток
+// отправить следующую пачку.
+type SinkCursor struct {
+	Sink     string
+	LastSeq  int64
+	Attempts int
+}
@@ -0,0 +1,56 @@
+package model
+
+import "time"
+
+// JobRunStatus — состояние запуска задачи планировщика.
+type JobRunStatus string
+
+const (
+	// JobRunning — задача выполняется. Если экземпляр сервиса упал во время выполнения,
+	// новый лидер переводит запуск в JobFailed.
+	JobRunning JobRunStatus = "running"
+	// JobSucceeded — задача завершилась успешно.
+	JobSucceeded JobRunStatus = "succeeded"
+	// JobFailed — задача завершилась ошибкой; её изменения откатены.
+	JobFailed JobRunStatus = "failed"
+)
+
+// JobRun описывает один запуск задачи планировщика. ScheduledAt — момент срабатывания
+// расписания, к которому относится запуск; Summary — краткий итог, который вернула задача.
+type JobRun struct {
+	ID          int64        `json:"id"`
+	Job         string       `json:"job"`
+	ScheduledAt time.Time    `json:"scheduled_at"`
+	StartedAt   time.Time    `json:"started_at"`
+	FinishedAt  *time.Time   `json:"finished_at,omitempty"`
+	Status      JobRunStatus `json:"status"`
+	Summary     string       `json:"summary,omitempty"`
+	Error       string       `json:"error,omitempty"`
+}
+
+// ReviewReminder — напоминание ревьюверу о давно ожидающих его ревью.
+type ReviewReminder struct {
+	UserID   string
+	Username string
+	Email    string
+	Reviews  []PendingReview
+}
+
+// StalledPR — открытый PR, который давно ждёт ревью. PendingReviewers — ревьюверы,
+// ещё не оставившие ревью (пусто, если ревьюверов нет).
+type StalledPR struct {
+	PullRequestID    string
+	PullRequestName  string
+	AuthorID         string
+	TeamName         string
+	CreatedAt        time.Time
+	PendingReviewers []string
+}
+
+// StalledSummary — сводка по зависшим PR команды для её руководителя.
+type StalledSummary struct {
+	TeamName     string
+	LeadUsername string
+	LeadEmail    string
+	PullRequests []StalledPR
+}
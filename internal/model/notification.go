@@ -0,0 +1,78 @@
+package model
+
+import "time"
+
+// ChatNotification — сообщение в очереди на отправку в incoming webhook чата команды.
+type ChatNotification struct {
+	ID         int64
+	WebhookURL string
+	Text       string
+	Attempts   int
+}
+
+// ChatUser — участник уведомления в чат: chat handle (может быть пустым) и username.
+type ChatUser struct {
+	Handle   string
+	Username string
+}
+
+// ChatContext — данные для уведомления о PR в чат: канал команды автора,
+// автор и участники по user_id. Пустой WebhookURL означает, что у команды уведомления не настроены.
+type ChatContext struct {
+	WebhookURL      string
+	PullRequestID   string
+	PullRequestName string
+	Author          ChatUser
+	Users           map[string]ChatUser
+}
+
+// EmailMode — режим почтовых уведомлений пользователя о назначенных ревью.
+type EmailMode string
+
+const (
+	// EmailOff — письма не отправляются.
+	EmailOff EmailMode = "off"
+	// EmailImmediate — письмо на каждое назначение.
+	EmailImmediate EmailMode = "immediate"
+	// EmailHourly — сводка ожидающих ревью не чаще раза в час.
+	EmailHourly EmailMode = "hourly"
+	// EmailDaily — сводка ожидающих ревью не чаще раза в сутки.
+	EmailDaily EmailMode = "daily"
+)
+
+// IsValid сообщает, является ли значение одним из известных режимов.
+func (m EmailMode) IsValid() bool {
+	switch m {
+	case EmailOff, EmailImmediate, EmailHourly, EmailDaily:
+		return true
+	}
+	return false
+}
+
+// EmailNotification — письмо в очереди на отправку.
+type EmailNotification struct {
+	ID       int64
+	To       string
+	Subject  string
+	Body     string
+	Attempts int
+}
+
+// AssignmentMail — данные письма о назначении ревьювера с режимом immediate.
+type AssignmentMail struct {
+	Email           string
+	Username        string
+	PullRequestID   string
+	PullRequestName string
+	Author          string
+}
+
+// DigestRecipient — пользователь, которому пора отправить сводку. PreviousDigestAt — время
+// предыдущей сводки (nil, если её не было): назначения после него отмечаются как новые.
+type DigestRecipient struct {
+	UserID           string
+	Username         string
+	Email            string
+	Mode             EmailMode
+	PreviousDigestAt *time.Time
+}
@@ -11,29 +11,211 @@ const (
 	StatusOpen PullRequestStatus = "OPEN"
 	// StatusMerged означает, что pull request был влит (merged).
 	StatusMerged PullRequestStatus = "MERGED"
+	// StatusClosed означает, что pull request закрыт без слияния; его можно открыть снова.
+	StatusClosed PullRequestStatus = "CLOSED"
 )
 
+// IsValid сообщает, является ли значение одним из статусов PR.
+func (s PullRequestStatus) IsValid() bool {
+	switch s {
+	case StatusOpen, StatusMerged, StatusClosed:
+		return true
+	}
+	return false
+}
+
+// PullRequestPriority представляет приоритет pull request'а.
+type PullRequestPriority string
+
+const (
+	// PriorityLow — низкий приоритет, PR может подождать.
+	PriorityLow PullRequestPriority = "low"
+	// PriorityNormal — обычный приоритет, используется по умолчанию.
+	PriorityNormal PullRequestPriority = "normal"
+	// PriorityHigh — высокий приоритет.
+	PriorityHigh PullRequestPriority = "high"
+	// PriorityHotfix — срочное исправление, ревьюится в первую очередь.
+	PriorityHotfix PullRequestPriority = "hotfix"
+)
+
+// Priorities перечисляет допустимые приоритеты в порядке возрастания срочности.
+var Priorities = []PullRequestPriority{PriorityLow, PriorityNormal, PriorityHigh, PriorityHotfix}
+
+// IsValid сообщает, является ли значение одним из допустимых приоритетов.
+func (p PullRequestPriority) IsValid() bool {
+	for _, v := range Priorities {
+		if p == v {
+			return true
+		}
+	}
+	return false
+}
+
 // PullRequest описывает полный объект pr с авторами, статусом, ревьюверами и временными метками.
 type PullRequest struct {
-	PullRequestID     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	AuthorID          string            `json:"author_id"`
-	Status            PullRequestStatus `json:"status"`
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	CreatedAt         *time.Time        `json:"createdAt,omitempty"`
-	MergedAt          *time.Time        `json:"mergedAt,omitempty"`
+	PullRequestID     string              `json:"pull_request_id"`
+	PullRequestName   string              `json:"pull_request_name"`
+	AuthorID          string              `json:"author_id"`
+	Status            PullRequestStatus   `json:"status"`
+	Priority          PullRequestPriority `json:"priority"`
+	AssignedReviewers []string            `json:"assigned_reviewers"`
+	CreatedAt         *time.Time          `json:"createdAt,omitempty"`
+	MergedAt          *time.Time          `json:"mergedAt,omitempty"`
 }
 
 // PullRequestShort описывает укороченное представление pr, которое используется в списках (без ревьюверов и временных полей).
 type PullRequestShort struct {
-	PullRequestID   string            `json:"pull_request_id"`
-	PullRequestName string            `json:"pull_request_name"`
-	AuthorID        string            `json:"author_id"`
-	Status          PullRequestStatus `json:"status"`
+	PullRequestID   string              `json:"pull_request_id"`
+	PullRequestName string              `json:"pull_request_name"`
+	AuthorID        string              `json:"author_id"`
+	Status          PullRequestStatus   `json:"status"`
+	Priority        PullRequestPriority `json:"priority"`
+}
+
+// ReviewState представляет состояние ревью конкретного ревьювера в PR.
+type ReviewState string
+
+const (
+	// ReviewPending означает, что ревьювер ещё не оставил ревью.
+	ReviewPending ReviewState = "PENDING"
+	// ReviewApproved означает, что ревьювер одобрил PR.
+	ReviewApproved ReviewState = "APPROVED"
+	// ReviewChangesRequested означает, что ревьювер запросил изменения.
+	ReviewChangesRequested ReviewState = "CHANGES_REQUESTED"
+)
+
+// IsValid сообщает, является ли значение одним из допустимых состояний ревью.
+func (s ReviewState) IsValid() bool {
+	switch s {
+	case ReviewPending, ReviewApproved, ReviewChangesRequested:
+		return true
+	}
+	return false
+}
+
+// ReviewerState описывает назначение ревьювера на PR: состояние ревью и его возраст.
+// AgeSeconds — сколько ревьювер держит PR: до ревью, а если его ещё нет — до текущего момента.
+type ReviewerState struct {
+	ReviewerID string      `json:"reviewer_id"`
+	State      ReviewState `json:"state"`
+	AssignedAt time.Time   `json:"assigned_at"`
+	ReviewedAt *time.Time  `json:"reviewed_at,omitempty"`
+	AgeSeconds int64       `json:"age_seconds"`
+}
+
+// AuthoredPullRequest описывает PR с точки зрения автора: статус, возраст и состояние каждого ревьювера.
+// AgeSeconds — время жизни PR до мержа, а для открытого PR — до текущего момента.
+type AuthoredPullRequest struct {
+	PullRequestID   string              `json:"pull_request_id"`
+	PullRequestName string              `json:"pull_request_name"`
+	Status          PullRequestStatus   `json:"status"`
+	Priority        PullRequestPriority `json:"priority"`
+	CreatedAt       time.Time           `json:"createdAt"`
+	MergedAt        *time.Time          `json:"mergedAt,omitempty"`
+	AgeSeconds      int64               `json:"age_seconds"`
+	Reviewers       []ReviewerState     `json:"reviewers"`
+}
+
+// PullRequestSort задаёт порядок сортировки списка PR. Префикс "-" означает убывание.
+type PullRequestSort string
+
+const (
+	// SortCreatedDesc — сначала новые PR (порядок по умолчанию).
+	SortCreatedDesc PullRequestSort = "-created_at"
+	// SortCreatedAsc — сначала старые PR.
+	SortCreatedAsc PullRequestSort = "created_at"
+	// SortNameAsc — по названию PR в алфавитном порядке.
+	SortNameAsc PullRequestSort = "pull_request_name"
+	// SortNameDesc — по названию PR в обратном алфавитном порядке.
+	SortNameDesc PullRequestSort = "-pull_request_name"
+)
+
+// IsValid сообщает, поддерживается ли такой порядок сортировки.
+func (s PullRequestSort) IsValid() bool {
+	switch s {
+	case SortCreatedDesc, SortCreatedAsc, SortNameAsc, SortNameDesc:
+		return true
+	}
+	return false
+}
+
+// PullRequestKey — ключ keyset-пагинации: значения колонок сортировки последнего PR страницы.
+type PullRequestKey struct {
+	CreatedAt       time.Time
+	PullRequestName string
+	PullRequestID   string
+}
+
+// PullRequestFilter задаёт параметры выборки списка PR. Пустые поля не фильтруют.
+// Границы дат включают From и исключают To. TeamName фильтрует по команде автора.
+type PullRequestFilter struct {
+	Status       PullRequestStatus
+	Priority     PullRequestPriority
+	AuthorID     string
+	ReviewerID   string
+	TeamName     string
+	NameContains string
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	MergedFrom   *time.Time
+	MergedTo     *time.Time
+	Sort         PullRequestSort
+	After        *PullRequestKey
+	Limit        int
 }
 
 // StatsDTO используется для возврата статистики по ревьюверам.
+// ByPriority содержит разбивку ReviewCount по приоритетам PR.
 type StatsDTO struct {
-	ReviewerID  string `json:"reviewer_id"`
-	ReviewCount int    `json:"review_count"`
+	ReviewerID  string                      `json:"reviewer_id"`
+	ReviewCount int                         `json:"review_count"`
+	ByPriority  map[PullRequestPriority]int `json:"by_priority"`
+}
+
+// ReassignAction описывает, что сделано с местом ушедшего ревьювера.
+type ReassignAction string
+
+const (
+	// ReassignActionReassigned — место отдано другому ревьюверу.
+	ReassignActionReassigned ReassignAction = "REASSIGNED"
+	// ReassignActionRemoved — замены нет, ревьювер просто снят с PR.
+	ReassignActionRemoved ReassignAction = "REMOVED"
+)
+
+// ReassignReason объясняет, откуда взята замена или почему её нет.
+type ReassignReason string
+
+const (
+	// ReasonTeamMember — замена найдена в команде ушедшего ревьювера.
+	ReasonTeamMember ReassignReason = "TEAM_MEMBER"
+	// ReasonParentTeamMember — в команде заменить некем, замена взята из ближайшей родительской команды.
+	ReasonParentTeamMember ReassignReason = "PARENT_TEAM_MEMBER"
+	// ReasonNoCandidate — активных кандидатов нет ни в команде, ни выше по иерархии.
+	ReasonNoCandidate ReassignReason = "NO_CANDIDATE"
+	// ReasonPRClosed — PR закрыт без слияния, ревьювер освобождён без замены.
+	ReasonPRClosed ReassignReason = "PR_CLOSED"
+)
+
+// ReviewReassignment описывает, что стало с ревью ушедшего ревьювера в конкретном PR.
+// NewReviewerID пуст, если замены не нашлось и ревьювер просто снят с PR.
+type ReviewReassignment struct {
+	PullRequestID string         `json:"pull_request_id"`
+	OldReviewerID string         `json:"old_reviewer_id"`
+	NewReviewerID string         `json:"new_reviewer_id,omitempty"`
+	Action        ReassignAction `json:"action"`
+	Reason        ReassignReason `json:"reason"`
+}
+
+// DeactivationReport описывает последствия деактивации пользователей для открытых PR.
+// Preview означает, что изменения только рассчитаны и не сохранены.
+type DeactivationReport struct {
+	Preview       bool                 `json:"preview"`
+	UserIDs       []string             `json:"user_ids"`
+	Reassignments []ReviewReassignment `json:"reassignments"`
+}
+
+// PendingReview — открытый PR, ревью которого ревьювер ещё не оставил, и время назначения.
+type PendingReview struct {
+	PullRequestShort
+	AssignedAt time.Time `json:"assigned_at"`
 }
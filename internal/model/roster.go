@@ -0,0 +1,53 @@
+package model
+
+// Roster описывает состав команд для массового импорта и экспорта.
+// Команды перечислены так, что родитель может идти как до, так и после подкоманды.
+type Roster struct {
+	Teams []Team
+}
+
+// TeamParentChange описывает смену родительской команды при импорте.
+// Пустые From/To означают корневую команду.
+type TeamParentChange struct {
+	TeamName string `json:"team_name"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+}
+
+// UserTeamChange описывает перевод пользователя между командами при импорте.
+// Пустой From означает, что пользователь не состоял ни в одной команде.
+type UserTeamChange struct {
+	UserID string `json:"user_id"`
+	From   string `json:"from"`
+	To     string `json:"to"`
+}
+
+// RosterDiff — сводка изменений, внесённых (или которые будут внесены) импортом или синхронизацией состава.
+// UsersUpdated — пользователи со сменой username; смена активности попадает в UsersDeactivated/UsersReactivated.
+// Reassignments — ревью, переназначенные из-за переводов и деактиваций. PlanOnly означает,
+// что изменения только рассчитаны и не сохранены.
+type RosterDiff struct {
+	PlanOnly         bool                 `json:"plan_only"`
+	TeamsCreated     []string             `json:"teams_created"`
+	TeamsMoved       []TeamParentChange   `json:"teams_moved"`
+	UsersCreated     []string             `json:"users_created"`
+	UsersUpdated     []string             `json:"users_updated"`
+	UsersMoved       []UserTeamChange     `json:"users_moved"`
+	UsersDeactivated []string             `json:"users_deactivated"`
+	UsersReactivated []string             `json:"users_reactivated"`
+	Reassignments    []ReviewReassignment `json:"reassignments"`
+}
+
+// NewRosterDiff создаёт пустую сводку с инициализированными списками (для стабильного JSON).
+func NewRosterDiff() RosterDiff {
+	return RosterDiff{
+		TeamsCreated:     make([]string, 0),
+		TeamsMoved:       make([]TeamParentChange, 0),
+		UsersCreated:     make([]string, 0),
+		UsersUpdated:     make([]string, 0),
+		UsersMoved:       make([]UserTeamChange, 0),
+		UsersDeactivated: make([]string, 0),
+		UsersReactivated: make([]string, 0),
+		Reassignments:    make([]ReviewReassignment, 0),
+	}
+}
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// StatsFilter ограничивает статистику ревью. Пустые поля не фильтруют.
+// TeamName — текущая команда ревьювера; From и To (полуинтервал [From, To)) — время назначения;
+// Status — текущий статус PR.
+type StatsFilter struct {
+	TeamName string
+	From     *time.Time
+	To       *time.Time
+	Status   PullRequestStatus
+}
+
+// ReviewerStats — нагрузка ревьювера. TotalAssignments учитывает и назначения, с которых
+// ревьювер был снят (ReassignedAway); Open и Merged — только оставшиеся за ним.
+// AvgOpenAgeHours — среднее время с назначения по ревью открытых PR.
+type ReviewerStats struct {
+	ReviewerID       string                      `json:"reviewer_id"`
+	Username         string                      `json:"username"`
+	TeamName         string                      `json:"team_name,omitempty"`
+	IsActive         bool                        `json:"is_active"`
+	TotalAssignments int                         `json:"total_assignments"`
+	Open             int                         `json:"open"`
+	Merged           int                         `json:"merged"`
+	ReassignedAway   int                         `json:"reassigned_away"`
+	AvgOpenAgeHours  float64                     `json:"avg_open_age_hours"`
+	ByPriority       map[PullRequestPriority]int `json:"by_priority"`
+}
+
+// TeamLoadStats — суммарная нагрузка ревьюверов команды. Gini — коэффициент Джини
+// числа назначений по активным участникам: 0 — нагрузка распределена поровну,
+// ближе к 1 — почти все ревью достаются одному.
+type TeamLoadStats struct {
+	TeamName         string  `json:"team_name"`
+	ActiveReviewers  int     `json:"active_reviewers"`
+	TotalAssignments int     `json:"total_assignments"`
+	Open             int     `json:"open"`
+	Merged           int     `json:"merged"`
+	ReassignedAway   int     `json:"reassigned_away"`
+	Gini             float64 `json:"gini"`
+}
+
+// ReviewStats — ответ /stats: ревьюверы по убыванию числа назначений и команды по имени.
+type ReviewStats struct {
+	Reviewers []ReviewerStats `json:"reviewers"`
+	Teams     []TeamLoadStats `json:"teams"`
+}
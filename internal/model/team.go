@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // TeamMember описывает участника команды с его идентификатором, отображаемым именем и признаком активности.
 type TeamMember struct {
 	UserID   string `json:"user_id"`
@@ -8,7 +10,51 @@ type TeamMember struct {
 }
 
 // Team описывает команду и список её участников.
+// ParentTeamName пуст у команд верхнего уровня, ArchivedAt заполнен только у архивных команд.
 type Team struct {
-	TeamName string       `json:"team_name"`
-	Members  []TeamMember `json:"members"`
+	TeamName       string       `json:"team_name"`
+	ParentTeamName string       `json:"parent_team_name,omitempty"`
+	Members        []TeamMember `json:"members"`
+	ArchivedAt     *time.Time   `json:"archived_at,omitempty"`
+}
+
+// TeamSummary описывает команду в списке: без состава, но с количеством участников.
+type TeamSummary struct {
+	TeamName           string     `json:"team_name"`
+	ParentTeamName     string     `json:"parent_team_name,omitempty"`
+	MembersCount       int        `json:"members_count"`
+	ActiveMembersCount int        `json:"active_members_count"`
+	ArchivedAt         *time.Time `json:"archived_at,omitempty"`
+}
+
+// TeamFilter задаёт параметры выборки списка команд.
+// After — имя последней команды предыдущей страницы (keyset-пагинация).
+type TeamFilter struct {
+	IncludeArchived bool
+	After           string
+	Limit           int
+}
+
+// TeamNode описывает команду в поддереве иерархии. Depth — расстояние от корня поддерева (дети — 1).
+type TeamNode struct {
+	TeamName       string `json:"team_name"`
+	ParentTeamName string `json:"parent_team_name"`
+	Depth          int    `json:"depth"`
+}
+
+// TeamCounters содержит счётчики участников и ревью команды.
+type TeamCounters struct {
+	MembersCount       int `json:"members_count"`
+	ActiveMembersCount int `json:"active_members_count"`
+	ReviewCount        int `json:"review_count"`
+	OpenReviewCount    int `json:"open_review_count"`
+}
+
+// TeamStatsDTO используется для возврата статистики по команде:
+// Direct — только прямые участники, Rollup — вместе со всеми подкомандами.
+type TeamStatsDTO struct {
+	TeamName       string       `json:"team_name"`
+	ParentTeamName string       `json:"parent_team_name,omitempty"`
+	Direct         TeamCounters `json:"direct"`
+	Rollup         TeamCounters `json:"rollup"`
 }
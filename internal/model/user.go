@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 // User описывает пользователя, его юзернейм, команду, статус активности.
 type User struct {
 	UserID   string `json:"user_id"`
@@ -7,3 +9,42 @@ type User struct {
 	TeamName string `json:"team_name"`
 	IsActive bool   `json:"is_active"`
 }
+
+// UserFilter задаёт параметры выборки списка пользователей. Пустые поля не фильтруют.
+// After — user_id последнего пользователя предыдущей страницы (keyset-пагинация),
+// Offset — число пропускаемых пользователей для клиентов со страницами по номеру (SCIM).
+// Username сравнивается без учёта регистра.
+type UserFilter struct {
+	UserID         string
+	TeamName       string
+	IsActive       *bool
+	Username       string
+	UsernamePrefix string
+	After          string
+	Offset         int
+	Limit          int
+}
+
+// UserActivityEvent описывает одно изменение флага активности пользователя.
+type UserActivityEvent struct {
+	UserID    string    `json:"user_id"`
+	IsActive  bool      `json:"is_active"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// UserRampUp описывает вернувшегося пользователя: когда он был активирован
+// и сколько ревью ему назначено с тех пор.
+type UserRampUp struct {
+	UserID        string
+	ActiveSince   time.Time
+	AssignedSince int
+}
+
+// WorkingHours описывает рабочее время пользователя: с StartHour (включительно) до EndHour
+// (не включительно) в часовом поясе TimeZone. StartHour > EndHour — смена через полночь.
+type WorkingHours struct {
+	UserID    string `json:"user_id"`
+	TimeZone  string `json:"timezone"`
+	StartHour int    `json:"start_hour"`
+	EndHour   int    `json:"end_hour"`
+}
@@ -0,0 +1,75 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "pull-request-service/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Queue is an autogenerated mock type for the Queue type
+type Queue struct {
+	mock.Mock
+}
+
+// ChatContext provides a mock function with given fields: ctx, prID, userIDs
+func (_m *Queue) ChatContext(ctx context.Context, prID string, userIDs []string) (model.ChatContext, error) {
+	ret := _m.Called(ctx, prID, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChatContext")
+	}
+
+	var r0 model.ChatContext
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (model.ChatContext, error)); ok {
+		return rf(ctx, prID, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) model.ChatContext); ok {
+		r0 = rf(ctx, prID, userIDs)
+	} else {
+		r0 = ret.Get(0).(model.ChatContext)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, prID, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnqueueChatNotification provides a mock function with given fields: ctx, webhookURL, text
+func (_m *Queue) EnqueueChatNotification(ctx context.Context, webhookURL string, text string) error {
+	ret := _m.Called(ctx, webhookURL, text)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EnqueueChatNotification")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, webhookURL, text)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewQueue creates a new instance of Queue. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewQueue(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Queue {
+	mock := &Queue{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
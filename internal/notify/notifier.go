@@ -0,0 +1,264 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/outbound"
+)
+
+const (
+	// batchSize — сколько сообщений забирается из очереди за раз.
+	batchSize = 50
+	// pollInterval — пауза между опросами очереди, если она пуста.
+	pollInterval = 2 * time.Second
+	// requestTimeout ограничивает ожидание ответа чата.
+	requestTimeout = 10 * time.Second
+	// claimLease — на сколько забранные сообщения скрываются от других экземпляров сервиса.
+	claimLease = 2 * time.Minute
+	// sendBudget — сколько времени после claim можно отправлять пачку. Сообщения, не успевшие
+	// уйти из-за ограничения частоты, возвращаются в очередь, пока не истёк lease.
+	sendBudget = claimLease / 2
+)
+
+// DefaultInterval — минимальный интервал между сообщениями в один канал:
+// incoming webhooks Slack принимают не больше одного сообщения в секунду.
+const DefaultInterval = time.Second
+
+// DefaultRetryPolicy — 6 попыток в течение примерно 20 минут: уведомление о ревью,
+// пришедшее позже, уже мало полезно.
+var DefaultRetryPolicy = outbound.RetryPolicy{MaxAttempts: 6, BaseDelay: 15 * time.Second, MaxDelay: 10 * time.Minute}
+
+// Store описывает очередь сообщений, из которой работает Notifier.
+type Store interface {
+	ClaimChatNotifications(ctx context.Context, limit int, lease time.Duration) ([]model.ChatNotification, error)
+	CompleteChatNotification(ctx context.Context, id int64) error
+	RetryChatNotification(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string) error
+	PostponeChatNotifications(ctx context.Context, ids []int64, until time.Time) error
+	FailChatNotification(ctx context.Context, id int64, lastErr string) error
+}
+
+// rateLimitedError — ответ 429; RetryAfter берётся из заголовка Retry-After.
+type rateLimitedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *rateLimitedError) Error() string {
+	return fmt.Sprintf("rate limited, retry after %s", e.RetryAfter)
+}
+
+// Delay реализует outbound.Delayer: повтор не раньше Retry-After.
+func (e *rateLimitedError) Delay() time.Duration {
+	return e.RetryAfter
+}
+
+// Notifier отправляет сообщения из очереди в incoming webhooks чатов. Сообщения одного канала
+// уходят по очереди и не чаще раза в interval; ответ 429 приостанавливает канал на Retry-After.
+type Notifier struct {
+	store    Store
+	retrier  outbound.Retrier
+	interval time.Duration
+	client   *http.Client
+	log      *slog.Logger
+
+	mu   sync.Mutex
+	next map[string]time.Time // когда в канал можно отправить следующее сообщение
+}
+
+// NewNotifier создаёт notifier с интервалом interval между сообщениями в один канал.
+func NewNotifier(store Store, policy outbound.RetryPolicy, interval time.Duration, log *slog.Logger) *Notifier {
+	return &Notifier{
+		store: store,
+		retrier: outbound.Retrier{
+			Policy:   policy,
+			Complete: store.CompleteChatNotification,
+			Retry:    store.RetryChatNotification,
+			Fail:     store.FailChatNotification,
+			Log:      log,
+			Message:  "chat notification failed",
+		},
+		interval: interval,
+		client:   &http.Client{Timeout: requestTimeout},
+		log:      log,
+		next:     make(map[string]time.Time),
+	}
+}
+
+// Run отправляет сообщения, пока не отменён ctx.
+func (n *Notifier) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		sent, err := n.NotifyOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			n.log.Error("chat notify failed", slog.Any("err", err))
+		}
+		if sent == batchSize && err == nil {
+			timer.Reset(0)
+		} else {
+			timer.Reset(pollInterval)
+		}
+	}
+}
+
+// NotifyOnce забирает пачку сообщений и отправляет их: каналы обслуживаются параллельно,
+// сообщения одного канала — последовательно в порядке постановки в очередь.
+// Возвращает число забранных сообщений.
+func (n *Notifier) NotifyOnce(ctx context.Context) (int, error) {
+	claimed, err := n.store.ClaimChatNotifications(ctx, batchSize, claimLease)
+	if err != nil {
+		return 0, err
+	}
+	deadline := time.Now().Add(sendBudget)
+
+	byURL := make(map[string][]model.ChatNotification)
+	for _, m := range claimed {
+		byURL[m.WebhookURL] = append(byURL[m.WebhookURL], m)
+	}
+
+	var wg sync.WaitGroup
+	for url, msgs := range byURL {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := n.sendChannel(ctx, msgs, deadline); err != nil {
+				n.log.Error("chat notification bookkeeping failed", slog.String("url", url), slog.Any("err", err))
+			}
+		}()
+	}
+	wg.Wait()
+	return len(claimed), nil
+}
+
+// sendChannel отправляет сообщения одного канала. Если до отправки очередного сообщения
+// пришлось бы ждать дольше deadline или канал ответил 429, оставшиеся сообщения
+// возвращаются в очередь без списания попытки.
+func (n *Notifier) sendChannel(ctx context.Context, msgs []model.ChatNotification, deadline time.Time) error {
+	for i, m := range msgs {
+		at := n.nextSlot(m.WebhookURL)
+		if at.After(deadline) {
+			return n.store.PostponeChatNotifications(ctx, ids(msgs[i:]), at)
+		}
+		if err := sleepUntil(ctx, at); err != nil {
+			return n.store.PostponeChatNotifications(context.WithoutCancel(ctx), ids(msgs[i:]), time.Now())
+		}
+
+		sendErr := n.send(ctx, m)
+		n.markSent(m.WebhookURL, sendErr)
+		if err := n.retrier.Record(ctx, m.ID, m.Attempts, sendErr, slog.Int64("notification_id", m.ID)); err != nil {
+			return err
+		}
+
+		var limited *rateLimitedError
+		if errors.As(sendErr, &limited) && i+1 < len(msgs) {
+			return n.store.PostponeChatNotifications(ctx, ids(msgs[i+1:]), n.nextSlot(m.WebhookURL))
+		}
+	}
+	return nil
+}
+
+// nextSlot возвращает момент, не раньше которого можно писать в канал.
+func (n *Notifier) nextSlot(url string) time.Time {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	now := time.Now()
+	if next := n.next[url]; next.After(now) {
+		return next
+	}
+	return now
+}
+
+// markSent сдвигает следующий слот канала на interval, а после 429 — на Retry-After.
+func (n *Notifier) markSent(url string, sendErr error) {
+	wait := n.interval
+	var limited *rateLimitedError
+	if errors.As(sendErr, &limited) {
+		wait = max(wait, limited.RetryAfter)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.next[url] = time.Now().Add(wait)
+}
+
+// send публикует сообщение в формате incoming webhook Slack: {"text": "..."}.
+func (n *Notifier) send(ctx context.Context, m model.ChatNotification) error {
+	body, err := json.Marshal(struct {
+		Text string `json:"text"`
+	}{m.Text})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &rateLimitedError{RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After"))}
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// parseRetryAfter разбирает Retry-After в секундах или в виде HTTP-даты.
+// Без заголовка канал приостанавливается на минуту.
+func parseRetryAfter(v string) time.Duration {
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return time.Minute
+}
+
+func sleepUntil(ctx context.Context, at time.Time) error {
+	d := time.Until(at)
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+func ids(msgs []model.ChatNotification) []int64 {
+	out := make([]int64, 0, len(msgs))
+	for _, m := range msgs {
+		out = append(out, m.ID)
+	}
+	return out
+}
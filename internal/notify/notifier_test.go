@@ -0,0 +1,166 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/notify"
+	"pull-request-service/internal/notify/mocks"
+	"pull-request-service/internal/outbound"
+)
+
+// chatStandIn — локальная замена incoming webhook чата: запоминает тексты и время запросов
+// и отвечает статусами из statuses по очереди (последний повторяется).
+type chatStandIn struct {
+	mu       sync.Mutex
+	statuses []int
+	header   http.Header
+	texts    []string
+	times    []time.Time
+}
+
+func (c *chatStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var payload struct {
+		Text string `json:"text"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&payload)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	status := c.statuses[min(len(c.texts), len(c.statuses)-1)]
+	c.texts = append(c.texts, payload.Text)
+	c.times = append(c.times, time.Now())
+	for k, v := range c.header {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(status)
+}
+
+func newNotifier(store notify.Store, interval time.Duration) *notify.Notifier {
+	policy := outbound.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+	return notify.NewNotifier(store, policy, interval, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestNotifier_NotifyOnce(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		attempts   int
+		setupMocks func(store *mocks.Store)
+	}{
+		{
+			name:   "Success: 2xx completes notification",
+			status: http.StatusOK,
+			setupMocks: func(store *mocks.Store) {
+				store.On("CompleteChatNotification", mock.Anything, int64(1)).Return(nil)
+			},
+		},
+		{
+			name:     "Retry: failure is rescheduled with backoff",
+			status:   http.StatusInternalServerError,
+			attempts: 1,
+			setupMocks: func(store *mocks.Store) {
+				store.On("RetryChatNotification", mock.Anything, int64(1), mock.MatchedBy(func(at time.Time) bool {
+					d := time.Until(at)
+					return d > time.Minute+50*time.Second && d <= 2*time.Minute
+				}), "unexpected status 500").Return(nil)
+			},
+		},
+		{
+			name:     "Fail: last attempt fails",
+			status:   http.StatusNotFound,
+			attempts: 2,
+			setupMocks: func(store *mocks.Store) {
+				store.On("FailChatNotification", mock.Anything, int64(1), "unexpected status 404").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chat := &chatStandIn{statuses: []int{tt.status}}
+			srv := httptest.NewServer(chat)
+			defer srv.Close()
+
+			store := new(mocks.Store)
+			store.On("ClaimChatNotifications", mock.Anything, mock.Anything, mock.Anything).Return([]model.ChatNotification{
+				{ID: 1, WebhookURL: srv.URL, Text: "review requested", Attempts: tt.attempts},
+			}, nil)
+			tt.setupMocks(store)
+
+			n, err := newNotifier(store, time.Millisecond).NotifyOnce(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+			assert.Equal(t, []string{"review requested"}, chat.texts)
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotifier_RateLimitsChannel(t *testing.T) {
+	chat := &chatStandIn{statuses: []int{http.StatusOK}}
+	srv := httptest.NewServer(chat)
+	defer srv.Close()
+
+	store := new(mocks.Store)
+	store.On("ClaimChatNotifications", mock.Anything, mock.Anything, mock.Anything).Return([]model.ChatNotification{
+		{ID: 1, WebhookURL: srv.URL, Text: "first"},
+		{ID: 2, WebhookURL: srv.URL, Text: "second"},
+		{ID: 3, WebhookURL: srv.URL, Text: "third"},
+	}, nil)
+	store.On("CompleteChatNotification", mock.Anything, mock.Anything).Return(nil).Times(3)
+
+	interval := 50 * time.Millisecond
+	_, err := newNotifier(store, interval).NotifyOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "third"}, chat.texts)
+	for i := 1; i < len(chat.times); i++ {
+		assert.GreaterOrEqual(t, chat.times[i].Sub(chat.times[i-1]), interval)
+	}
+	store.AssertExpectations(t)
+}
+
+func TestNotifier_TooManyRequests(t *testing.T) {
+	chat := &chatStandIn{
+		statuses: []int{http.StatusTooManyRequests},
+		header:   http.Header{"Retry-After": []string{"30"}},
+	}
+	srv := httptest.NewServer(chat)
+	defer srv.Close()
+
+	store := new(mocks.Store)
+	store.On("ClaimChatNotifications", mock.Anything, mock.Anything, mock.Anything).Return([]model.ChatNotification{
+		{ID: 1, WebhookURL: srv.URL, Text: "first"},
+		{ID: 2, WebhookURL: srv.URL, Text: "second"},
+		{ID: 3, WebhookURL: srv.URL, Text: "third"},
+	}, nil)
+	// Retry-After больше первой задержки политики не сокращает её
+	store.On("RetryChatNotification", mock.Anything, int64(1), mock.MatchedBy(func(at time.Time) bool {
+		return time.Until(at) > 50*time.Second
+	}), "rate limited, retry after 30s").Return(nil)
+	// Остальные сообщения канала возвращаются в очередь до конца паузы без отправки
+	store.On("PostponeChatNotifications", mock.Anything, []int64{2, 3}, mock.MatchedBy(func(at time.Time) bool {
+		d := time.Until(at)
+		return d > 25*time.Second && d <= 30*time.Second
+	})).Return(nil)
+
+	_, err := newNotifier(store, time.Millisecond).NotifyOnce(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"first"}, chat.texts)
+	store.AssertExpectations(t)
+}
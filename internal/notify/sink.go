@@ -0,0 +1,115 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/repository"
+)
+
+// Queue описывает чтение настроек чата и очередь сообщений, в которую пишет Sink.
+type Queue interface {
+	ChatContext(ctx context.Context, prID string, userIDs []string) (model.ChatContext, error)
+	EnqueueChatNotification(ctx context.Context, webhookURL, text string) error
+}
+
+// Sink — sink relay outbox, превращающий события в сообщения для чата команды автора PR.
+// Сообщения ставятся в очередь в транзакции relay, поэтому каждое событие порождает
+// сообщение ровно один раз, даже если его публикация повторяется после сбоя.
+type Sink struct {
+	queue     Queue
+	templates Templates
+}
+
+// NewSink создаёт sink уведомлений в чат.
+func NewSink(queue Queue, templates Templates) *Sink {
+	return &Sink{queue: queue, templates: templates}
+}
+
+// Name реализует outbox.Sink.
+func (s *Sink) Name() string { return "chat" }
+
+// Publish реализует outbox.Sink. Сообщение порождают создание PR с ревьюверами и замена
+// ревьювера; назначения при создании PR уже перечислены в сообщении о нём.
+// События команд без настроенного канала пропускаются.
+func (s *Sink) Publish(ctx context.Context, events []model.Event) error {
+	for _, ev := range events {
+		if err := s.publish(ctx, ev); err != nil {
+			return fmt.Errorf("event %s: %w", ev.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *Sink) publish(ctx context.Context, ev model.Event) error {
+	var users []string
+	switch {
+	case ev.Type == model.EventPRCreated && len(ev.Reviewers) > 0:
+		users = ev.Reviewers
+	case ev.Type == model.EventReviewerAssigned && ev.ReplacedReviewerID != "":
+		users = []string{ev.ReviewerID, ev.ReplacedReviewerID}
+	default:
+		return nil
+	}
+
+	chat, err := s.queue.ChatContext(ctx, ev.PullRequestID, users)
+	if errors.Is(err, repository.ErrPRNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if chat.WebhookURL == "" {
+		return nil
+	}
+
+	msg := Message{
+		PullRequestID:   escape(chat.PullRequestID),
+		PullRequestName: escape(chat.PullRequestName),
+		Author:          mention(chat.Author),
+	}
+	tpl := s.templates.Reassigned
+	if ev.Type == model.EventPRCreated {
+		tpl = s.templates.Created
+		for _, id := range ev.Reviewers {
+			msg.Reviewers = append(msg.Reviewers, mentionByID(chat, id))
+		}
+	} else {
+		msg.Reviewer = mentionByID(chat, ev.ReviewerID)
+		msg.Replaced = mentionByID(chat, ev.ReplacedReviewerID)
+	}
+
+	text, err := render(tpl, msg)
+	if err != nil {
+		return err
+	}
+	return s.queue.EnqueueChatNotification(ctx, chat.WebhookURL, text)
+}
+
+// escaper экранирует управляющие символы разметки Slack и Mattermost: без этого название PR
+// вида "<!channel>" или "<https://evil|docs>" превратилось бы в упоминание канала или ссылку.
+var escaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+// mention возвращает упоминание пользователя: chat handle задан им самим для этого чата
+// и подставляется как есть, а username экранируется, как и остальные поля сообщения.
+func mention(u model.ChatUser) string {
+	if u.Handle != "" {
+		return u.Handle
+	}
+	return escape(u.Username)
+}
+
+// mentionByID возвращает упоминание участника userID; неизвестный пользователь упоминается по id.
+func mentionByID(chat model.ChatContext, userID string) string {
+	if u, ok := chat.Users[userID]; ok {
+		return mention(u)
+	}
+	return escape(userID)
+}
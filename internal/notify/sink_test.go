@@ -0,0 +1,132 @@
+package notify_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/notify"
+	"pull-request-service/internal/notify/mocks"
+	"pull-request-service/internal/repository"
+)
+
+const hookURL = "https://chat.example.com/hooks/backend"
+
+func TestSink_Publish(t *testing.T) {
+	chat := model.ChatContext{
+		WebhookURL:      hookURL,
+		PullRequestID:   "pr-1",
+		PullRequestName: "Add search",
+		Author:          model.ChatUser{Handle: "@alice", Username: "alice"},
+		Users: map[string]model.ChatUser{
+			"u2": {Handle: "<@U2>", Username: "bob"},
+			"u3": {Handle: "@carol", Username: "carol"},
+		},
+	}
+	// Название PR и username без chat handle приходят из внешних систем и не должны стать разметкой чата
+	hostile := model.ChatContext{
+		WebhookURL:      hookURL,
+		PullRequestID:   "pr-6",
+		PullRequestName: "<!channel> Fix & <https://evil.example|docs>",
+		Author:          model.ChatUser{Username: "<@U0ADMIN>"},
+		Users: map[string]model.ChatUser{
+			"u2": {Handle: "<@U2>", Username: "bob"},
+			"u3": {Username: "carol>"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		event      model.Event
+		setupMocks func(q *mocks.Queue)
+	}{
+		{
+			name:  "Created: all reviewers are mentioned",
+			event: model.Event{ID: "ev-1", Type: model.EventPRCreated, PullRequestID: "pr-1", Reviewers: []string{"u2", "u3"}},
+			setupMocks: func(q *mocks.Queue) {
+				q.On("ChatContext", mock.Anything, "pr-1", []string{"u2", "u3"}).Return(chat, nil)
+				q.On("EnqueueChatNotification", mock.Anything, hookURL,
+					"Review requested on *Add search* (pr-1) by @alice: <@U2>, @carol").Return(nil)
+			},
+		},
+		{
+			name: "Reassigned: new and replaced reviewers are mentioned",
+			event: model.Event{ID: "ev-2", Type: model.EventReviewerAssigned, PullRequestID: "pr-1",
+				ReviewerID: "u3", ReplacedReviewerID: "u2"},
+			setupMocks: func(q *mocks.Queue) {
+				q.On("ChatContext", mock.Anything, "pr-1", []string{"u3", "u2"}).Return(chat, nil)
+				q.On("EnqueueChatNotification", mock.Anything, hookURL,
+					"@carol now reviews *Add search* (pr-1) instead of <@U2>").Return(nil)
+			},
+		},
+		{
+			name:  "Created: text fields are escaped, chat handles are kept",
+			event: model.Event{ID: "ev-6", Type: model.EventPRCreated, PullRequestID: "pr-6", Reviewers: []string{"u2", "u3", "u9"}},
+			setupMocks: func(q *mocks.Queue) {
+				q.On("ChatContext", mock.Anything, "pr-6", []string{"u2", "u3", "u9"}).Return(hostile, nil)
+				q.On("EnqueueChatNotification", mock.Anything, hookURL,
+					"Review requested on *&lt;!channel&gt; Fix &amp; &lt;https://evil.example|docs&gt;* (pr-6) by &lt;@U0ADMIN&gt;: "+
+						"<@U2>, carol&gt;, u9").Return(nil)
+			},
+		},
+		{
+			name: "Reassigned: username without chat handle is escaped",
+			event: model.Event{ID: "ev-7", Type: model.EventReviewerAssigned, PullRequestID: "pr-6",
+				ReviewerID: "u3", ReplacedReviewerID: "u2"},
+			setupMocks: func(q *mocks.Queue) {
+				q.On("ChatContext", mock.Anything, "pr-6", []string{"u3", "u2"}).Return(hostile, nil)
+				q.On("EnqueueChatNotification", mock.Anything, hookURL,
+					"carol&gt; now reviews *&lt;!channel&gt; Fix &amp; &lt;https://evil.example|docs&gt;* (pr-6) instead of <@U2>").Return(nil)
+			},
+		},
+		{
+			name:       "Skip: assignment on creation",
+			event:      model.Event{ID: "ev-3", Type: model.EventReviewerAssigned, PullRequestID: "pr-1", ReviewerID: "u2"},
+			setupMocks: func(q *mocks.Queue) {},
+		},
+		{
+			name:  "Skip: team has no channel",
+			event: model.Event{ID: "ev-4", Type: model.EventPRCreated, PullRequestID: "pr-2", Reviewers: []string{"u2"}},
+			setupMocks: func(q *mocks.Queue) {
+				q.On("ChatContext", mock.Anything, "pr-2", []string{"u2"}).Return(model.ChatContext{PullRequestID: "pr-2"}, nil)
+			},
+		},
+		{
+			name:  "Skip: pull request is gone",
+			event: model.Event{ID: "ev-5", Type: model.EventPRCreated, PullRequestID: "pr-3", Reviewers: []string{"u2"}},
+			setupMocks: func(q *mocks.Queue) {
+				q.On("ChatContext", mock.Anything, "pr-3", []string{"u2"}).Return(model.ChatContext{}, repository.ErrPRNotFound)
+			},
+		},
+	}
+
+	templates, err := notify.ParseTemplates("", "")
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := new(mocks.Queue)
+			tt.setupMocks(q)
+
+			err := notify.NewSink(q, templates).Publish(context.Background(), []model.Event{tt.event})
+
+			assert.NoError(t, err)
+			q.AssertExpectations(t)
+		})
+	}
+}
+
+func TestParseTemplates(t *testing.T) {
+	_, err := notify.ParseTemplates(`{{.Author}} opened {{.PullRequestName}}`, "")
+	assert.NoError(t, err)
+
+	_, err = notify.ParseTemplates(`{{.Nope}}`, "")
+	assert.ErrorContains(t, err, "created template")
+
+	_, err = notify.ParseTemplates("", `{{.Reviewer`)
+	assert.ErrorContains(t, err, "reassigned template")
+}
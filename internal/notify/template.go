@@ -0,0 +1,83 @@
+// Package notify отправляет в чат команды (Slack, Mattermost и другие сервисы с совместимыми
+// incoming webhooks) сообщения о назначении ревьюверов. Сообщения формируются из событий outbox
+// по шаблонам, ставятся в очередь в БД и отправляются фоновым Notifier с ограничением частоты
+// и повторами.
+package notify
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Шаблоны сообщений по умолчанию. Chat handle пользователя подставляется как есть, а username
+// (если handle не задан), название и ID PR экранируются (&, <, >), чтобы не стать разметкой чата.
+const (
+	DefaultCreatedTemplate    = `Review requested on *{{.PullRequestName}}* ({{.PullRequestID}}) by {{.Author}}: {{join .Reviewers ", "}}`
+	DefaultReassignedTemplate = `{{.Reviewer}} now reviews *{{.PullRequestName}}* ({{.PullRequestID}}) instead of {{.Replaced}}`
+)
+
+// Message — данные, доступные в шаблонах. Reviewers заполняется для нового PR,
+// Reviewer и Replaced — для переназначения.
+type Message struct {
+	PullRequestID   string
+	PullRequestName string
+	Author          string
+	Reviewers       []string
+	Reviewer        string
+	Replaced        string
+}
+
+// Templates — шаблоны сообщений о новом PR и о переназначении ревьювера.
+type Templates struct {
+	Created    *template.Template
+	Reassigned *template.Template
+}
+
+var funcs = template.FuncMap{"join": strings.Join}
+
+// ParseTemplates разбирает шаблоны text/template; пустая строка означает шаблон по умолчанию.
+// Шаблоны сразу исполняются на пробном сообщении, чтобы ошибка в них обнаружилась при запуске,
+// а не при первом уведомлении.
+func ParseTemplates(created, reassigned string) (Templates, error) {
+	if created == "" {
+		created = DefaultCreatedTemplate
+	}
+	if reassigned == "" {
+		reassigned = DefaultReassignedTemplate
+	}
+
+	var t Templates
+	var err error
+	if t.Created, err = parse("created", created); err != nil {
+		return Templates{}, err
+	}
+	if t.Reassigned, err = parse("reassigned", reassigned); err != nil {
+		return Templates{}, err
+	}
+	return t, nil
+}
+
+func parse(name, text string) (*template.Template, error) {
+	tpl, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%s template: %w", name, err)
+	}
+	sample := Message{
+		PullRequestID: "pr-1", PullRequestName: "Sample", Author: "author",
+		Reviewers: []string{"a", "b"}, Reviewer: "a", Replaced: "b",
+	}
+	if _, err := render(tpl, sample); err != nil {
+		return nil, fmt.Errorf("%s template: %w", name, err)
+	}
+	return tpl, nil
+}
+
+func render(tpl *template.Template, msg Message) (string, error) {
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, msg); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,168 @@
+// Package outbound доставляет доменные события подписчикам исходящих вебхуков:
+// подписывает тела HMAC-SHA256, повторяет неудачные доставки с экспоненциальной
+// задержкой и переносит доставки, исчерпавшие попытки, в dead letters.
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"pull-request-service/internal/model"
+)
+
+// Заголовки исходящих вебхуков.
+const (
+	EventHeader     = "X-Webhook-Event"
+	IDHeader        = "X-Webhook-ID"
+	AttemptHeader   = "X-Webhook-Attempt"
+	SignatureHeader = "X-Webhook-Signature-256"
+)
+
+const (
+	// batchSize — сколько доставок забирается из очереди за раз; они отправляются параллельно.
+	batchSize = 20
+	// pollInterval — пауза между опросами очереди, если она пуста.
+	pollInterval = 5 * time.Second
+	// requestTimeout ограничивает ожидание ответа подписчика.
+	requestTimeout = 10 * time.Second
+	// claimLease — на сколько забранная доставка скрывается от других экземпляров сервиса.
+	// Должен с запасом превышать requestTimeout.
+	claimLease = time.Minute
+)
+
+// Store описывает очередь доставок, из которой работает Dispatcher.
+type Store interface {
+	ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDelivery, error)
+	CompleteDelivery(ctx context.Context, id int64) error
+	RetryDelivery(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string) error
+	DeadLetterDelivery(ctx context.Context, id int64, lastErr string) error
+}
+
+// DefaultRetryPolicy — 8 попыток в течение примерно часа.
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 8, BaseDelay: 30 * time.Second, MaxDelay: 30 * time.Minute}
+
+// Sign возвращает подпись тела доставки в формате заголовка SignatureHeader: sha256=<hex HMAC>.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher забирает доставки из Store и отправляет их подписчикам.
+// Несколько экземпляров сервиса могут работать с одной очередью одновременно.
+type Dispatcher struct {
+	store   Store
+	retrier Retrier
+	client  *http.Client
+	log     *slog.Logger
+}
+
+// NewDispatcher создаёт диспетчер исходящих вебхуков.
+func NewDispatcher(store Store, policy RetryPolicy, log *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		store: store,
+		retrier: Retrier{
+			Policy:   policy,
+			Complete: store.CompleteDelivery,
+			Retry:    store.RetryDelivery,
+			Fail:     store.DeadLetterDelivery,
+			Log:      log,
+			Message:  "webhook delivery failed",
+		},
+		client: &http.Client{Timeout: requestTimeout},
+		log:    log,
+	}
+}
+
+// Run отправляет доставки, пока не отменён ctx. Пока очередь отдаёт полные пачки,
+// они обрабатываются без пауз; после неполной пачки диспетчер ждёт pollInterval.
+func (d *Dispatcher) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		n, err := d.DispatchOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			d.log.Error("webhook dispatch failed", slog.Any("err", err))
+		}
+		if n == batchSize && err == nil {
+			timer.Reset(0)
+		} else {
+			timer.Reset(pollInterval)
+		}
+	}
+}
+
+// DispatchOnce забирает одну пачку доставок, отправляет их и записывает результаты.
+// Возвращает число обработанных доставок.
+func (d *Dispatcher) DispatchOnce(ctx context.Context) (int, error) {
+	deliveries, err := d.store.ClaimDeliveries(ctx, batchSize, claimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, dl := range deliveries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := d.deliver(ctx, dl); err != nil {
+				d.log.Error("webhook delivery bookkeeping failed",
+					slog.Int64("delivery_id", dl.ID), slog.Any("err", err))
+			}
+		}()
+	}
+	wg.Wait()
+	return len(deliveries), nil
+}
+
+// deliver отправляет одну доставку и записывает её исход: удаление из очереди,
+// повтор с задержкой или перенос в dead letters.
+func (d *Dispatcher) deliver(ctx context.Context, dl model.WebhookDelivery) error {
+	return d.retrier.Record(ctx, dl.ID, dl.Attempts, d.send(ctx, dl),
+		slog.Int64("delivery_id", dl.ID),
+		slog.Int64("subscription_id", dl.SubscriptionID),
+		slog.String("event_id", dl.EventID),
+	)
+}
+
+// send выполняет HTTP-запрос к подписчику. Успехом считается любой ответ 2xx.
+func (d *Dispatcher) send(ctx context.Context, dl model.WebhookDelivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, dl.URL, bytes.NewReader(dl.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, string(dl.EventType))
+	req.Header.Set(IDHeader, dl.EventID)
+	req.Header.Set(AttemptHeader, strconv.Itoa(dl.Attempts+1))
+	req.Header.Set(SignatureHeader, Sign(dl.Secret, dl.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package outbound_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/outbound"
+	"pull-request-service/internal/outbound/mocks"
+)
+
+func TestDispatcher_DispatchOnce(t *testing.T) {
+	payload := []byte(`{"id":"ev-1","type":"pull_request.merged","pull_request_id":"pr-1"}`)
+	policy := outbound.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+	tests := []struct {
+		name       string
+		status     int
+		attempts   int
+		setupMocks func(store *mocks.Store)
+	}{
+		{
+			name:   "Success: 2xx completes delivery",
+			status: http.StatusNoContent,
+			setupMocks: func(store *mocks.Store) {
+				store.On("CompleteDelivery", mock.Anything, int64(7)).Return(nil)
+			},
+		},
+		{
+			name:     "Retry: failure is rescheduled with backoff",
+			status:   http.StatusInternalServerError,
+			attempts: 1,
+			setupMocks: func(store *mocks.Store) {
+				// После второй неудачи задержка удваивается: 2 минуты
+				store.On("RetryDelivery", mock.Anything, int64(7), mock.MatchedBy(func(at time.Time) bool {
+					d := time.Until(at)
+					return d > time.Minute+50*time.Second && d <= 2*time.Minute
+				}), "unexpected status 500").Return(nil)
+			},
+		},
+		{
+			name:     "Dead letter: last attempt fails",
+			status:   http.StatusBadGateway,
+			attempts: 2,
+			setupMocks: func(store *mocks.Store) {
+				store.On("DeadLetterDelivery", mock.Anything, int64(7), "unexpected status 502").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *http.Request
+			var body []byte
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			store := new(mocks.Store)
+			store.On("ClaimDeliveries", mock.Anything, mock.Anything, mock.Anything).Return([]model.WebhookDelivery{{
+				ID:             7,
+				SubscriptionID: 1,
+				URL:            srv.URL,
+				Secret:         "s3cret",
+				EventID:        "ev-1",
+				EventType:      model.EventPRMerged,
+				Payload:        payload,
+				Attempts:       tt.attempts,
+			}}, nil)
+			tt.setupMocks(store)
+
+			d := outbound.NewDispatcher(store, policy, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			n, err := d.DispatchOnce(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, 1, n)
+			require.NotNil(t, got)
+			assert.Equal(t, payload, body)
+			assert.Equal(t, "pull_request.merged", got.Header.Get(outbound.EventHeader))
+			assert.Equal(t, "ev-1", got.Header.Get(outbound.IDHeader))
+			assert.Equal(t, outbound.Sign("s3cret", payload), got.Header.Get(outbound.SignatureHeader))
+			store.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxErrorLength ограничивает длину текста ошибки, сохраняемого в очередях и журналах запусков.
+const MaxErrorLength = 500
+
+// ErrorText возвращает текст ошибки, обрезанный до MaxErrorLength байт по границе символа:
+// PostgreSQL не примет строку с оборванной UTF-8 последовательностью.
+func ErrorText(err error) string {
+	msg := err.Error()
+	if len(msg) <= MaxErrorLength {
+		return msg
+	}
+	msg = msg[:MaxErrorLength]
+	for len(msg) > 0 && !utf8.ValidString(msg) {
+		msg = msg[:len(msg)-1]
+	}
+	return msg
+}
+
+// RetryPolicy задаёт число попыток доставки и задержку между ними:
+// после n-й неудачи следующая попытка откладывается на BaseDelay·2^(n-1), но не больше MaxDelay.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Backoff возвращает задержку перед попыткой, следующей за failed неудачными.
+func (p RetryPolicy) Backoff(failed int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < failed && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.MaxDelay)
+}
+
+// Delayer реализуют ошибки, после которых получатель сам назначил паузу (ответ 429 с Retry-After):
+// повтор назначается не раньше, чем через Delay.
+type Delayer interface {
+	Delay() time.Duration
+}
+
+// Retrier записывает исходы попыток отправки из очереди с повторами — доставок вебхуков,
+// сообщений в чат, писем. Функции привязывают его к таблице конкретной очереди: Complete убирает
+// отправленный элемент, Retry засчитывает попытку и откладывает элемент, Fail закрывает элемент,
+// исчерпавший MaxAttempts попыток (помечает неудачным или переносит в dead letters).
+type Retrier struct {
+	Policy   RetryPolicy
+	Complete func(ctx context.Context, id int64) error
+	Retry    func(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string) error
+	Fail     func(ctx context.Context, id int64, lastErr string) error
+	Log      *slog.Logger
+	// Message — текст предупреждения о неудачной попытке, например "email failed".
+	Message string
+}
+
+// Record записывает исход попытки отправки элемента id, у которого до неё было attempts неудачных
+// попыток. Неудача логируется с attrs, номером попытки и текстом ошибки.
+func (r Retrier) Record(ctx context.Context, id int64, attempts int, sendErr error, attrs ...slog.Attr) error {
+	if sendErr == nil {
+		return r.Complete(ctx, id)
+	}
+
+	msg := ErrorText(sendErr)
+	failed := attempts + 1
+	attrs = append(attrs, slog.Int("attempt", failed), slog.String("err", msg))
+	r.Log.LogAttrs(ctx, slog.LevelWarn, r.Message, attrs...)
+	if failed >= r.Policy.MaxAttempts {
+		return r.Fail(ctx, id, msg)
+	}
+
+	delay := r.Policy.Backoff(failed)
+	var d Delayer
+	if errors.As(sendErr, &d) {
+		delay = max(delay, d.Delay())
+	}
+	return r.Retry(ctx, id, time.Now().Add(delay), msg)
+}
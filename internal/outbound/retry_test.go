@@ -0,0 +1,119 @@
+package outbound_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"pull-request-service/internal/outbound"
+	"pull-request-service/internal/outbound/mocks"
+)
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := outbound.RetryPolicy{MaxAttempts: 10, BaseDelay: time.Second, MaxDelay: 10 * time.Second}
+
+	tests := []struct {
+		failed int
+		want   time.Duration
+	}{
+		{failed: 1, want: time.Second},
+		{failed: 2, want: 2 * time.Second},
+		{failed: 4, want: 8 * time.Second},
+		{failed: 5, want: 10 * time.Second},
+		{failed: 40, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, p.Backoff(tt.failed), "failed=%d", tt.failed)
+	}
+}
+
+// pausedError — ошибка получателя, назначившего паузу, как ответ 429 с Retry-After.
+type pausedError struct{ d time.Duration }
+
+func (e pausedError) Error() string        { return "rate limited" }
+func (e pausedError) Delay() time.Duration { return e.d }
+
+func TestRetrier_Record(t *testing.T) {
+	policy := outbound.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Minute, MaxDelay: time.Hour}
+
+	tests := []struct {
+		name       string
+		attempts   int
+		sendErr    error
+		setupMocks func(store *mocks.Store)
+	}{
+		{
+			name: "Success completes the item",
+			setupMocks: func(store *mocks.Store) {
+				store.On("CompleteDelivery", mock.Anything, int64(7)).Return(nil)
+			},
+		},
+		{
+			name:     "Failure is retried with backoff",
+			attempts: 1,
+			sendErr:  errors.New("connection refused"),
+			setupMocks: func(store *mocks.Store) {
+				store.On("RetryDelivery", mock.Anything, int64(7), mock.MatchedBy(func(at time.Time) bool {
+					d := time.Until(at)
+					return d > 110*time.Second && d <= 2*time.Minute
+				}), "connection refused").Return(nil)
+			},
+		},
+		{
+			name:    "Receiver pause outlasts backoff",
+			sendErr: pausedError{d: 10 * time.Minute},
+			setupMocks: func(store *mocks.Store) {
+				store.On("RetryDelivery", mock.Anything, int64(7), mock.MatchedBy(func(at time.Time) bool {
+					return time.Until(at) > 9*time.Minute
+				}), "rate limited").Return(nil)
+			},
+		},
+		{
+			name:     "Last attempt fails the item",
+			attempts: 2,
+			sendErr:  errors.New("gone"),
+			setupMocks: func(store *mocks.Store) {
+				store.On("DeadLetterDelivery", mock.Anything, int64(7), "gone").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := new(mocks.Store)
+			tt.setupMocks(store)
+			r := outbound.Retrier{
+				Policy:   policy,
+				Complete: store.CompleteDelivery,
+				Retry:    store.RetryDelivery,
+				Fail:     store.DeadLetterDelivery,
+				Log:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+				Message:  "delivery failed",
+			}
+
+			err := r.Record(context.Background(), 7, tt.attempts, tt.sendErr)
+
+			assert.NoError(t, err)
+			store.AssertExpectations(t)
+		})
+	}
+}
+
+func TestErrorText(t *testing.T) {
+	assert.Equal(t, "short", outbound.ErrorText(errors.New("short")))
+
+	// Многобайтовый символ на границе обрезки не должен разорваться
+	long := outbound.ErrorText(errors.New("a" + strings.Repeat("я", outbound.MaxErrorLength)))
+	assert.LessOrEqual(t, len(long), outbound.MaxErrorLength)
+	assert.Greater(t, len(long), outbound.MaxErrorLength-2)
+	assert.True(t, utf8.ValidString(long))
+}
@@ -0,0 +1,105 @@
+package outbox
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/outbound"
+)
+
+const (
+	// cursorLease — на сколько забранный курсор скрывается от других экземпляров сервиса.
+	// Должен с запасом превышать httpTimeout.
+	cursorLease = time.Minute
+)
+
+// DefaultRetryPolicy — задержка повторов отправки во внешний sink: от 5 секунд до 10 минут.
+// Пачка повторяется, пока sink её не примет, поэтому MaxAttempts не используется.
+var DefaultRetryPolicy = outbound.RetryPolicy{BaseDelay: 5 * time.Second, MaxDelay: 10 * time.Minute}
+
+// CursorStore описывает журнал опубликованных событий и курсоры внешних sinks.
+type CursorStore interface {
+	ClaimCursor(ctx context.Context, sink string, lease time.Duration) (model.SinkCursor, bool, error)
+	ListPublishedSince(ctx context.Context, after int64, limit int) ([]model.Event, error)
+	AdvanceCursor(ctx context.Context, sink string, seq int64) error
+	RetryCursor(ctx context.Context, sink string, nextAttemptAt time.Time, lastErr string) error
+}
+
+// Forwarder доставляет опубликованные события во внешний sink (HTTP, файл, лог) вне транзакции relay.
+// У каждого sink свой курсор в БД и своё расписание повторов, поэтому недоступный получатель
+// задерживает только себя. Неудачная пачка повторяется с задержкой до MaxDelay, пока sink её не примет,
+// а события, не отправленные хотя бы одному sink, не удаляются из outbox. Пачка, принятая sink, больше
+// не отправляется; если sink упал после приёма, но до сдвига курсора, пачка придёт повторно с теми же ID.
+type Forwarder struct {
+	store  CursorStore
+	sink   Sink
+	policy outbound.RetryPolicy
+	log    *slog.Logger
+}
+
+// NewForwarder создаёт доставщик событий во внешний sink.
+func NewForwarder(store CursorStore, sink Sink, policy outbound.RetryPolicy, log *slog.Logger) *Forwarder {
+	return &Forwarder{store: store, sink: sink, policy: policy, log: log}
+}
+
+// Run доставляет события, пока не отменён ctx. Полные пачки отправляются без пауз.
+func (f *Forwarder) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		n, err := f.ForwardOnce(ctx)
+		if err != nil && ctx.Err() == nil {
+			f.log.Error("outbox sink bookkeeping failed", slog.String("sink", f.sink.Name()), slog.Any("err", err))
+		}
+		if n == batchSize && err == nil {
+			timer.Reset(0)
+		} else {
+			timer.Reset(pollInterval)
+		}
+	}
+}
+
+// ForwardOnce отправляет в sink следующую пачку событий после его курсора и записывает исход:
+// сдвиг курсора или повтор с задержкой.
+// Возвращает число доставленных событий.
+func (f *Forwarder) ForwardOnce(ctx context.Context) (int, error) {
+	name := f.sink.Name()
+	cursor, ok, err := f.store.ClaimCursor(ctx, name, cursorLease)
+	if err != nil || !ok {
+		return 0, err
+	}
+
+	events, err := f.store.ListPublishedSince(ctx, cursor.LastSeq, batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(events) == 0 {
+		return 0, f.store.AdvanceCursor(ctx, name, cursor.LastSeq)
+	}
+	last := events[len(events)-1].Seq
+
+	sendErr := f.sink.Publish(ctx, events)
+	if sendErr == nil {
+		return len(events), f.store.AdvanceCursor(ctx, name, last)
+	}
+
+	msg := outbound.ErrorText(sendErr)
+	failed := cursor.Attempts + 1
+	f.log.Warn("outbox sink publish failed",
+		slog.String("sink", name),
+		slog.Int64("from_seq", events[0].Seq),
+		slog.Int64("to_seq", last),
+		slog.Int("attempt", failed),
+		slog.String("err", msg),
+	)
+	return 0, f.store.RetryCursor(ctx, name, time.Now().Add(f.policy.Backoff(failed)), msg)
+}
@@ -0,0 +1,132 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "pull-request-service/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// CursorStore is an autogenerated mock type for the CursorStore type
+type CursorStore struct {
+	mock.Mock
+}
+
+// AdvanceCursor provides a mock function with given fields: ctx, sink, seq
+func (_m *CursorStore) AdvanceCursor(ctx context.Context, sink string, seq int64) error {
+	ret := _m.Called(ctx, sink, seq)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdvanceCursor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) error); ok {
+		r0 = rf(ctx, sink, seq)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ClaimCursor provides a mock function with given fields: ctx, sink, lease
+func (_m *CursorStore) ClaimCursor(ctx context.Context, sink string, lease time.Duration) (model.SinkCursor, bool, error) {
+	ret := _m.Called(ctx, sink, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimCursor")
+	}
+
+	var r0 model.SinkCursor
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) (model.SinkCursor, bool, error)); ok {
+		return rf(ctx, sink, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) model.SinkCursor); ok {
+		r0 = rf(ctx, sink, lease)
+	} else {
+		r0 = ret.Get(0).(model.SinkCursor)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Duration) bool); ok {
+		r1 = rf(ctx, sink, lease)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Duration) error); ok {
+		r2 = rf(ctx, sink, lease)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ListPublishedSince provides a mock function with given fields: ctx, after, limit
+func (_m *CursorStore) ListPublishedSince(ctx context.Context, after int64, limit int) ([]model.Event, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPublishedSince")
+	}
+
+	var r0 []model.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]model.Event, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []model.Event); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RetryCursor provides a mock function with given fields: ctx, sink, nextAttemptAt, lastErr
+func (_m *CursorStore) RetryCursor(ctx context.Context, sink string, nextAttemptAt time.Time, lastErr string) error {
+	ret := _m.Called(ctx, sink, nextAttemptAt, lastErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RetryCursor")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time, string) error); ok {
+		r0 = rf(ctx, sink, nextAttemptAt, lastErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewCursorStore creates a new instance of CursorStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCursorStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CursorStore {
+	mock := &CursorStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,65 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "pull-request-service/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Sink is an autogenerated mock type for the Sink type
+type Sink struct {
+	mock.Mock
+}
+
+// Name provides a mock function with no fields
+func (_m *Sink) Name() string {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Name")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Publish provides a mock function with given fields: ctx, events
+func (_m *Sink) Publish(ctx context.Context, events []model.Event) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Publish")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []model.Event) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewSink creates a new instance of Sink. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewSink(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *Sink {
+	mock := &Sink{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
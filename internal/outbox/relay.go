@@ -0,0 +1,135 @@
+// Package outbox публикует доменные события, записанные в таблицу outbox в транзакциях
+// бизнес-операций, в подключаемые sinks. Sinks в той же БД (очереди вебхуков, чатов и писем)
+// получают пачку в транзакции relay, атомарно с пометкой о публикации. Внешние sinks читают
+// уже опубликованные события через Forwarder, каждый со своим курсором и повторами.
+// Доставка — «хотя бы один раз».
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pull-request-service/internal/model"
+)
+
+const (
+	// batchSize — сколько событий публикуется за одну транзакцию.
+	batchSize = 100
+	// pollInterval — пауза между опросами outbox, когда новых событий нет.
+	pollInterval = time.Second
+	// maxRetryDelay ограничивает паузу после повторяющихся ошибок публикации.
+	maxRetryDelay = time.Minute
+	// retention — сколько хранятся опубликованные события.
+	retention = 7 * 24 * time.Hour
+	// pruneInterval — как часто удаляются старые опубликованные события.
+	pruneInterval = time.Hour
+)
+
+// Store описывает таблицу outbox, из которой читает Relay.
+type Store interface {
+	ClaimPending(ctx context.Context, limit int) ([]model.Event, error)
+	MarkPublished(ctx context.Context, eventIDs []string) error
+	PrunePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+// TransactionManager описывает запуск функции в транзакции БД.
+type TransactionManager interface {
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Sink принимает пачку событий в порядке их записи. Sinks relay вызываются внутри его транзакции
+// и должны только писать в ту же БД: ошибка любого из них откатывает публикацию всей пачки.
+// Внешние получатели подключаются через Forwarder и могут получить пачку повторно (с теми же ID).
+type Sink interface {
+	Name() string
+	Publish(ctx context.Context, events []model.Event) error
+}
+
+// Relay переносит события из outbox в sinks той же БД и назначает им номера публикации. Экземпляров сервиса может быть несколько:
+// публикует всегда один из них, поэтому порядок событий сохраняется.
+type Relay struct {
+	store Store
+	tx    TransactionManager
+	sinks []Sink
+	log   *slog.Logger
+}
+
+// NewRelay создаёт relay, публикующий события в sinks.
+func NewRelay(store Store, tx TransactionManager, sinks []Sink, log *slog.Logger) *Relay {
+	return &Relay{store: store, tx: tx, sinks: sinks, log: log}
+}
+
+// Run публикует события, пока не отменён ctx. Полные пачки публикуются без пауз,
+// после ошибки пауза удваивается до maxRetryDelay. Раз в pruneInterval удаляются
+// события, опубликованные больше retention назад.
+func (r *Relay) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	delay := pollInterval
+	var lastPrune time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		n, err := r.PublishOnce(ctx)
+		switch {
+		case err != nil:
+			if ctx.Err() != nil {
+				return
+			}
+			r.log.Error("outbox publish failed", slog.Any("err", err))
+			delay = min(delay*2, maxRetryDelay)
+			timer.Reset(delay)
+			continue
+		case n == batchSize:
+			delay = pollInterval
+			timer.Reset(0)
+		default:
+			delay = pollInterval
+			timer.Reset(delay)
+		}
+
+		if time.Since(lastPrune) >= pruneInterval {
+			lastPrune = time.Now()
+			if _, err := r.store.PrunePublished(ctx, lastPrune.Add(-retention)); err != nil && ctx.Err() == nil {
+				r.log.Error("outbox prune failed", slog.Any("err", err))
+			}
+		}
+	}
+}
+
+// PublishOnce публикует одну пачку событий во все sinks и помечает её опубликованной.
+// Если хотя бы один sink вернул ошибку, транзакция откатывается и пачка будет опубликована снова.
+// Возвращает число опубликованных событий.
+func (r *Relay) PublishOnce(ctx context.Context) (int, error) {
+	var published int
+	err := r.tx.RunInTransaction(ctx, func(ctx context.Context) error {
+		events, err := r.store.ClaimPending(ctx, batchSize)
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		for _, sink := range r.sinks {
+			if err := sink.Publish(ctx, events); err != nil {
+				return fmt.Errorf("sink %s: %w", sink.Name(), err)
+			}
+		}
+
+		ids := make([]string, 0, len(events))
+		for _, ev := range events {
+			ids = append(ids, ev.ID)
+		}
+		if err := r.store.MarkPublished(ctx, ids); err != nil {
+			return err
+		}
+		published = len(events)
+		return nil
+	})
+	return published, err
+}
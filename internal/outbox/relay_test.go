@@ -0,0 +1,189 @@
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/outbox"
+	"pull-request-service/internal/outbox/mocks"
+)
+
+func TestRelay_PublishOnce(t *testing.T) {
+	events := []model.Event{
+		{ID: "ev-1", Type: model.EventPRCreated, PullRequestID: "pr-1"},
+		{ID: "ev-2", Type: model.EventReviewerAssigned, PullRequestID: "pr-1", ReviewerID: "u2"},
+	}
+
+	tests := []struct {
+		name          string
+		setupMocks    func(store *mocks.Store, first, second *mocks.Sink)
+		wantPublished int
+		wantErr       bool
+	}{
+		{
+			name: "Success: All sinks accept, batch marked published",
+			setupMocks: func(store *mocks.Store, first, second *mocks.Sink) {
+				store.On("ClaimPending", mock.Anything, mock.Anything).Return(events, nil)
+				first.On("Publish", mock.Anything, events).Return(nil)
+				second.On("Publish", mock.Anything, events).Return(nil)
+				store.On("MarkPublished", mock.Anything, []string{"ev-1", "ev-2"}).Return(nil)
+			},
+			wantPublished: 2,
+		},
+		{
+			name: "Fail: Sink error leaves batch pending",
+			setupMocks: func(store *mocks.Store, first, second *mocks.Sink) {
+				store.On("ClaimPending", mock.Anything, mock.Anything).Return(events, nil)
+				first.On("Publish", mock.Anything, events).Return(nil)
+				second.On("Name").Return("http")
+				second.On("Publish", mock.Anything, events).Return(errors.New("unexpected status 503"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "Empty outbox publishes nothing",
+			setupMocks: func(store *mocks.Store, first, second *mocks.Sink) {
+				store.On("ClaimPending", mock.Anything, mock.Anything).Return([]model.Event{}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := new(mocks.Store)
+			first, second := new(mocks.Sink), new(mocks.Sink)
+			tm := new(mocks.TransactionManager)
+			tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+				return fn(ctx)
+			})
+			tt.setupMocks(store, first, second)
+
+			relay := outbox.NewRelay(store, tm, []outbox.Sink{first, second}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+			n, err := relay.PublishOnce(context.Background())
+
+			if tt.wantErr {
+				assert.ErrorContains(t, err, "sink http")
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantPublished, n)
+			store.AssertExpectations(t)
+			first.AssertExpectations(t)
+			second.AssertExpectations(t)
+		})
+	}
+}
+
+func TestRelay_ExternalSinkFailureIsIsolated(t *testing.T) {
+	events := []model.Event{
+		{ID: "ev-1", Type: model.EventPRCreated, PullRequestID: "pr-1"},
+		{ID: "ev-2", Type: model.EventReviewerAssigned, PullRequestID: "pr-1", ReviewerID: "u2"},
+	}
+	published := []model.Event{events[0], events[1]}
+	published[0].Seq, published[1].Seq = 11, 12
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	store := new(mocks.Store)
+	queue := new(mocks.Sink)
+	tm := new(mocks.TransactionManager)
+	tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	store.On("ClaimPending", mock.Anything, mock.Anything).Return(events, nil)
+	queue.On("Publish", mock.Anything, events).Return(nil)
+	store.On("MarkPublished", mock.Anything, []string{"ev-1", "ev-2"}).Return(nil)
+
+	cursors := new(mocks.CursorStore)
+	failing, healthy := new(mocks.Sink), new(mocks.Sink)
+	failing.On("Name").Return("http")
+	failing.On("Publish", mock.Anything, published).Return(errors.New("unexpected status 503"))
+	healthy.On("Name").Return("file")
+	healthy.On("Publish", mock.Anything, published).Return(nil)
+	for _, sink := range []string{"http", "file"} {
+		cursors.On("ClaimCursor", mock.Anything, sink, mock.Anything).Return(model.SinkCursor{Sink: sink, LastSeq: 10}, true, nil)
+	}
+	cursors.On("ListPublishedSince", mock.Anything, int64(10), mock.Anything).Return(published, nil)
+	cursors.On("RetryCursor", mock.Anything, "http", mock.Anything, "unexpected status 503").Return(nil)
+	cursors.On("AdvanceCursor", mock.Anything, "file", int64(12)).Return(nil)
+
+	// Внешние sinks не участвуют в транзакции relay: пачка публикуется, даже если HTTP-получатель недоступен
+	n, err := outbox.NewRelay(store, tm, []outbox.Sink{queue}, logger).PublishOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	n, err = outbox.NewForwarder(cursors, failing, outbox.DefaultRetryPolicy, logger).ForwardOnce(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	n, err = outbox.NewForwarder(cursors, healthy, outbox.DefaultRetryPolicy, logger).ForwardOnce(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	store.AssertExpectations(t)
+	queue.AssertExpectations(t)
+	cursors.AssertExpectations(t)
+	failing.AssertExpectations(t)
+	healthy.AssertExpectations(t)
+	cursors.AssertNotCalled(t, "AdvanceCursor", mock.Anything, "http", mock.Anything)
+}
+
+func TestForwarder_ForwardOnce(t *testing.T) {
+	batch := []model.Event{{Seq: 5, ID: "ev-5", Type: model.EventPRMerged}}
+
+	tests := []struct {
+		name       string
+		setupMocks func(store *mocks.CursorStore, sink *mocks.Sink)
+		want       int
+	}{
+		{
+			name: "Cursor held by another instance",
+			setupMocks: func(store *mocks.CursorStore, _ *mocks.Sink) {
+				store.On("ClaimCursor", mock.Anything, "http", mock.Anything).Return(model.SinkCursor{}, false, nil)
+			},
+		},
+		{
+			name: "Nothing new releases cursor",
+			setupMocks: func(store *mocks.CursorStore, _ *mocks.Sink) {
+				store.On("ClaimCursor", mock.Anything, "http", mock.Anything).Return(model.SinkCursor{Sink: "http", LastSeq: 5}, true, nil)
+				store.On("ListPublishedSince", mock.Anything, int64(5), mock.Anything).Return([]model.Event{}, nil)
+				store.On("AdvanceCursor", mock.Anything, "http", int64(5)).Return(nil)
+			},
+		},
+		{
+			name: "Many failures keep batch with capped backoff",
+			setupMocks: func(store *mocks.CursorStore, sink *mocks.Sink) {
+				store.On("ClaimCursor", mock.Anything, "http", mock.Anything).Return(model.SinkCursor{Sink: "http", LastSeq: 4, Attempts: 50}, true, nil)
+				store.On("ListPublishedSince", mock.Anything, int64(4), mock.Anything).Return(batch, nil)
+				sink.On("Publish", mock.Anything, batch).Return(errors.New("connection refused"))
+				store.On("RetryCursor", mock.Anything, "http", mock.MatchedBy(func(next time.Time) bool {
+					return time.Until(next) <= outbox.DefaultRetryPolicy.MaxDelay
+				}), "connection refused").Return(nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store, sink := new(mocks.CursorStore), new(mocks.Sink)
+			sink.On("Name").Return("http")
+			tt.setupMocks(store, sink)
+
+			n, err := outbox.NewForwarder(store, sink, outbox.DefaultRetryPolicy, slog.New(slog.NewTextHandler(io.Discard, nil))).
+				ForwardOnce(context.Background())
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, n)
+			store.AssertExpectations(t)
+			sink.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"time"
+
+	"pull-request-service/internal/model"
+)
+
+// httpTimeout ограничивает ожидание ответа HTTP-sink.
+const httpTimeout = 10 * time.Second
+
+// encodeNDJSON кодирует события по одному JSON-объекту на строку.
+func encodeNDJSON(events []model.Event) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, ev := range events {
+		if err := enc.Encode(ev); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// funcSink — sink из функции; используется для получателей в той же БД.
+type funcSink struct {
+	name string
+	fn   func(ctx context.Context, events []model.Event) error
+}
+
+// NewFuncSink возвращает sink с именем name, передающий события функции fn.
+func NewFuncSink(name string, fn func(ctx context.Context, events []model.Event) error) Sink {
+	return funcSink{name: name, fn: fn}
+}
+
+func (s funcSink) Name() string { return s.name }
+
+func (s funcSink) Publish(ctx context.Context, events []model.Event) error { return s.fn(ctx, events) }
+
+// LogSink пишет каждое событие в лог.
+type LogSink struct {
+	Log *slog.Logger
+}
+
+// Name реализует Sink.
+func (LogSink) Name() string { return "log" }
+
+// Publish реализует Sink.
+func (s LogSink) Publish(ctx context.Context, events []model.Event) error {
+	for _, ev := range events {
+		s.Log.InfoContext(ctx, "domain event",
+			slog.String("event_id", ev.ID),
+			slog.String("type", string(ev.Type)),
+			slog.String("pull_request_id", ev.PullRequestID),
+			slog.String("reviewer_id", ev.ReviewerID),
+		)
+	}
+	return nil
+}
+
+// HTTPSink отправляет пачку событий одним POST-запросом в формате NDJSON.
+// Любой ответ, кроме 2xx, считается ошибкой, и Forwarder отправит пачку повторно.
+type HTTPSink struct {
+	URL    string
+	Client *http.Client
+}
+
+// NewHTTPSink создаёт HTTP-sink с таймаутом запроса по умолчанию.
+func NewHTTPSink(url string) *HTTPSink {
+	return &HTTPSink{URL: url, Client: &http.Client{Timeout: httpTimeout}}
+}
+
+// Name реализует Sink.
+func (*HTTPSink) Name() string { return "http" }
+
+// Publish реализует Sink.
+func (s *HTTPSink) Publish(ctx context.Context, events []model.Event) error {
+	body, err := encodeNDJSON(events)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	resp, err := s.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// FileSink дописывает события в файл NDJSON. Удобен в тестах и для отладки:
+// содержимое файла — поток событий в порядке публикации.
+type FileSink struct {
+	Path string
+}
+
+// Name реализует Sink.
+func (FileSink) Name() string { return "file" }
+
+// Publish реализует Sink.
+func (s FileSink) Publish(_ context.Context, events []model.Event) error {
+	body, err := encodeNDJSON(events)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(s.Path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(body); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,79 @@
+package outbox_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/outbox"
+)
+
+func TestFileSink_AppendsNDJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	sink := outbox.FileSink{Path: path}
+
+	require.NoError(t, sink.Publish(context.Background(), []model.Event{{ID: "ev-1", Type: model.EventPRCreated}}))
+	require.NoError(t, sink.Publish(context.Background(), []model.Event{
+		{ID: "ev-2", Type: model.EventReviewerAssigned},
+		{ID: "ev-3", Type: model.EventPRMerged},
+	}))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var ev model.Event
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &ev))
+		ids = append(ids, ev.ID)
+	}
+	assert.Equal(t, []string{"ev-1", "ev-2", "ev-3"}, ids)
+}
+
+func TestHTTPSink_Publish(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "Success: 2xx", status: http.StatusAccepted},
+		{name: "Fail: 5xx", status: http.StatusServiceUnavailable, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body []byte
+			var contentType string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ = io.ReadAll(r.Body)
+				contentType = r.Header.Get("Content-Type")
+				w.WriteHeader(tt.status)
+			}))
+			defer srv.Close()
+
+			err := outbox.NewHTTPSink(srv.URL).Publish(context.Background(), []model.Event{
+				{ID: "ev-1", Type: model.EventPRMerged, PullRequestID: "pr-1"},
+			})
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, "application/x-ndjson", contentType)
+			assert.Contains(t, string(body), `"id":"ev-1"`)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"pull-request-service/internal/model"
+)
+
+// CycleTimeRepo пересчитывает и читает rollup-таблицы времени цикла ревью.
+type CycleTimeRepo struct {
+	db *Postgres
+}
+
+// NewCycleTimeRepo создаёт новый экземпляр CycleTimeRepo c переданным подключением к PostgreSQL.
+func NewCycleTimeRepo(db *Postgres) *CycleTimeRepo {
+	return &CycleTimeRepo{db: db}
+}
+
+// RefreshRollups пересчитывает процентили по замерам cycle_time_samples: за каждое окно
+// из windowDays дней до now и за последние weeks недель (включая текущую). Прежние строки
+// заменяются целиком, поэтому вызывается в транзакции. Возвращает число записанных строк.
+func (r *CycleTimeRepo) RefreshRollups(ctx context.Context, now time.Time, windowDays []int, weeks int) (int64, error) {
+	q := r.db.GetQueryExecutor(ctx)
+
+	if _, err := q.Exec(ctx, `DELETE FROM cycle_time_window_rollups`); err != nil {
+		return 0, fmt.Errorf("clear window rollups: %w", err)
+	}
+	windowTag, err := q.Exec(ctx, `
+INSERT INTO cycle_time_window_rollups
+    (window_days, dimension, subject, metric, samples, p50_seconds, p90_seconds, p99_seconds, refreshed_at)
+SELECT w.days, s.dimension, s.subject, s.metric, COUNT(*),
+       percentile_cont(0.5)  WITHIN GROUP (ORDER BY s.seconds),
+       percentile_cont(0.9)  WITHIN GROUP (ORDER BY s.seconds),
+       percentile_cont(0.99) WITHIN GROUP (ORDER BY s.seconds),
+       $1::timestamptz
+FROM cycle_time_samples s
+JOIN unnest($2::int[]) AS w(days)
+  ON s.done_at >= $1::timestamptz - make_interval(days => w.days) AND s.done_at < $1::timestamptz
+GROUP BY w.days, s.dimension, s.subject, s.metric
+`, now, windowDays)
+	if err != nil {
+		return 0, fmt.Errorf("refresh window rollups: %w", err)
+	}
+
+	if _, err := q.Exec(ctx, `DELETE FROM cycle_time_weekly_rollups`); err != nil {
+		return 0, fmt.Errorf("clear weekly rollups: %w", err)
+	}
+	weeklyTag, err := q.Exec(ctx, `
+WITH bounds AS (
+    SELECT date_trunc('week', $1::timestamptz AT TIME ZONE 'UTC') - make_interval(weeks => $2::int - 1) AS since
+)
+INSERT INTO cycle_time_weekly_rollups
+    (week_start, dimension, subject, metric, samples, p50_seconds, p90_seconds, p99_seconds)
+SELECT date_trunc('week', s.done_at AT TIME ZONE 'UTC')::date, s.dimension, s.subject, s.metric, COUNT(*),
+       percentile_cont(0.5)  WITHIN GROUP (ORDER BY s.seconds),
+       percentile_cont(0.9)  WITHIN GROUP (ORDER BY s.seconds),
+       percentile_cont(0.99) WITHIN GROUP (ORDER BY s.seconds)
+FROM cycle_time_samples s, bounds b
+WHERE s.done_at AT TIME ZONE 'UTC' >= b.since AND s.done_at < $1::timestamptz
+GROUP BY 1, s.dimension, s.subject, s.metric
+`, now, weeks)
+	if err != nil {
+		return 0, fmt.Errorf("refresh weekly rollups: %w", err)
+	}
+	return windowTag.RowsAffected() + weeklyTag.RowsAffected(), nil
+}
+
+// ListWindowRollups возвращает процентили разреза dimension за окно windowDays
+// (только для subject, если он задан) и время пересчёта; nil, если строк нет.
+func (r *CycleTimeRepo) ListWindowRollups(ctx context.Context, dimension model.CycleTimeDimension, windowDays int, subject string) ([]model.CycleTimeRollup, *time.Time, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT subject, metric, samples, p50_seconds, p90_seconds, p99_seconds, refreshed_at
+FROM cycle_time_window_rollups
+WHERE dimension = $1 AND window_days = $2 AND ($3 = '' OR subject = $3)
+ORDER BY subject, metric
+`, string(dimension), windowDays, subject)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query window rollups: %w", err)
+	}
+	defer rows.Close()
+
+	rollups := make([]model.CycleTimeRollup, 0)
+	var refreshedAt *time.Time
+	for rows.Next() {
+		var (
+			ru   model.CycleTimeRollup
+			at   time.Time
+			p    [3]float64
+			name string
+		)
+		if err := rows.Scan(&ru.Subject, &name, &ru.Percentiles.Samples, &p[0], &p[1], &p[2], &at); err != nil {
+			return nil, nil, fmt.Errorf("scan window rollup: %w", err)
+		}
+		ru.Metric = model.CycleTimeMetric(name)
+		setHours(&ru.Percentiles, p)
+		rollups = append(rollups, ru)
+		if refreshedAt == nil || at.After(*refreshedAt) {
+			refreshedAt = &at
+		}
+	}
+	return rollups, refreshedAt, rows.Err()
+}
+
+// ListWeeklyRollups возвращает недельные процентили разреза dimension (только для subject, если он задан)
+// по возрастанию недели.
+func (r *CycleTimeRepo) ListWeeklyRollups(ctx context.Context, dimension model.CycleTimeDimension, subject string) ([]model.CycleTimeRollup, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT subject, week_start, metric, samples, p50_seconds, p90_seconds, p99_seconds
+FROM cycle_time_weekly_rollups
+WHERE dimension = $1 AND ($2 = '' OR subject = $2)
+ORDER BY subject, week_start, metric
+`, string(dimension), subject)
+	if err != nil {
+		return nil, fmt.Errorf("query weekly rollups: %w", err)
+	}
+	defer rows.Close()
+
+	rollups := make([]model.CycleTimeRollup, 0)
+	for rows.Next() {
+		var (
+			ru   model.CycleTimeRollup
+			p    [3]float64
+			name string
+		)
+		if err := rows.Scan(&ru.Subject, &ru.WeekStart, &name, &ru.Percentiles.Samples, &p[0], &p[1], &p[2]); err != nil {
+			return nil, fmt.Errorf("scan weekly rollup: %w", err)
+		}
+		ru.Metric = model.CycleTimeMetric(name)
+		setHours(&ru.Percentiles, p)
+		rollups = append(rollups, ru)
+	}
+	return rollups, rows.Err()
+}
+
+// setHours переводит процентили p50, p90, p99 из секунд в часы с точностью до сотых.
+func setHours(p *model.Percentiles, seconds [3]float64) {
+	hours := func(s float64) float64 { return math.Round(s/36) / 100 }
+	p.P50Hours, p.P90Hours, p.P99Hours = hours(seconds[0]), hours(seconds[1]), hours(seconds[2])
+}
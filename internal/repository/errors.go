@@ -6,15 +6,46 @@ var (
 	// ErrUserNotFound возвращается, если пользователь не найден в БД.
 	ErrUserNotFound = errors.New("user not found")
 
+	// ErrUserExists возвращается при попытке создать пользователя с уже занятым user_id.
+	ErrUserExists = errors.New("user already exists")
+
 	// ErrTeamNotFound возвращается, если команда не найдена.
 	ErrTeamNotFound = errors.New("team not found")
 
 	// ErrTeamExists возвращается при попытке создать дубликат команды.
 	ErrTeamExists = errors.New("team already exists")
 
+	// ErrParentTeamNotFound возвращается, если родительская команда не найдена.
+	ErrParentTeamNotFound = errors.New("parent team not found")
+
+	// ErrTeamCycle возвращается, если перенос команды создал бы цикл в иерархии.
+	ErrTeamCycle = errors.New("team hierarchy cycle")
+
+	// ErrTeamArchived возвращается при попытке изменить состав архивной команды.
+	ErrTeamArchived = errors.New("team is archived")
+
+	// ErrTeamNotEmpty возвращается при попытке удалить команду, у которой есть участники или подкоманды.
+	ErrTeamNotEmpty = errors.New("team is not empty")
+
+	// ErrTeamHasHistory возвращается при попытке удалить команду, бывшие участники которой
+	// писали или ревьюили PR, пока состояли в ней. Такую команду можно только архивировать.
+	ErrTeamHasHistory = errors.New("team has pull request history")
+
+	// ErrUserInOtherTeam возвращается, если пользователь уже состоит в другой команде.
+	ErrUserInOtherTeam = errors.New("user belongs to another team")
+
+	// ErrUsernameTaken возвращается, если в команде уже есть участник с таким username.
+	ErrUsernameTaken = errors.New("username already taken in team")
+
 	// ErrPRNotFound возвращается, если PR не найден.
 	ErrPRNotFound = errors.New("pull request not found")
 
 	// ErrPRExists возвращается при конфликте ID пулл-реквеста.
 	ErrPRExists = errors.New("pull request already exists")
+
+	// ErrSubscriptionNotFound возвращается, если подписка на вебхуки не найдена.
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+
+	// ErrDeadLetterNotFound возвращается, если недоставленное событие не найдено.
+	ErrDeadLetterNotFound = errors.New("dead letter not found")
 )
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"pull-request-service/internal/model"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// MailRepo хранит очередь писем и выбирает получателей почтовых уведомлений.
+type MailRepo struct {
+	db *Postgres
+}
+
+// NewMailRepo создаёт новый экземпляр MailRepo c переданным подключением к PostgreSQL.
+func NewMailRepo(db *Postgres) *MailRepo {
+	return &MailRepo{db: db}
+}
+
+// AssignmentMail возвращает данные письма о назначении reviewerID на PR, если ревьювер
+// выбрал режим immediate и указал адрес. Иначе ok = false.
+func (r *MailRepo) AssignmentMail(ctx context.Context, prID, reviewerID string) (model.AssignmentMail, bool, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	var m model.AssignmentMail
+	err := q.QueryRow(ctx, `
+SELECT u.email, u.username, pr.pull_request_id, pr.pull_request_name, a.username
+FROM users u
+JOIN pull_requests pr ON pr.pull_request_id = $1
+JOIN users a ON a.user_id = pr.author_id
+WHERE u.user_id = $2
+  AND u.email_mode = 'immediate'
+  AND u.email <> ''
+`, prID, reviewerID).Scan(&m.Email, &m.Username, &m.PullRequestID, &m.PullRequestName, &m.Author)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.AssignmentMail{}, false, nil
+		}
+		return model.AssignmentMail{}, false, fmt.Errorf("get assignment mail: %w", err)
+	}
+	return m, true, nil
+}
+
+// ClaimDueDigests выбирает до limit пользователей, которым пора отправить сводку
+// (hourly — час с предыдущей, daily — сутки), и записывает now как время их сводки.
+// Вызывается в транзакции вместе с постановкой сводок в очередь: при откате сводки не теряются.
+func (r *MailRepo) ClaimDueDigests(ctx context.Context, now time.Time, limit int) ([]model.DigestRecipient, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+WITH due AS (
+    SELECT user_id, email_digest_at AS previous
+    FROM users
+    WHERE email <> ''
+      AND is_active
+      AND (
+          (email_mode = 'hourly' AND (email_digest_at IS NULL OR email_digest_at <= $1 - interval '1 hour'))
+       OR (email_mode = 'daily'  AND (email_digest_at IS NULL OR email_digest_at <= $1 - interval '1 day'))
+      )
+    ORDER BY user_id
+    LIMIT $2
+    FOR UPDATE SKIP LOCKED
+)
+UPDATE users u
+SET email_digest_at = $1
+FROM due
+WHERE u.user_id = due.user_id
+RETURNING u.user_id, u.username, u.email, u.email_mode, due.previous
+`, now, limit)
+	if err != nil {
+		return nil, fmt.Errorf("claim digests: %w", err)
+	}
+	defer rows.Close()
+
+	recipients := make([]model.DigestRecipient, 0)
+	for rows.Next() {
+		var d model.DigestRecipient
+		var mode string
+		if err := rows.Scan(&d.UserID, &d.Username, &d.Email, &mode, &d.PreviousDigestAt); err != nil {
+			return nil, fmt.Errorf("scan digest recipient: %w", err)
+		}
+		d.Mode = model.EmailMode(mode)
+		recipients = append(recipients, d)
+	}
+	return recipients, rows.Err()
+}
+
+// EnqueueEmail ставит письмо в очередь на отправку.
+func (r *MailRepo) EnqueueEmail(ctx context.Context, to, subject, body string) error {
+	q := r.db.GetQueryExecutor(ctx)
+	if _, err := q.Exec(ctx, `
+INSERT INTO email_notifications (recipient, subject, body) VALUES ($1, $2, $3)
+`, to, subject, body); err != nil {
+		return fmt.Errorf("enqueue email: %w", err)
+	}
+	return nil
+}
+
+// ClaimEmails выбирает до limit писем, время отправки которых наступило, и откладывает
+// их на lease, чтобы их не взял другой экземпляр сервиса.
+func (r *MailRepo) ClaimEmails(ctx context.Context, limit int, lease time.Duration) ([]model.EmailNotification, error) {
+	rows, err := emailQueue.claim(ctx, r.db.GetQueryExecutor(ctx), limit, lease, "id, recipient, subject, body, attempts")
+	if err != nil {
+		return nil, fmt.Errorf("claim emails: %w", err)
+	}
+	defer rows.Close()
+
+	emails := make([]model.EmailNotification, 0)
+	for rows.Next() {
+		var e model.EmailNotification
+		if err := rows.Scan(&e.ID, &e.To, &e.Subject, &e.Body, &e.Attempts); err != nil {
+			return nil, fmt.Errorf("scan email: %w", err)
+		}
+		emails = append(emails, e)
+	}
+	return emails, rows.Err()
+}
+
+// CompleteEmail удаляет отправленное письмо из очереди.
+func (r *MailRepo) CompleteEmail(ctx context.Context, id int64) error {
+	if err := emailQueue.complete(ctx, r.db.GetQueryExecutor(ctx), id); err != nil {
+		return fmt.Errorf("complete email: %w", err)
+	}
+	return nil
+}
+
+// RetryEmail засчитывает неудачную попытку и назначает следующую на nextAttemptAt.
+func (r *MailRepo) RetryEmail(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string) error {
+	if err := emailQueue.retry(ctx, r.db.GetQueryExecutor(ctx), id, nextAttemptAt, lastErr); err != nil {
+		return fmt.Errorf("retry email: %w", err)
+	}
+	return nil
+}
+
+// FailEmail засчитывает последнюю неудачную попытку и оставляет письмо в таблице с failed_at.
+func (r *MailRepo) FailEmail(ctx context.Context, id int64, lastErr string) error {
+	if err := emailQueue.fail(ctx, r.db.GetQueryExecutor(ctx), id, lastErr); err != nil {
+		return fmt.Errorf("fail email: %w", err)
+	}
+	return nil
+}
+
+// ReminderRecipients возвращает активных пользователей с почтовыми уведомлениями и их ревью,
+// ожидающие с момента назначения раньше assignedBefore, в порядке ListPendingAssignedToUser.
+func (r *MailRepo) ReminderRecipients(ctx context.Context, assignedBefore time.Time) ([]model.ReviewReminder, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT u.user_id, u.username, u.email,
+       pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.priority, r.assigned_at
+FROM users u
+JOIN pull_request_reviewers r ON r.reviewer_id = u.user_id
+JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+WHERE u.is_active
+  AND u.email <> ''
+  AND u.email_mode <> 'off'
+  AND r.state = 'PENDING'
+  AND r.assigned_at <= $1
+  AND pr.status = 'OPEN'
+ORDER BY u.user_id, pr.priority DESC, pr.created_at ASC
+`, assignedBefore)
+	if err != nil {
+		return nil, fmt.Errorf("query reminders: %w", err)
+	}
+	defer rows.Close()
+
+	reminders := make([]model.ReviewReminder, 0)
+	for rows.Next() {
+		var (
+			rem              model.ReviewReminder
+			pr               model.PendingReview
+			status, priority string
+		)
+		if err := rows.Scan(&rem.UserID, &rem.Username, &rem.Email,
+			&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &priority, &pr.AssignedAt); err != nil {
+			return nil, fmt.Errorf("scan reminder: %w", err)
+		}
+		pr.Status = model.PullRequestStatus(status)
+		pr.Priority = model.PullRequestPriority(priority)
+
+		if n := len(reminders); n == 0 || reminders[n-1].UserID != rem.UserID {
+			reminders = append(reminders, rem)
+		}
+		last := &reminders[len(reminders)-1]
+		last.Reviews = append(last.Reviews, pr)
+	}
+	return reminders, rows.Err()
+}
+
+// StalledSummaries возвращает для каждой неархивной команды с руководителем, получающим письма,
+// открытые PR её участников, созданные раньше createdBefore, у которых нет ревьюверов
+// или кто-то из ревьюверов ещё не оставил ревью.
+func (r *MailRepo) StalledSummaries(ctx context.Context, createdBefore time.Time) ([]model.StalledSummary, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT t.team_name, l.username, l.email,
+       pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.created_at,
+       COALESCE(array_agg(r.reviewer_id ORDER BY r.reviewer_id) FILTER (WHERE r.state = 'PENDING'), '{}')
+FROM teams t
+JOIN users l ON l.user_id = t.lead_user_id
+JOIN users a ON a.team_id = t.id
+JOIN pull_requests pr ON pr.author_id = a.user_id
+LEFT JOIN pull_request_reviewers r ON r.pull_request_id = pr.pull_request_id
+WHERE t.archived_at IS NULL
+  AND l.is_active
+  AND l.email <> ''
+  AND l.email_mode <> 'off'
+  AND pr.status = 'OPEN'
+  AND pr.created_at <= $1
+GROUP BY t.team_name, l.username, l.email, pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.created_at
+HAVING count(r.reviewer_id) = 0 OR bool_or(r.state = 'PENDING')
+ORDER BY t.team_name, pr.created_at, pr.pull_request_id
+`, createdBefore)
+	if err != nil {
+		return nil, fmt.Errorf("query stalled pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	summaries := make([]model.StalledSummary, 0)
+	for rows.Next() {
+		var (
+			s  model.StalledSummary
+			pr model.StalledPR
+		)
+		if err := rows.Scan(&s.TeamName, &s.LeadUsername, &s.LeadEmail,
+			&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.CreatedAt, &pr.PendingReviewers); err != nil {
+			return nil, fmt.Errorf("scan stalled pull request: %w", err)
+		}
+		pr.TeamName = s.TeamName
+		if n := len(summaries); n == 0 || summaries[n-1].TeamName != s.TeamName {
+			summaries = append(summaries, s)
+		}
+		last := &summaries[len(summaries)-1]
+		last.PullRequests = append(last.PullRequests, pr)
+	}
+	return summaries, rows.Err()
+}
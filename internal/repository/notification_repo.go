@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"pull-request-service/internal/model"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// NotificationRepo хранит очередь уведомлений в чат и читает для них настройки команд и пользователей.
+type NotificationRepo struct {
+	db *Postgres
+}
+
+// NewNotificationRepo создаёт новый экземпляр NotificationRepo c переданным подключением к PostgreSQL.
+func NewNotificationRepo(db *Postgres) *NotificationRepo {
+	return &NotificationRepo{db: db}
+}
+
+// ChatContext возвращает канал команды автора PR, название PR, chat handle и username автора
+// и пользователей userIDs. Если PR не найден, возвращает ErrPRNotFound.
+func (r *NotificationRepo) ChatContext(ctx context.Context, prID string, userIDs []string) (model.ChatContext, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	c := model.ChatContext{PullRequestID: prID, Users: make(map[string]model.ChatUser, len(userIDs))}
+
+	err := q.QueryRow(ctx, `
+SELECT pr.pull_request_name,
+       COALESCE(t.chat_webhook_url, ''),
+       a.chat_handle,
+       a.username
+FROM pull_requests pr
+JOIN users a ON a.user_id = pr.author_id
+LEFT JOIN teams t ON t.id = a.team_id
+WHERE pr.pull_request_id = $1
+`, prID).Scan(&c.PullRequestName, &c.WebhookURL, &c.Author.Handle, &c.Author.Username)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return model.ChatContext{}, ErrPRNotFound
+		}
+		return model.ChatContext{}, fmt.Errorf("get chat context: %w", err)
+	}
+	if c.WebhookURL == "" || len(userIDs) == 0 {
+		return c, nil
+	}
+
+	rows, err := q.Query(ctx, `
+SELECT user_id, chat_handle, username
+FROM users
+WHERE user_id = ANY($1)
+`, userIDs)
+	if err != nil {
+		return model.ChatContext{}, fmt.Errorf("get chat mentions: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var u model.ChatUser
+		if err := rows.Scan(&id, &u.Handle, &u.Username); err != nil {
+			return model.ChatContext{}, fmt.Errorf("scan chat mention: %w", err)
+		}
+		c.Users[id] = u
+	}
+	return c, rows.Err()
+}
+
+// EnqueueChatNotification ставит сообщение в очередь на отправку в webhookURL.
+func (r *NotificationRepo) EnqueueChatNotification(ctx context.Context, webhookURL, text string) error {
+	q := r.db.GetQueryExecutor(ctx)
+	if _, err := q.Exec(ctx, `
+INSERT INTO chat_notifications (webhook_url, text) VALUES ($1, $2)
+`, webhookURL, text); err != nil {
+		return fmt.Errorf("enqueue chat notification: %w", err)
+	}
+	return nil
+}
+
+// ClaimChatNotifications выбирает до limit сообщений, время отправки которых наступило,
+// в порядке постановки в очередь и откладывает их на lease, чтобы их не взял другой экземпляр сервиса.
+func (r *NotificationRepo) ClaimChatNotifications(ctx context.Context, limit int, lease time.Duration) ([]model.ChatNotification, error) {
+	rows, err := chatQueue.claim(ctx, r.db.GetQueryExecutor(ctx), limit, lease, "id, webhook_url, text, attempts")
+	if err != nil {
+		return nil, fmt.Errorf("claim chat notifications: %w", err)
+	}
+	defer rows.Close()
+
+	notifications := make([]model.ChatNotification, 0)
+	for rows.Next() {
+		var n model.ChatNotification
+		if err := rows.Scan(&n.ID, &n.WebhookURL, &n.Text, &n.Attempts); err != nil {
+			return nil, fmt.Errorf("scan chat notification: %w", err)
+		}
+		notifications = append(notifications, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// RETURNING не гарантирует порядок, а сообщения одного канала должны уходить по очереди
+	slices.SortFunc(notifications, func(a, b model.ChatNotification) int { return cmp.Compare(a.ID, b.ID) })
+	return notifications, nil
+}
+
+// CompleteChatNotification удаляет отправленное сообщение из очереди.
+func (r *NotificationRepo) CompleteChatNotification(ctx context.Context, id int64) error {
+	if err := chatQueue.complete(ctx, r.db.GetQueryExecutor(ctx), id); err != nil {
+		return fmt.Errorf("complete chat notification: %w", err)
+	}
+	return nil
+}
+
+// RetryChatNotification засчитывает неудачную попытку и назначает следующую на nextAttemptAt.
+func (r *NotificationRepo) RetryChatNotification(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string) error {
+	if err := chatQueue.retry(ctx, r.db.GetQueryExecutor(ctx), id, nextAttemptAt, lastErr); err != nil {
+		return fmt.Errorf("retry chat notification: %w", err)
+	}
+	return nil
+}
+
+// PostponeChatNotifications откладывает сообщения до until, не засчитывая попытку:
+// так возвращаются в очередь сообщения, до которых не дошла очередь из-за ограничения частоты.
+func (r *NotificationRepo) PostponeChatNotifications(ctx context.Context, ids []int64, until time.Time) error {
+	q := r.db.GetQueryExecutor(ctx)
+	if _, err := q.Exec(ctx, `
+UPDATE chat_notifications SET next_attempt_at = $2 WHERE id = ANY($1)
+`, ids, until); err != nil {
+		return fmt.Errorf("postpone chat notifications: %w", err)
+	}
+	return nil
+}
+
+// FailChatNotification засчитывает последнюю неудачную попытку и оставляет сообщение
+// в таблице с failed_at для разбора; больше оно не отправляется.
+func (r *NotificationRepo) FailChatNotification(ctx context.Context, id int64, lastErr string) error {
+	if err := chatQueue.fail(ctx, r.db.GetQueryExecutor(ctx), id, lastErr); err != nil {
+		return fmt.Errorf("fail chat notification: %w", err)
+	}
+	return nil
+}
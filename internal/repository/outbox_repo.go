@@ -0,0 +1,243 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"pull-request-service/internal/model"
+)
+
+// outboxLockKey — ключ advisory-блокировки relay: события публикует один экземпляр сервиса за раз,
+// иначе нарушился бы порядок публикации.
+const outboxLockKey int64 = 0x6f7574626f78 // "outbox"
+
+// OutboxRepo хранит доменные события в таблице outbox до их публикации.
+type OutboxRepo struct {
+	db *Postgres
+}
+
+// NewOutboxRepo создаёт новый экземпляр OutboxRepo c переданным подключением к PostgreSQL.
+func NewOutboxRepo(db *Postgres) *OutboxRepo {
+	return &OutboxRepo{db: db}
+}
+
+// RecordEvents сохраняет события в outbox. Вызывается внутри транзакции изменения,
+// породившего события, поэтому событие появляется тогда и только тогда, когда изменение зафиксировано.
+func (r *OutboxRepo) RecordEvents(ctx context.Context, events []model.Event) error {
+	q := r.db.GetQueryExecutor(ctx)
+	for _, ev := range events {
+		payload, err := json.Marshal(ev)
+		if err != nil {
+			return fmt.Errorf("marshal event: %w", err)
+		}
+		if _, err := q.Exec(ctx, `
+INSERT INTO outbox (event_id, event_type, payload, created_at)
+VALUES ($1, $2, $3, $4)
+`, ev.ID, string(ev.Type), payload, ev.OccurredAt); err != nil {
+			return fmt.Errorf("insert outbox event: %w", err)
+		}
+	}
+	return nil
+}
+
+// ClaimPending возвращает до limit неопубликованных событий в порядке записи и блокирует их
+// до конца транзакции. Должен вызываться внутри транзакции. Если события публикует другой
+// экземпляр сервиса, возвращает пустой список.
+func (r *OutboxRepo) ClaimPending(ctx context.Context, limit int) ([]model.Event, error) {
+	q := r.db.GetQueryExecutor(ctx)
+
+	var locked bool
+	if err := q.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, outboxLockKey).Scan(&locked); err != nil {
+		return nil, fmt.Errorf("lock outbox: %w", err)
+	}
+	if !locked {
+		return make([]model.Event, 0), nil
+	}
+
+	rows, err := q.Query(ctx, `
+SELECT payload::text
+FROM outbox
+WHERE published_at IS NULL
+ORDER BY seq
+LIMIT $1
+FOR UPDATE
+`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("select outbox events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]model.Event, 0)
+	for rows.Next() {
+		var payload string
+		if err := rows.Scan(&payload); err != nil {
+			return nil, fmt.Errorf("scan outbox event: %w", err)
+		}
+		var ev model.Event
+		if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+			return nil, fmt.Errorf("decode outbox event: %w", err)
+		}
+		events = append(events, ev)
+	}
+	return events, rows.Err()
+}
+
+// MarkPublished помечает события опубликованными и присваивает им номера публикации
+// в порядке записи.
+func (r *OutboxRepo) MarkPublished(ctx context.Context, eventIDs []string) error {
+	q := r.db.GetQueryExecutor(ctx)
+	if _, err := q.Exec(ctx, `
+UPDATE outbox o
+SET published_at = now(), published_seq = p.published_seq
+FROM (
+    SELECT seq, nextval('outbox_published_seq') AS published_seq
+    FROM (SELECT seq FROM outbox WHERE event_id = ANY($1) ORDER BY seq) ordered
+) p
+WHERE o.seq = p.seq
+`, eventIDs); err != nil {
+		return fmt.Errorf("mark outbox events published: %w", err)
+	}
+	return nil
+}
+
+// PrunePublished удаляет события, опубликованные раньше before и уже отправленные всем внешним sinks
+// (не дальше наименьшего курсора в outbox_sink_cursors). Курсор отключённого sink задерживает очистку,
+// пока его строка не удалена из outbox_sink_cursors.
+func (r *OutboxRepo) PrunePublished(ctx context.Context, before time.Time) (int64, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	tag, err := q.Exec(ctx, `
+DELETE FROM outbox
+WHERE published_at < $1
+  AND published_seq <= COALESCE((SELECT MIN(last_seq) FROM outbox_sink_cursors), published_seq)
+`, before)
+	if err != nil {
+		return 0, fmt.Errorf("prune outbox: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// ClaimCursor забирает курсор внешнего sink на время lease, если подошло время его следующей попытки
+// и курсор не забран другим экземпляром сервиса. Курсор нового sink начинается с последнего
+// опубликованного события: журнал до его подключения sink не получает. ok=false, если курсор занят
+// или попытка отложена.
+func (r *OutboxRepo) ClaimCursor(ctx context.Context, sink string, lease time.Duration) (cursor model.SinkCursor, ok bool, err error) {
+	q := r.db.GetQueryExecutor(ctx)
+	if _, err := q.Exec(ctx, `
+INSERT INTO outbox_sink_cursors (sink, last_seq)
+VALUES ($1, (SELECT COALESCE(MAX(published_seq), 0) FROM outbox))
+ON CONFLICT (sink) DO NOTHING
+`, sink); err != nil {
+		return model.SinkCursor{}, false, fmt.Errorf("init sink cursor: %w", err)
+	}
+
+	cursor.Sink = sink
+	err = q.QueryRow(ctx, `
+UPDATE outbox_sink_cursors
+SET next_attempt_at = now() + make_interval(secs => $2)
+WHERE sink = $1 AND next_attempt_at <= now()
+RETURNING last_seq, attempts
+`, sink, lease.Seconds()).Scan(&cursor.LastSeq, &cursor.Attempts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.SinkCursor{}, false, nil
+	}
+	if err != nil {
+		return model.SinkCursor{}, false, fmt.Errorf("claim sink cursor: %w", err)
+	}
+	return cursor, true, nil
+}
+
+// AdvanceCursor сдвигает курсор sink на событие seq, сбрасывает счётчик неудач и освобождает курсор.
+func (r *OutboxRepo) AdvanceCursor(ctx context.Context, sink string, seq int64) error {
+	q := r.db.GetQueryExecutor(ctx)
+	if _, err := q.Exec(ctx, `
+UPDATE outbox_sink_cursors
+SET last_seq = $2, attempts = 0, next_attempt_at = now(), last_error = '', updated_at = now()
+WHERE sink = $1
+`, sink, seq); err != nil {
+		return fmt.Errorf("advance sink cursor: %w", err)
+	}
+	return nil
+}
+
+// RetryCursor записывает неудачную попытку sink и откладывает следующую до nextAttemptAt.
+func (r *OutboxRepo) RetryCursor(ctx context.Context, sink string, nextAttemptAt time.Time, lastErr string) error {
+	q := r.db.GetQueryExecutor(ctx)
+	if _, err := q.Exec(ctx, `
+UPDATE outbox_sink_cursors
+SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3, updated_at = now()
+WHERE sink = $1
+`, sink, nextAttemptAt, lastErr); err != nil {
+		return fmt.Errorf("retry sink cursor: %w", err)
+	}
+	return nil
+}
+
+// LatestPublishedSeq возвращает номер последнего опубликованного события (0, если их нет).
+func (r *OutboxRepo) LatestPublishedSeq(ctx context.Context) (int64, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	var seq int64
+	if err := q.QueryRow(ctx, `SELECT COALESCE(MAX(published_seq), 0) FROM outbox`).Scan(&seq); err != nil {
+		return 0, fmt.Errorf("latest published seq: %w", err)
+	}
+	return seq, nil
+}
+
+// ListPublishedSince возвращает до limit событий, опубликованных после номера after, в порядке публикации.
+func (r *OutboxRepo) ListPublishedSince(ctx context.Context, after int64, limit int) ([]model.Event, error) {
+	return r.listPublished(ctx, `
+SELECT published_seq, payload::text
+FROM outbox
+WHERE published_seq > $1
+ORDER BY published_seq
+LIMIT $2
+`, after, limit)
+}
+
+// ListReviewerEventsSince возвращает до limit опубликованных после after событий, касающихся
+// пользователя userID: его назначения и снятия, слияния PR, где он ревьювер, и ревью в PR,
+// где он ревьювер или автор.
+func (r *OutboxRepo) ListReviewerEventsSince(ctx context.Context, userID string, after int64, limit int) ([]model.Event, error) {
+	return r.listPublished(ctx, `
+SELECT published_seq, payload::text
+FROM outbox
+WHERE published_seq > $1
+  AND (payload->>'reviewer_id' = $3
+       OR payload->>'replaced_reviewer_id' = $3
+       OR (event_type = 'pull_request.merged' AND payload->'reviewers' ? $3)
+       OR (event_type = 'review.submitted' AND (payload->'reviewers' ? $3 OR payload->>'author_id' = $3)))
+ORDER BY published_seq
+LIMIT $2
+`, after, limit, userID)
+}
+
+func (r *OutboxRepo) listPublished(ctx context.Context, query string, args ...any) ([]model.Event, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("list published events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]model.Event, 0)
+	for rows.Next() {
+		var (
+			seq     int64
+			payload string
+		)
+		if err := rows.Scan(&seq, &payload); err != nil {
+			return nil, fmt.Errorf("scan published event: %w", err)
+		}
+		var ev model.Event
+		if err := json.Unmarshal([]byte(payload), &ev); err != nil {
+			return nil, fmt.Errorf("decode published event: %w", err)
+		}
+		ev.Seq = seq
+		events = append(events, ev)
+	}
+	return events, rows.Err()
+}
@@ -4,6 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"pull-request-service/internal/model"
@@ -35,17 +38,17 @@ func (r *PRRepo) CreatePRWithReviewers(
 
 	// 2. Выполняем запросы через q, а не через r.db.Pool
 	row := q.QueryRow(ctx, `
-INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status)
-VALUES ($1, $2, $3, $4)
-RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at
-`, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, string(pr.Status))
+INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, priority)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING pull_request_id, pull_request_name, author_id, status, priority, created_at, merged_at
+`, pr.PullRequestID, pr.PullRequestName, pr.AuthorID, string(pr.Status), string(pr.Priority))
 
 	var created model.PullRequest
-	var status string
+	var status, priority string
 	var createdAt time.Time
 	var mergedAt *time.Time
 
-	if err := row.Scan(&created.PullRequestID, &created.PullRequestName, &created.AuthorID, &status, &createdAt, &mergedAt); err != nil {
+	if err := row.Scan(&created.PullRequestID, &created.PullRequestName, &created.AuthorID, &status, &priority, &createdAt, &mergedAt); err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			return model.PullRequest{}, ErrPRExists
@@ -53,6 +56,7 @@ RETURNING pull_request_id, pull_request_name, author_id, status, created_at, mer
 		return model.PullRequest{}, fmt.Errorf("insert pr: %w", err)
 	}
 	created.Status = model.PullRequestStatus(status)
+	created.Priority = model.PullRequestPriority(priority)
 	created.CreatedAt = &createdAt
 	created.MergedAt = mergedAt
 	created.AssignedReviewers = make([]string, 0)
@@ -89,17 +93,17 @@ func (r *PRRepo) GetPR(ctx context.Context, prID string) (model.PullRequest, err
 	q := r.db.GetQueryExecutor(ctx)
 
 	row := q.QueryRow(ctx, `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+		SELECT pull_request_id, pull_request_name, author_id, status, priority, created_at, merged_at
 		FROM pull_requests
 		WHERE pull_request_id = $1
 	`, prID)
 
 	var pr model.PullRequest
-	var status string
+	var status, priority string
 	var createdAt time.Time
 	var mergedAt *time.Time
 
-	if err := row.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &createdAt, &mergedAt); err != nil {
+	if err := row.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &priority, &createdAt, &mergedAt); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return model.PullRequest{}, ErrPRNotFound
 		}
@@ -107,6 +111,7 @@ func (r *PRRepo) GetPR(ctx context.Context, prID string) (model.PullRequest, err
 	}
 
 	pr.Status = model.PullRequestStatus(status)
+	pr.Priority = model.PullRequestPriority(priority)
 	pr.CreatedAt = &createdAt
 	pr.MergedAt = mergedAt
 
@@ -123,20 +128,21 @@ func (r *PRRepo) GetPR(ctx context.Context, prID string) (model.PullRequest, err
 // MarkMerged помечает pull request как MERGED и устанавливает время мержа (если оно ещё не установлено).
 // Если PR не найден, возвращает ErrPRNotFound.
 func (r *PRRepo) MarkMerged(ctx context.Context, prID string, mergedAt time.Time) (model.PullRequest, error) {
-	row := r.db.Pool.QueryRow(ctx, `
+	q := r.db.GetQueryExecutor(ctx)
+	row := q.QueryRow(ctx, `
 UPDATE pull_requests
 SET status = 'MERGED',
     merged_at = COALESCE(merged_at, $2)
 WHERE pull_request_id = $1
-RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+RETURNING pull_request_id, pull_request_name, author_id, status, priority, created_at, merged_at
 `, prID, mergedAt)
 
 	var pr model.PullRequest
-	var status string
+	var status, priority string
 	var createdAt time.Time
 	var mergedAtOut *time.Time
 
-	if err := row.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &createdAt, &mergedAtOut); err != nil {
+	if err := row.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &priority, &createdAt, &mergedAtOut); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return model.PullRequest{}, ErrPRNotFound
 		}
@@ -144,10 +150,11 @@ RETURNING pull_request_id, pull_request_name, author_id, status, created_at, mer
 	}
 
 	pr.Status = model.PullRequestStatus(status)
+	pr.Priority = model.PullRequestPriority(priority)
 	pr.CreatedAt = &createdAt
 	pr.MergedAt = mergedAtOut
 
-	reviewers, err := r.listReviewers(ctx, r.db.Pool, pr.PullRequestID)
+	reviewers, err := r.listReviewersWithExecutor(ctx, q, pr.PullRequestID)
 	if err != nil {
 		return model.PullRequest{}, err
 	}
@@ -156,12 +163,37 @@ RETURNING pull_request_id, pull_request_name, author_id, status, created_at, mer
 	return pr, nil
 }
 
+// SetStatus переводит PR из статуса from в статус to.
+// Если PR не найден или его статус уже не from, возвращает ErrPRNotFound.
+func (r *PRRepo) SetStatus(ctx context.Context, prID string, from, to model.PullRequestStatus) error {
+	q := r.db.GetQueryExecutor(ctx)
+	tag, err := q.Exec(ctx, `
+UPDATE pull_requests
+SET status = $3
+WHERE pull_request_id = $1 AND status = $2
+`, prID, string(from), string(to))
+	if err != nil {
+		return fmt.Errorf("set pr status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrPRNotFound
+	}
+	return nil
+}
+
 // ReassignReviewer заменяет ревьювера oldUserID на newUserID в указанном PR.
+// Для нового ревьювера состояние ревью и время назначения сбрасываются.
 // Если строка не найдена (PR или ревьювер не привязан), возвращает ErrPRNotFound.
 func (r *PRRepo) ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string) (model.PullRequest, error) {
-	cmdTag, err := r.db.Pool.Exec(ctx, `
+	q := r.db.GetQueryExecutor(ctx)
+	cmdTag, err := q.Exec(ctx, `
 UPDATE pull_request_reviewers
-SET reviewer_id = $3
+SET reviewer_id       = $3,
+    state             = 'PENDING',
+    assigned_at       = now(),
+    reviewed_at       = NULL,
+    first_reviewed_at = NULL,
+    first_approved_at = NULL
 WHERE pull_request_id = $1 AND reviewer_id = $2
 `, prID, oldUserID, newUserID)
 	if err != nil {
@@ -176,17 +208,20 @@ WHERE pull_request_id = $1 AND reviewer_id = $2
 
 // ListAssignedToUser возвращает список укороченных описаний PR,
 // в которых указанный пользователь назначен ревьювером.
+// Очередь упорядочена по приоритету (hotfix первым), а внутри приоритета — от старых PR к новым.
 func (r *PRRepo) ListAssignedToUser(ctx context.Context, userID string) ([]model.PullRequestShort, error) {
-	rows, err := r.db.Pool.Query(ctx, `
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
 SELECT pr.pull_request_id,
        pr.pull_request_name,
        pr.author_id,
-       pr.status
+       pr.status,
+       pr.priority
 FROM pull_requests pr
 JOIN pull_request_reviewers r
   ON pr.pull_request_id = r.pull_request_id
 WHERE r.reviewer_id = $1
-ORDER BY pr.created_at DESC
+ORDER BY pr.priority DESC, pr.created_at ASC
 `, userID)
 	if err != nil {
 		return nil, fmt.Errorf("query pull requests: %w", err)
@@ -196,11 +231,53 @@ ORDER BY pr.created_at DESC
 	res := make([]model.PullRequestShort, 0)
 	for rows.Next() {
 		var pr model.PullRequestShort
-		var status string
-		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status); err != nil {
+		var status, priority string
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &priority); err != nil {
 			return nil, fmt.Errorf("scan pr: %w", err)
 		}
 		pr.Status = model.PullRequestStatus(status)
+		pr.Priority = model.PullRequestPriority(priority)
+		res = append(res, pr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return res, nil
+}
+
+// ListPendingAssignedToUser возвращает открытые PR, ревью которых пользователь ещё не оставил,
+// в том же порядке, что ListAssignedToUser, вместе с временем назначения.
+func (r *PRRepo) ListPendingAssignedToUser(ctx context.Context, userID string) ([]model.PendingReview, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT pr.pull_request_id,
+       pr.pull_request_name,
+       pr.author_id,
+       pr.status,
+       pr.priority,
+       r.assigned_at
+FROM pull_requests pr
+JOIN pull_request_reviewers r
+  ON pr.pull_request_id = r.pull_request_id
+WHERE r.reviewer_id = $1
+  AND r.state = 'PENDING'
+  AND pr.status = 'OPEN'
+ORDER BY pr.priority DESC, pr.created_at ASC
+`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query pending reviews: %w", err)
+	}
+	defer rows.Close()
+
+	res := make([]model.PendingReview, 0)
+	for rows.Next() {
+		var pr model.PendingReview
+		var status, priority string
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &priority, &pr.AssignedAt); err != nil {
+			return nil, fmt.Errorf("scan pending review: %w", err)
+		}
+		pr.Status = model.PullRequestStatus(status)
+		pr.Priority = model.PullRequestPriority(priority)
 		res = append(res, pr)
 	}
 	if err := rows.Err(); err != nil {
@@ -209,26 +286,257 @@ ORDER BY pr.created_at DESC
 	return res, nil
 }
 
-// listReviewers возвращает список идентификаторов ревьюверов для заданного PR.
-func (r *PRRepo) listReviewers(ctx context.Context, q pgxQuerier, prID string) ([]string, error) {
+// ListAuthoredByUser возвращает PR, автором которых является пользователь, от новых к старым,
+// вместе с состоянием ревью каждого ревьювера. Ревьюверы загружаются одним дополнительным запросом.
+func (r *PRRepo) ListAuthoredByUser(ctx context.Context, authorID string) ([]model.AuthoredPullRequest, error) {
+	q := r.db.GetQueryExecutor(ctx)
+
+	// Фильтр по author_id обслуживается индексом idx_pr_author
 	rows, err := q.Query(ctx, `
-SELECT reviewer_id
+SELECT pull_request_id, pull_request_name, status, priority, created_at, merged_at
+FROM pull_requests
+WHERE author_id = $1
+ORDER BY created_at DESC, pull_request_id
+`, authorID)
+	if err != nil {
+		return nil, fmt.Errorf("query authored prs: %w", err)
+	}
+	defer rows.Close()
+
+	prs := make([]model.AuthoredPullRequest, 0)
+	ids := make([]string, 0)
+	for rows.Next() {
+		var pr model.AuthoredPullRequest
+		var status, priority string
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &status, &priority, &pr.CreatedAt, &pr.MergedAt); err != nil {
+			return nil, fmt.Errorf("scan pr: %w", err)
+		}
+		pr.Status = model.PullRequestStatus(status)
+		pr.Priority = model.PullRequestPriority(priority)
+		pr.Reviewers = make([]model.ReviewerState, 0, 2)
+		prs = append(prs, pr)
+		ids = append(ids, pr.PullRequestID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	if len(prs) == 0 {
+		return prs, nil
+	}
+
+	revRows, err := q.Query(ctx, `
+SELECT pull_request_id, reviewer_id, state, assigned_at, reviewed_at
 FROM pull_request_reviewers
-WHERE pull_request_id = $1
-ORDER BY reviewer_id
-`, prID)
+WHERE pull_request_id = ANY($1)
+ORDER BY pull_request_id, reviewer_id
+`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("query reviewer states: %w", err)
+	}
+	defer revRows.Close()
+
+	byPR := make(map[string][]model.ReviewerState, len(ids))
+	for revRows.Next() {
+		var prID, state string
+		var rs model.ReviewerState
+		if err := revRows.Scan(&prID, &rs.ReviewerID, &state, &rs.AssignedAt, &rs.ReviewedAt); err != nil {
+			return nil, fmt.Errorf("scan reviewer state: %w", err)
+		}
+		rs.State = model.ReviewState(state)
+		byPR[prID] = append(byPR[prID], rs)
+	}
+	if err := revRows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	for i := range prs {
+		if rs, ok := byPR[prs[i].PullRequestID]; ok {
+			prs[i].Reviewers = rs
+		}
+	}
+	return prs, nil
+}
+
+// SetReviewState обновляет состояние ревью ревьювера в PR и время ревью.
+// Для состояния PENDING время ревью сбрасывается. Время первого ревью и первого одобрения
+// записывается один раз и дальше не меняется.
+// Если ревьювер не назначен на PR, возвращает ErrPRNotFound.
+func (r *PRRepo) SetReviewState(ctx context.Context, prID, reviewerID string, state model.ReviewState, at time.Time) error {
+	q := r.db.GetQueryExecutor(ctx)
+	cmdTag, err := q.Exec(ctx, `
+UPDATE pull_request_reviewers
+SET state             = $3,
+    reviewed_at       = CASE WHEN $3 = 'PENDING' THEN NULL ELSE $4::timestamptz END,
+    first_reviewed_at = COALESCE(first_reviewed_at, CASE WHEN $3 <> 'PENDING' THEN $4::timestamptz END),
+    first_approved_at = COALESCE(first_approved_at, CASE WHEN $3 = 'APPROVED' THEN $4::timestamptz END)
+WHERE pull_request_id = $1 AND reviewer_id = $2
+`, prID, reviewerID, string(state), at)
+	if err != nil {
+		return fmt.Errorf("set review state: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return ErrPRNotFound
+	}
+	return nil
+}
+
+// prSortColumns сопоставляет порядок сортировки с колонкой и направлением.
+// pull_request_id всегда добавляется вторым ключом, чтобы порядок был однозначным.
+var prSortColumns = map[model.PullRequestSort]struct {
+	column string
+	desc   bool
+}{
+	model.SortCreatedDesc: {column: "pr.created_at", desc: true},
+	model.SortCreatedAsc:  {column: "pr.created_at"},
+	model.SortNameAsc:     {column: "pr.pull_request_name"},
+	model.SortNameDesc:    {column: "pr.pull_request_name", desc: true},
+}
+
+// ListPullRequests возвращает страницу PR по фильтрам вместе с ревьюверами.
+// Ревьюверы всех PR страницы загружаются одним дополнительным запросом.
+func (r *PRRepo) ListPullRequests(ctx context.Context, filter model.PullRequestFilter) ([]model.PullRequest, error) {
+	q := r.db.GetQueryExecutor(ctx)
+
+	sortBy, ok := prSortColumns[filter.Sort]
+	if !ok {
+		sortBy = prSortColumns[model.SortCreatedDesc]
+	}
+
+	conds := make([]string, 0, 10)
+	args := make([]any, 0, 12)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.Status != "" {
+		conds = append(conds, "pr.status = "+arg(string(filter.Status)))
+	}
+	if filter.Priority != "" {
+		conds = append(conds, "pr.priority = "+arg(string(filter.Priority)))
+	}
+	if filter.AuthorID != "" {
+		conds = append(conds, "pr.author_id = "+arg(filter.AuthorID))
+	}
+	if filter.ReviewerID != "" {
+		conds = append(conds, `EXISTS (
+    SELECT 1 FROM pull_request_reviewers r
+    WHERE r.pull_request_id = pr.pull_request_id AND r.reviewer_id = `+arg(filter.ReviewerID)+`)`)
+	}
+	if filter.TeamName != "" {
+		conds = append(conds, `EXISTS (
+    SELECT 1 FROM users a JOIN teams t ON t.id = a.team_id
+    WHERE a.user_id = pr.author_id AND t.team_name = `+arg(filter.TeamName)+`)`)
+	}
+	if filter.NameContains != "" {
+		conds = append(conds, "pr.pull_request_name ILIKE "+arg("%"+escapeLike(filter.NameContains)+"%"))
+	}
+	if filter.CreatedFrom != nil {
+		conds = append(conds, "pr.created_at >= "+arg(*filter.CreatedFrom))
+	}
+	if filter.CreatedTo != nil {
+		conds = append(conds, "pr.created_at < "+arg(*filter.CreatedTo))
+	}
+	if filter.MergedFrom != nil {
+		conds = append(conds, "pr.merged_at >= "+arg(*filter.MergedFrom))
+	}
+	if filter.MergedTo != nil {
+		conds = append(conds, "pr.merged_at < "+arg(*filter.MergedTo))
+	}
+	if filter.After != nil {
+		var value any = filter.After.CreatedAt
+		if sortBy.column == "pr.pull_request_name" {
+			value = filter.After.PullRequestName
+		}
+		op := ">"
+		if sortBy.desc {
+			op = "<"
+		}
+		conds = append(conds, fmt.Sprintf("(%s, pr.pull_request_id) %s (%s, %s)",
+			sortBy.column, op, arg(value), arg(filter.After.PullRequestID)))
+	}
+
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, "\n  AND ")
+	}
+	dir := "ASC"
+	if sortBy.desc {
+		dir = "DESC"
+	}
+
+	rows, err := q.Query(ctx, fmt.Sprintf(`
+SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.priority, pr.created_at, pr.merged_at
+FROM pull_requests pr
+%s
+ORDER BY %s %s, pr.pull_request_id %s
+LIMIT %s
+`, where, sortBy.column, dir, dir, arg(filter.Limit)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	prs := make([]model.PullRequest, 0)
+	ids := make([]string, 0)
+	for rows.Next() {
+		var pr model.PullRequest
+		var status, priority string
+		var createdAt time.Time
+		var mergedAt *time.Time
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &status, &priority, &createdAt, &mergedAt); err != nil {
+			return nil, fmt.Errorf("scan pr: %w", err)
+		}
+		pr.Status = model.PullRequestStatus(status)
+		pr.Priority = model.PullRequestPriority(priority)
+		pr.CreatedAt = &createdAt
+		pr.MergedAt = mergedAt
+		pr.AssignedReviewers = make([]string, 0, 2)
+		prs = append(prs, pr)
+		ids = append(ids, pr.PullRequestID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	if len(prs) == 0 {
+		return prs, nil
+	}
+
+	reviewers, err := r.listReviewersForPRs(ctx, q, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i := range prs {
+		if rs, ok := reviewers[prs[i].PullRequestID]; ok {
+			prs[i].AssignedReviewers = rs
+		}
+	}
+
+	return prs, nil
+}
+
+// listReviewersForPRs возвращает ревьюверов сразу для набора PR: PRID -> список reviewer_id.
+func (r *PRRepo) listReviewersForPRs(ctx context.Context, q DBTX, prIDs []string) (map[string][]string, error) {
+	rows, err := q.Query(ctx, `
+SELECT pull_request_id, reviewer_id
+FROM pull_request_reviewers
+WHERE pull_request_id = ANY($1)
+ORDER BY pull_request_id, reviewer_id
+`, prIDs)
 	if err != nil {
 		return nil, fmt.Errorf("query reviewers: %w", err)
 	}
 	defer rows.Close()
 
-	res := make([]string, 0)
+	res := make(map[string][]string, len(prIDs))
 	for rows.Next() {
-		var rid string
-		if err := rows.Scan(&rid); err != nil {
+		var prID, rid string
+		if err := rows.Scan(&prID, &rid); err != nil {
 			return nil, fmt.Errorf("scan reviewer: %w", err)
 		}
-		res = append(res, rid)
+		res[prID] = append(res[prID], rid)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows error: %w", err)
@@ -236,13 +544,8 @@ ORDER BY reviewer_id
 	return res, nil
 }
 
-// pgxQuerier описывает минимальный интерфейс для выполнения запросов,
-// который реализуют как пул соединений, так и транзакция pgx.
-type pgxQuerier interface {
-	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
-}
-
-// listReviewersWithExecutor — вспомогательный метод
+// listReviewersWithExecutor возвращает список идентификаторов ревьюверов для заданного PR,
+// выполняя запрос через переданный исполнитель (транзакцию или пул).
 func (r *PRRepo) listReviewersWithExecutor(ctx context.Context, q DBTX, prID string) ([]string, error) {
 	rows, err := q.Query(ctx, `
 		SELECT reviewer_id
@@ -293,20 +596,15 @@ func (r *PRRepo) GetOpenPRsByReviewers(ctx context.Context, reviewerIDs []string
 	return result, nil
 }
 
-// StatsDTO для статистики
-type StatsDTO struct {
-	ReviewerID  string `json:"reviewer_id"`
-	ReviewCount int    `json:"review_count"`
-}
-
-// GetReviewerStats возвращает количество назначений по пользователям.
+// GetReviewerStats возвращает количество назначений по пользователям
+// с разбивкой по приоритетам PR.
 func (r *PRRepo) GetReviewerStats(ctx context.Context) ([]model.StatsDTO, error) {
 	q := r.db.GetQueryExecutor(ctx)
 	rows, err := q.Query(ctx, `
-		SELECT reviewer_id, COUNT(*) 
-		FROM pull_request_reviewers 
-		GROUP BY reviewer_id 
-		ORDER BY COUNT(*) DESC
+		SELECT r.reviewer_id, pr.priority, COUNT(*)
+		FROM pull_request_reviewers r
+		JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+		GROUP BY r.reviewer_id, pr.priority
 	`)
 	if err != nil {
 		return nil, err
@@ -315,22 +613,136 @@ func (r *PRRepo) GetReviewerStats(ctx context.Context) ([]model.StatsDTO, error)
 
 	// Используем тип из пакета model
 	var stats []model.StatsDTO
+	index := make(map[string]int)
 
 	for rows.Next() {
-		var s model.StatsDTO
-		if err := rows.Scan(&s.ReviewerID, &s.ReviewCount); err != nil {
+		var reviewerID, priority string
+		var count int
+		if err := rows.Scan(&reviewerID, &priority, &count); err != nil {
 			return nil, err
 		}
-		stats = append(stats, s)
+
+		i, ok := index[reviewerID]
+		if !ok {
+			i = len(stats)
+			index[reviewerID] = i
+			stats = append(stats, model.StatsDTO{
+				ReviewerID: reviewerID,
+				ByPriority: make(map[model.PullRequestPriority]int),
+			})
+		}
+		stats[i].ReviewCount += count
+		stats[i].ByPriority[model.PullRequestPriority(priority)] = count
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("rows iteration error: %w", err)
 	}
 
+	sort.SliceStable(stats, func(i, j int) bool {
+		if stats[i].ReviewCount != stats[j].ReviewCount {
+			return stats[i].ReviewCount > stats[j].ReviewCount
+		}
+		return stats[i].ReviewerID < stats[j].ReviewerID
+	})
+
 	return stats, nil
 }
 
+// ListReviewerStats возвращает нагрузку пользователей по назначениям, подходящим под filter:
+// активных — даже без назначений (они нужны для оценки равномерности), неактивных — только
+// с назначениями. Снятые с PR назначения берутся из журнала reviewer_reassignments.
+// Возраст открытых ревью считается на момент now. Порядок — по убыванию числа назначений.
+func (r *PRRepo) ListReviewerStats(ctx context.Context, filter model.StatsFilter, now time.Time) ([]model.ReviewerStats, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+WITH assignments AS (
+    SELECT reviewer_id, pull_request_id, assigned_at, FALSE AS away
+    FROM pull_request_reviewers
+    UNION ALL
+    SELECT reviewer_id, pull_request_id, assigned_at, TRUE
+    FROM reviewer_reassignments
+)
+SELECT u.user_id, u.username, COALESCE(t.team_name, ''), u.is_active,
+       pr.priority,
+       COUNT(a.pull_request_id),
+       COUNT(*) FILTER (WHERE NOT a.away AND pr.status = 'OPEN'),
+       COUNT(*) FILTER (WHERE NOT a.away AND pr.status = 'MERGED'),
+       COUNT(*) FILTER (WHERE a.away),
+       COALESCE(SUM(EXTRACT(EPOCH FROM $5 - a.assigned_at)) FILTER (WHERE NOT a.away AND pr.status = 'OPEN'), 0)::float8
+FROM users u
+LEFT JOIN teams t ON t.id = u.team_id
+LEFT JOIN (
+    assignments a
+    JOIN pull_requests pr ON pr.pull_request_id = a.pull_request_id
+        AND ($2 = '' OR pr.status::text = $2)
+        AND ($3::timestamptz IS NULL OR a.assigned_at >= $3)
+        AND ($4::timestamptz IS NULL OR a.assigned_at < $4)
+) ON a.reviewer_id = u.user_id
+WHERE $1 = '' OR t.team_name = $1
+GROUP BY u.user_id, u.username, t.team_name, u.is_active, pr.priority
+`, filter.TeamName, string(filter.Status), filter.From, filter.To, now)
+	if err != nil {
+		return nil, fmt.Errorf("query reviewer stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]model.ReviewerStats, 0)
+	index := make(map[string]int)
+	openAge := make(map[string]float64)
+	for rows.Next() {
+		var (
+			st                        model.ReviewerStats
+			priority                  *string
+			total, open, merged, away int
+			openAgeSeconds            float64
+		)
+		if err := rows.Scan(&st.ReviewerID, &st.Username, &st.TeamName, &st.IsActive,
+			&priority, &total, &open, &merged, &away, &openAgeSeconds); err != nil {
+			return nil, fmt.Errorf("scan reviewer stats: %w", err)
+		}
+
+		i, ok := index[st.ReviewerID]
+		if !ok {
+			i = len(stats)
+			index[st.ReviewerID] = i
+			st.ByPriority = make(map[model.PullRequestPriority]int)
+			stats = append(stats, st)
+		}
+		s := &stats[i]
+		if priority != nil {
+			s.ByPriority[model.PullRequestPriority(*priority)] = total
+		}
+		s.TotalAssignments += total
+		s.Open += open
+		s.Merged += merged
+		s.ReassignedAway += away
+		openAge[s.ReviewerID] += openAgeSeconds
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows iteration error: %w", err)
+	}
+
+	result := stats[:0]
+	for _, s := range stats {
+		if !s.IsActive && s.TotalAssignments == 0 {
+			continue
+		}
+		if s.Open > 0 {
+			s.AvgOpenAgeHours = math.Round(openAge[s.ReviewerID]/float64(s.Open)/36) / 100
+		}
+		result = append(result, s)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].TotalAssignments != result[j].TotalAssignments {
+			return result[i].TotalAssignments > result[j].TotalAssignments
+		}
+		return result[i].ReviewerID < result[j].ReviewerID
+	})
+	return result, nil
+}
+
 // RemoveReviewer удаляет ревьювера из PR.
 // Используется, когда деактивированного пользователя некем заменить.
 func (r *PRRepo) RemoveReviewer(ctx context.Context, prID, reviewerID string) error {
@@ -344,3 +756,62 @@ func (r *PRRepo) RemoveReviewer(ctx context.Context, prID, reviewerID string) er
 	}
 	return nil
 }
+
+// ListStalePullRequests возвращает до limit самых старых открытых PR, созданных раньше createdBefore,
+// у которых нет ревьюверов или кто-то из ревьюверов ещё не оставил ревью.
+func (r *PRRepo) ListStalePullRequests(ctx context.Context, createdBefore time.Time, limit int) ([]model.StalledPR, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, COALESCE(t.team_name, ''), pr.created_at,
+       COALESCE(array_agg(r.reviewer_id ORDER BY r.reviewer_id) FILTER (WHERE r.state = 'PENDING'), '{}')
+FROM pull_requests pr
+JOIN users a ON a.user_id = pr.author_id
+LEFT JOIN teams t ON t.id = a.team_id
+LEFT JOIN pull_request_reviewers r ON r.pull_request_id = pr.pull_request_id
+WHERE pr.status = 'OPEN' AND pr.created_at <= $1
+GROUP BY pr.pull_request_id, t.team_name
+HAVING COUNT(r.reviewer_id) = 0 OR bool_or(r.state = 'PENDING')
+ORDER BY pr.created_at, pr.pull_request_id
+LIMIT $2
+`, createdBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query stale pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	prs := make([]model.StalledPR, 0)
+	for rows.Next() {
+		var pr model.StalledPR
+		if err := rows.Scan(&pr.PullRequestID, &pr.PullRequestName, &pr.AuthorID, &pr.TeamName, &pr.CreatedAt, &pr.PendingReviewers); err != nil {
+			return nil, fmt.Errorf("scan stale pull request: %w", err)
+		}
+		prs = append(prs, pr)
+	}
+	return prs, rows.Err()
+}
+
+// ListRecentReassignments возвращает до limit последних снятий ревьюверов с PR, начиная с новых.
+func (r *PRRepo) ListRecentReassignments(ctx context.Context, limit int) ([]model.ReviewerReassignment, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT h.pull_request_id, COALESCE(pr.pull_request_name, ''), h.reviewer_id, COALESCE(h.replaced_by, ''), h.reassigned_at
+FROM reviewer_reassignments h
+LEFT JOIN pull_requests pr ON pr.pull_request_id = h.pull_request_id
+ORDER BY h.reassigned_at DESC, h.id DESC
+LIMIT $1
+`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query reassignments: %w", err)
+	}
+	defer rows.Close()
+
+	list := make([]model.ReviewerReassignment, 0)
+	for rows.Next() {
+		var ra model.ReviewerReassignment
+		if err := rows.Scan(&ra.PullRequestID, &ra.PullRequestName, &ra.ReviewerID, &ra.ReplacedBy, &ra.ReassignedAt); err != nil {
+			return nil, fmt.Errorf("scan reassignment: %w", err)
+		}
+		list = append(list, ra)
+	}
+	return list, rows.Err()
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// retryQueue — таблица очереди с повторами: id, attempts, next_attempt_at, last_error и
+// (для очередей без dead letters) failed_at. Значение — имя таблицы; оно подставляется в SQL,
+// поэтому задаётся только константами ниже.
+type retryQueue string
+
+const (
+	emailQueue    retryQueue = "email_notifications"
+	chatQueue     retryQueue = "chat_notifications"
+	deliveryQueue retryQueue = "webhook_deliveries"
+)
+
+// claim выбирает до limit элементов, время попытки которых наступило и которые не помечены
+// неудачными, и откладывает их на lease, чтобы их не взял другой экземпляр сервиса.
+// Если исход попытки не будет записан за lease, элемент снова станет доступным.
+// returning — список столбцов RETURNING.
+func (t retryQueue) claim(ctx context.Context, q DBTX, limit int, lease time.Duration, returning string) (pgx.Rows, error) {
+	return q.Query(ctx, fmt.Sprintf(`
+UPDATE %[1]s
+SET next_attempt_at = now() + make_interval(secs => $2)
+WHERE id IN (
+    SELECT id
+    FROM %[1]s
+    WHERE failed_at IS NULL AND next_attempt_at <= now()
+    ORDER BY next_attempt_at, id
+    LIMIT $1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING %[2]s
+`, t, returning), limit, lease.Seconds())
+}
+
+// complete удаляет отправленный элемент из очереди.
+func (t retryQueue) complete(ctx context.Context, q DBTX, id int64) error {
+	_, err := q.Exec(ctx, fmt.Sprintf(`DELETE FROM %s WHERE id = $1`, t), id)
+	return err
+}
+
+// retry засчитывает неудачную попытку и назначает следующую на nextAttemptAt.
+func (t retryQueue) retry(ctx context.Context, q DBTX, id int64, nextAttemptAt time.Time, lastErr string) error {
+	_, err := q.Exec(ctx, fmt.Sprintf(`
+UPDATE %s
+SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3
+WHERE id = $1
+`, t), id, nextAttemptAt, lastErr)
+	return err
+}
+
+// fail засчитывает последнюю неудачную попытку и оставляет элемент в таблице с failed_at для разбора.
+func (t retryQueue) fail(ctx context.Context, q DBTX, id int64, lastErr string) error {
+	_, err := q.Exec(ctx, fmt.Sprintf(`
+UPDATE %s
+SET attempts = attempts + 1, last_error = $2, failed_at = now()
+WHERE id = $1
+`, t), id, lastErr)
+	return err
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"pull-request-service/internal/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// schedulerLockKey — ключ advisory-блокировки лидера планировщика.
+const schedulerLockKey int64 = 0x7363686564 // "sched"
+
+// LeaderLock — лидерство среди экземпляров сервиса на сессионной advisory-блокировке.
+// Блокировка держится на отдельном соединении пула: если соединение рвётся, PostgreSQL
+// снимает её, и лидером становится другой экземпляр.
+type LeaderLock struct {
+	db  *Postgres
+	key int64
+
+	mu   sync.Mutex
+	conn *pgxpool.Conn
+}
+
+// NewLeaderLock создаёт лидерство планировщика задач.
+func NewLeaderLock(db *Postgres) *LeaderLock {
+	return &LeaderLock{db: db, key: schedulerLockKey}
+}
+
+// Acquire пытается получить блокировку, а если она уже получена — проверяет соединение,
+// на котором она держится. Возвращает true, пока экземпляр остаётся лидером.
+func (l *LeaderLock) Acquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		if err := l.conn.Ping(ctx); err == nil {
+			return true, nil
+		}
+		// Соединение потеряно вместе с блокировкой
+		l.conn.Conn().Close(context.Background())
+		l.conn.Release()
+		l.conn = nil
+	}
+
+	conn, err := l.db.Pool.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("acquire connection: %w", err)
+	}
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock($1)`, l.key).Scan(&locked); err != nil {
+		conn.Release()
+		return false, fmt.Errorf("try leader lock: %w", err)
+	}
+	if !locked {
+		conn.Release()
+		return false, nil
+	}
+	l.conn = conn
+	return true, nil
+}
+
+// Release снимает блокировку и возвращает соединение в пул.
+func (l *LeaderLock) Release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := l.conn.Exec(ctx, `SELECT pg_advisory_unlock($1)`, l.key); err != nil {
+		// Без явного снятия блокировка уйдёт вместе с соединением
+		l.conn.Conn().Close(ctx)
+	}
+	l.conn.Release()
+	l.conn = nil
+}
+
+// SchedulerRepo хранит историю запусков задач планировщика.
+type SchedulerRepo struct {
+	db *Postgres
+}
+
+// NewSchedulerRepo создаёт новый экземпляр SchedulerRepo c переданным подключением к PostgreSQL.
+func NewSchedulerRepo(db *Postgres) *SchedulerRepo {
+	return &SchedulerRepo{db: db}
+}
+
+// LastScheduledAt возвращает срабатывание, для которого задача запускалась последний раз,
+// или нулевое время, если она ещё не запускалась.
+func (r *SchedulerRepo) LastScheduledAt(ctx context.Context, job string) (time.Time, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	var last *time.Time
+	if err := q.QueryRow(ctx, `
+SELECT max(scheduled_at) FROM scheduler_runs WHERE job_name = $1
+`, job).Scan(&last); err != nil {
+		return time.Time{}, fmt.Errorf("last job run: %w", err)
+	}
+	if last == nil {
+		return time.Time{}, nil
+	}
+	return *last, nil
+}
+
+// StartRun записывает начало запуска задачи для срабатывания scheduledAt.
+// Если запуск для этого срабатывания уже есть, возвращает started = false.
+func (r *SchedulerRepo) StartRun(ctx context.Context, job string, scheduledAt time.Time) (int64, bool, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	var id int64
+	err := q.QueryRow(ctx, `
+INSERT INTO scheduler_runs (job_name, scheduled_at)
+VALUES ($1, $2)
+ON CONFLICT (job_name, scheduled_at) DO NOTHING
+RETURNING id
+`, job, scheduledAt).Scan(&id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, nil
+		}
+		return 0, false, fmt.Errorf("start job run: %w", err)
+	}
+	return id, true, nil
+}
+
+// FinishRun записывает итог запуска: пустой runErr означает успех.
+func (r *SchedulerRepo) FinishRun(ctx context.Context, id int64, summary string, runErr string) error {
+	status := model.JobSucceeded
+	if runErr != "" {
+		status = model.JobFailed
+	}
+	q := r.db.GetQueryExecutor(ctx)
+	if _, err := q.Exec(ctx, `
+UPDATE scheduler_runs
+SET finished_at = now(), status = $2, summary = $3, error = $4
+WHERE id = $1
+`, id, string(status), summary, runErr); err != nil {
+		return fmt.Errorf("finish job run: %w", err)
+	}
+	return nil
+}
+
+// FailRunningRuns помечает все незавершённые запуски неудачными с ошибкой runErr
+// и возвращает их число. Вызывается новым лидером до того, как он начнёт запускать задачи.
+func (r *SchedulerRepo) FailRunningRuns(ctx context.Context, runErr string) (int64, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	cmdTag, err := q.Exec(ctx, `
+UPDATE scheduler_runs
+SET finished_at = now(), status = $1, error = $2
+WHERE status = $3
+`, string(model.JobFailed), runErr, string(model.JobRunning))
+	if err != nil {
+		return 0, fmt.Errorf("fail running job runs: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// PruneRuns удаляет завершённые запуски, начатые раньше before, кроме последнего запуска каждой задачи:
+// по нему LastScheduledAt продолжает расписание. Возвращает число удалённых запусков.
+func (r *SchedulerRepo) PruneRuns(ctx context.Context, before time.Time) (int64, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	cmdTag, err := q.Exec(ctx, `
+DELETE FROM scheduler_runs r
+WHERE r.started_at < $1
+  AND r.finished_at IS NOT NULL
+  AND r.scheduled_at < (SELECT max(scheduled_at) FROM scheduler_runs l WHERE l.job_name = r.job_name)
+`, before)
+	if err != nil {
+		return 0, fmt.Errorf("prune job runs: %w", err)
+	}
+	return cmdTag.RowsAffected(), nil
+}
+
+// ListRuns возвращает до limit последних запусков, начиная с новых. Пустой job — запуски всех задач.
+func (r *SchedulerRepo) ListRuns(ctx context.Context, job string, limit int) ([]model.JobRun, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT id, job_name, scheduled_at, started_at, finished_at, status, summary, error
+FROM scheduler_runs
+WHERE $1 = '' OR job_name = $1
+ORDER BY started_at DESC, id DESC
+LIMIT $2
+`, job, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list job runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := make([]model.JobRun, 0)
+	for rows.Next() {
+		var run model.JobRun
+		var status string
+		if err := rows.Scan(&run.ID, &run.Job, &run.ScheduledAt, &run.StartedAt, &run.FinishedAt, &status, &run.Summary, &run.Error); err != nil {
+			return nil, fmt.Errorf("scan job run: %w", err)
+		}
+		run.Status = model.JobRunStatus(status)
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
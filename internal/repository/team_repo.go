@@ -4,9 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"pull-request-service/internal/model"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 )
 
@@ -20,23 +23,28 @@ func NewTeamRepo(db *Postgres) *TeamRepo {
 	return &TeamRepo{db: db}
 }
 
-// CreateTeamWithMembers создаёт команду с участниками и обновляет/создаёт пользователей,
-// привязывая их к команде по team_id. При конфликте по имени команды вернёт ErrTeamExists.
+// CreateTeamWithMembers создаёт команду с участниками и создаёт пользователей,
+// привязывая их к команде по team_id. Существующий пользователь без команды присоединяется
+// к новой, а пользователь из другой команды не переносится — возвращается ErrUserInOtherTeam.
+// Если указан ParentTeamName, команда создаётся дочерней (ErrParentTeamNotFound, если родителя нет).
+// При конфликте по имени команды вернёт ErrTeamExists.
 func (r *TeamRepo) CreateTeamWithMembers(ctx context.Context, t model.Team) (model.Team, error) {
-	tx, err := r.db.Pool.Begin(ctx)
-	if err != nil {
-		return model.Team{}, fmt.Errorf("begin tx: %w", err)
-	}
-	defer func() {
+	q := r.db.GetQueryExecutor(ctx)
+
+	var parentID *int64
+	if t.ParentTeamName != "" {
+		id, err := r.activeTeamIDByName(ctx, q, t.ParentTeamName)
 		if err != nil {
-			_ = tx.Rollback(ctx)
-		} else {
-			_ = tx.Commit(ctx)
+			if errors.Is(err, ErrTeamNotFound) {
+				return model.Team{}, ErrParentTeamNotFound
+			}
+			return model.Team{}, err
 		}
-	}()
+		parentID = &id
+	}
 
 	var teamID int64
-	err = tx.QueryRow(ctx, `INSERT INTO teams (team_name) VALUES ($1) RETURNING id`, t.TeamName).Scan(&teamID)
+	err := q.QueryRow(ctx, `INSERT INTO teams (team_name, parent_team_id) VALUES ($1, $2) RETURNING id`, t.TeamName, parentID).Scan(&teamID)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
@@ -47,17 +55,25 @@ func (r *TeamRepo) CreateTeamWithMembers(ctx context.Context, t model.Team) (mod
 	}
 
 	for _, m := range t.Members {
-		_, err = tx.Exec(ctx, `
+		cmdTag, err := q.Exec(ctx, `
 INSERT INTO users (user_id, username, team_id, is_active)
 VALUES ($1, $2, $3, $4)
 ON CONFLICT (user_id) DO UPDATE
-SET username = EXCLUDED.username,
-    team_id  = EXCLUDED.team_id,
+SET username  = EXCLUDED.username,
+    team_id   = EXCLUDED.team_id,
     is_active = EXCLUDED.is_active
+WHERE users.team_id IS NULL
 `, m.UserID, m.Username, teamID, m.IsActive)
 		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return model.Team{}, ErrUsernameTaken
+			}
 			return model.Team{}, fmt.Errorf("upsert user %s: %w", m.UserID, err)
 		}
+		if cmdTag.RowsAffected() == 0 {
+			return model.Team{}, ErrUserInOtherTeam
+		}
 	}
 
 	return t, nil
@@ -66,9 +82,11 @@ SET username = EXCLUDED.username,
 // GetTeamByName возвращает команду с указанным именем и списком её участников.
 // Если команда не найдена, возвращает ErrTeamNotFound.
 func (r *TeamRepo) GetTeamByName(ctx context.Context, name string) (model.Team, error) {
-	rows, err := r.db.Pool.Query(ctx, `
-SELECT t.team_name, u.user_id, u.username, u.is_active
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT t.team_name, COALESCE(p.team_name, ''), t.archived_at, u.user_id, u.username, u.is_active
 FROM teams t
+LEFT JOIN teams p ON p.id = t.parent_team_id
 LEFT JOIN users u ON u.team_id = t.id
 WHERE t.team_name = $1
 ORDER BY u.user_id
@@ -88,17 +106,20 @@ ORDER BY u.user_id
 	for rows.Next() {
 		foundTeam = true
 
-		var teamName string
+		var teamName, parentName string
+		var archivedAt *time.Time
 		var userID *string
 		var username *string
 		var isActive *bool
 
-		if err := rows.Scan(&teamName, &userID, &username, &isActive); err != nil {
+		if err := rows.Scan(&teamName, &parentName, &archivedAt, &userID, &username, &isActive); err != nil {
 			return model.Team{}, fmt.Errorf("scan row: %w", err)
 		}
 
 		// синхронизируем имя команды с тем, что реально лежит в БД
 		team.TeamName = teamName
+		team.ParentTeamName = parentName
+		team.ArchivedAt = archivedAt
 
 		if userID != nil && username != nil && isActive != nil {
 			team.Members = append(team.Members, model.TeamMember{
@@ -119,3 +140,479 @@ ORDER BY u.user_id
 
 	return team, nil
 }
+
+// ListTeams возвращает страницу команд, упорядоченных по имени, с количеством участников.
+// Архивные команды включаются только при filter.IncludeArchived.
+func (r *TeamRepo) ListTeams(ctx context.Context, filter model.TeamFilter) ([]model.TeamSummary, error) {
+	q := r.db.GetQueryExecutor(ctx)
+
+	conds := make([]string, 0, 2)
+	args := make([]any, 0, 3)
+	if !filter.IncludeArchived {
+		conds = append(conds, "t.archived_at IS NULL")
+	}
+	if filter.After != "" {
+		args = append(args, filter.After)
+		conds = append(conds, fmt.Sprintf("t.team_name > $%d", len(args)))
+	}
+	where := ""
+	if len(conds) > 0 {
+		where = "WHERE " + strings.Join(conds, " AND ")
+	}
+	args = append(args, filter.Limit)
+
+	rows, err := q.Query(ctx, fmt.Sprintf(`
+SELECT t.team_name,
+       COALESCE(p.team_name, ''),
+       t.archived_at,
+       COUNT(u.user_id),
+       COUNT(u.user_id) FILTER (WHERE u.is_active)
+FROM teams t
+LEFT JOIN teams p ON p.id = t.parent_team_id
+LEFT JOIN users u ON u.team_id = t.id
+%s
+GROUP BY t.id, p.team_name
+ORDER BY t.team_name
+LIMIT $%d
+`, where, len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query teams: %w", err)
+	}
+	defer rows.Close()
+
+	teams := make([]model.TeamSummary, 0)
+	for rows.Next() {
+		var t model.TeamSummary
+		if err := rows.Scan(&t.TeamName, &t.ParentTeamName, &t.ArchivedAt, &t.MembersCount, &t.ActiveMembersCount); err != nil {
+			return nil, fmt.Errorf("scan team: %w", err)
+		}
+		teams = append(teams, t)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return teams, nil
+}
+
+// ListRoster возвращает все неархивные команды с родителями и участниками,
+// упорядоченные по имени команды и user_id. Используется для экспорта состава.
+func (r *TeamRepo) ListRoster(ctx context.Context) ([]model.Team, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT t.team_name, COALESCE(p.team_name, ''), u.user_id, u.username, u.is_active
+FROM teams t
+LEFT JOIN teams p ON p.id = t.parent_team_id
+LEFT JOIN users u ON u.team_id = t.id
+WHERE t.archived_at IS NULL
+ORDER BY t.team_name, u.user_id
+`)
+	if err != nil {
+		return nil, fmt.Errorf("query roster: %w", err)
+	}
+	defer rows.Close()
+
+	teams := make([]model.Team, 0)
+	for rows.Next() {
+		var (
+			teamName, parentName string
+			userID, username     *string
+			isActive             *bool
+		)
+		if err := rows.Scan(&teamName, &parentName, &userID, &username, &isActive); err != nil {
+			return nil, fmt.Errorf("scan roster row: %w", err)
+		}
+
+		if len(teams) == 0 || teams[len(teams)-1].TeamName != teamName {
+			teams = append(teams, model.Team{
+				TeamName:       teamName,
+				ParentTeamName: parentName,
+				Members:        make([]model.TeamMember, 0),
+			})
+		}
+		if userID != nil {
+			t := &teams[len(teams)-1]
+			t.Members = append(t.Members, model.TeamMember{
+				UserID:   *userID,
+				Username: *username,
+				IsActive: *isActive,
+			})
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return teams, nil
+}
+
+// SetParent переносит команду вместе со всем её поддеревом под parentName.
+// Пустой parentName делает команду корневой. Перенос под саму команду или её потомка
+// возвращает ErrTeamCycle. Должен вызываться внутри транзакции: переносы сериализуются
+// advisory-локом, чтобы параллельные переносы не образовали цикл.
+func (r *TeamRepo) SetParent(ctx context.Context, teamName, parentName string) error {
+	q := r.db.GetQueryExecutor(ctx)
+
+	if _, err := q.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext('teams_hierarchy'))`); err != nil {
+		return fmt.Errorf("lock hierarchy: %w", err)
+	}
+
+	teamID, err := r.teamIDByName(ctx, q, teamName)
+	if err != nil {
+		return err
+	}
+
+	var parentID *int64
+	if parentName != "" {
+		id, err := r.activeTeamIDByName(ctx, q, parentName)
+		if err != nil {
+			if errors.Is(err, ErrTeamNotFound) {
+				return ErrParentTeamNotFound
+			}
+			return err
+		}
+
+		var cycle bool
+		err = q.QueryRow(ctx, `
+WITH RECURSIVE subtree AS (
+    SELECT id FROM teams WHERE id = $1
+    UNION ALL
+    SELECT c.id FROM subtree s JOIN teams c ON c.parent_team_id = s.id
+)
+SELECT EXISTS (SELECT 1 FROM subtree WHERE id = $2)
+`, teamID, id).Scan(&cycle)
+		if err != nil {
+			return fmt.Errorf("check hierarchy cycle: %w", err)
+		}
+		if cycle {
+			return ErrTeamCycle
+		}
+		parentID = &id
+	}
+
+	if _, err := q.Exec(ctx, `UPDATE teams SET parent_team_id = $2 WHERE id = $1`, teamID, parentID); err != nil {
+		return fmt.Errorf("set parent: %w", err)
+	}
+	return nil
+}
+
+// ListDescendants возвращает всех потомков команды по уровням (сначала дети, затем внуки и т.д.).
+// Если команда не найдена, возвращает ErrTeamNotFound.
+func (r *TeamRepo) ListDescendants(ctx context.Context, teamName string) ([]model.TeamNode, error) {
+	q := r.db.GetQueryExecutor(ctx)
+
+	teamID, err := r.teamIDByName(ctx, q, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := q.Query(ctx, `
+WITH RECURSIVE subtree AS (
+    SELECT c.id, c.team_name, $2::text AS parent_name, 1 AS depth
+    FROM teams c
+    WHERE c.parent_team_id = $1
+    UNION ALL
+    SELECT c.id, c.team_name, s.team_name, s.depth + 1
+    FROM subtree s
+    JOIN teams c ON c.parent_team_id = s.id
+)
+SELECT team_name, parent_name, depth
+FROM subtree
+ORDER BY depth, team_name
+`, teamID, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("query descendants: %w", err)
+	}
+	defer rows.Close()
+
+	nodes := make([]model.TeamNode, 0)
+	for rows.Next() {
+		var n model.TeamNode
+		if err := rows.Scan(&n.TeamName, &n.ParentTeamName, &n.Depth); err != nil {
+			return nil, fmt.Errorf("scan team node: %w", err)
+		}
+		nodes = append(nodes, n)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return nodes, nil
+}
+
+// ListTeamStats возвращает счётчики прямых участников и их ревью для всех команд,
+// включая архивные (они нужны для корректного свёртывания по иерархии).
+func (r *TeamRepo) ListTeamStats(ctx context.Context) ([]model.TeamStatsDTO, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT t.team_name,
+       COALESCE(p.team_name, ''),
+       COUNT(DISTINCT u.user_id),
+       COUNT(DISTINCT u.user_id) FILTER (WHERE u.is_active),
+       COUNT(r.reviewer_id),
+       COUNT(r.reviewer_id) FILTER (WHERE pr.status = 'OPEN')
+FROM teams t
+LEFT JOIN teams p ON p.id = t.parent_team_id
+LEFT JOIN users u ON u.team_id = t.id
+LEFT JOIN pull_request_reviewers r ON r.reviewer_id = u.user_id
+LEFT JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+GROUP BY t.id, p.team_name
+ORDER BY t.team_name
+`)
+	if err != nil {
+		return nil, fmt.Errorf("query team stats: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make([]model.TeamStatsDTO, 0)
+	for rows.Next() {
+		var st model.TeamStatsDTO
+		d := &st.Direct
+		if err := rows.Scan(&st.TeamName, &st.ParentTeamName, &d.MembersCount, &d.ActiveMembersCount, &d.ReviewCount, &d.OpenReviewCount); err != nil {
+			return nil, fmt.Errorf("scan team stats: %w", err)
+		}
+		stats = append(stats, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return stats, nil
+}
+
+// AddMembers добавляет участников в существующую команду. Новые пользователи создаются,
+// а уже существующие обновляются, только если они не состоят в другой команде.
+// Если команда не найдена, возвращает ErrTeamNotFound, если архивна — ErrTeamArchived.
+func (r *TeamRepo) AddMembers(ctx context.Context, teamName string, members []model.TeamMember) error {
+	q := r.db.GetQueryExecutor(ctx)
+
+	teamID, err := r.activeTeamIDByName(ctx, q, teamName)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range members {
+		cmdTag, err := q.Exec(ctx, `
+INSERT INTO users (user_id, username, team_id, is_active)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (user_id) DO UPDATE
+SET username  = EXCLUDED.username,
+    team_id   = EXCLUDED.team_id,
+    is_active = EXCLUDED.is_active
+WHERE users.team_id IS NULL OR users.team_id = EXCLUDED.team_id
+`, m.UserID, m.Username, teamID, m.IsActive)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+				return ErrUsernameTaken
+			}
+			return fmt.Errorf("upsert user %s: %w", m.UserID, err)
+		}
+		if cmdTag.RowsAffected() == 0 {
+			return ErrUserInOtherTeam
+		}
+	}
+
+	return nil
+}
+
+// RemoveMembers исключает пользователей из команды, оставляя их без команды.
+// Пользователи, не состоящие в этой команде, не затрагиваются.
+// Если команда не найдена, возвращает ErrTeamNotFound.
+func (r *TeamRepo) RemoveMembers(ctx context.Context, teamName string, userIDs []string) error {
+	q := r.db.GetQueryExecutor(ctx)
+
+	teamID, err := r.teamIDByName(ctx, q, teamName)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, `
+UPDATE users
+SET team_id = NULL
+WHERE user_id = ANY($1) AND team_id = $2
+`, userIDs, teamID)
+	if err != nil {
+		return fmt.Errorf("remove members: %w", err)
+	}
+	return nil
+}
+
+// MoveMembers переводит пользователей в указанную команду.
+// Если команда не найдена, возвращает ErrTeamNotFound, если архивна — ErrTeamArchived.
+func (r *TeamRepo) MoveMembers(ctx context.Context, teamName string, userIDs []string) error {
+	q := r.db.GetQueryExecutor(ctx)
+
+	teamID, err := r.activeTeamIDByName(ctx, q, teamName)
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, `
+UPDATE users
+SET team_id = $2
+WHERE user_id = ANY($1)
+`, userIDs, teamID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrUsernameTaken
+		}
+		return fmt.Errorf("move members: %w", err)
+	}
+	return nil
+}
+
+// ArchiveTeam помечает команду архивной. Повторная архивация не меняет исходную дату.
+// Если команда не найдена, возвращает ErrTeamNotFound.
+func (r *TeamRepo) ArchiveTeam(ctx context.Context, teamName string, archivedAt time.Time) error {
+	q := r.db.GetQueryExecutor(ctx)
+	cmdTag, err := q.Exec(ctx, `
+UPDATE teams
+SET archived_at = COALESCE(archived_at, $2)
+WHERE team_name = $1
+`, teamName, archivedAt)
+	if err != nil {
+		return fmt.Errorf("archive team: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return ErrTeamNotFound
+	}
+	return nil
+}
+
+// DeleteTeam удаляет команду без участников и без истории. Если на команду ссылается хотя бы один
+// пользователь или подкоманда, возвращает ErrTeamNotEmpty; если бывшие участники писали или ревьюили PR,
+// пока состояли в команде (по журналу team_memberships), — ErrTeamHasHistory; если команда не найдена — ErrTeamNotFound.
+func (r *TeamRepo) DeleteTeam(ctx context.Context, teamName string) error {
+	q := r.db.GetQueryExecutor(ctx)
+
+	teamID, err := r.teamIDByName(ctx, q, teamName)
+	if err != nil {
+		return err
+	}
+
+	var hasMembers bool
+	if err := q.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE team_id = $1)`, teamID).Scan(&hasMembers); err != nil {
+		return fmt.Errorf("check team members: %w", err)
+	}
+	if hasMembers {
+		return ErrTeamNotEmpty
+	}
+	hasHistory, err := r.hasHistory(ctx, q, teamID)
+	if err != nil {
+		return err
+	}
+	if hasHistory {
+		return ErrTeamHasHistory
+	}
+
+	cmdTag, err := q.Exec(ctx, `DELETE FROM teams WHERE id = $1`, teamID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			// на команду ссылаются подкоманды или успевшие вступить участники (ON DELETE RESTRICT)
+			return ErrTeamNotEmpty
+		}
+		return fmt.Errorf("delete team: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return ErrTeamNotFound
+	}
+	return nil
+}
+
+// HasHistory сообщает, писали или ревьюили ли PR нынешние или бывшие участники команды,
+// пока состояли в ней (по журналу team_memberships). Если команда не найдена, возвращает ErrTeamNotFound.
+func (r *TeamRepo) HasHistory(ctx context.Context, teamName string) (bool, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	teamID, err := r.teamIDByName(ctx, q, teamName)
+	if err != nil {
+		return false, err
+	}
+	return r.hasHistory(ctx, q, teamID)
+}
+
+func (r *TeamRepo) hasHistory(ctx context.Context, q DBTX, teamID int64) (bool, error) {
+	var exists bool
+	if err := q.QueryRow(ctx, `
+SELECT EXISTS (
+    SELECT 1
+    FROM team_memberships m
+    WHERE m.team_id = $1
+      AND (EXISTS (SELECT 1 FROM pull_requests p
+                   WHERE p.author_id = m.user_id
+                     AND p.created_at >= m.joined_at AND p.created_at < COALESCE(m.left_at, 'infinity'))
+           OR EXISTS (SELECT 1 FROM pull_request_reviewers r
+                      WHERE r.reviewer_id = m.user_id
+                        AND r.assigned_at >= m.joined_at AND r.assigned_at < COALESCE(m.left_at, 'infinity'))
+           OR EXISTS (SELECT 1 FROM reviewer_reassignments ra
+                      WHERE ra.reviewer_id = m.user_id
+                        AND ra.assigned_at >= m.joined_at AND ra.assigned_at < COALESCE(m.left_at, 'infinity')))
+)
+`, teamID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("check team history: %w", err)
+	}
+	return exists, nil
+}
+
+// teamIDByName возвращает идентификатор команды по имени или ErrTeamNotFound.
+func (r *TeamRepo) teamIDByName(ctx context.Context, q DBTX, name string) (int64, error) {
+	teamID, _, err := r.teamByName(ctx, q, name)
+	return teamID, err
+}
+
+// activeTeamIDByName как teamIDByName, но для архивной команды возвращает ErrTeamArchived.
+func (r *TeamRepo) activeTeamIDByName(ctx context.Context, q DBTX, name string) (int64, error) {
+	teamID, archived, err := r.teamByName(ctx, q, name)
+	if err != nil {
+		return 0, err
+	}
+	if archived {
+		return 0, ErrTeamArchived
+	}
+	return teamID, nil
+}
+
+// teamByName возвращает идентификатор команды и признак архивности или ErrTeamNotFound.
+func (r *TeamRepo) teamByName(ctx context.Context, q DBTX, name string) (int64, bool, error) {
+	var teamID int64
+	var archived bool
+	err := q.QueryRow(ctx, `SELECT id, archived_at IS NOT NULL FROM teams WHERE team_name = $1`, name).Scan(&teamID, &archived)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, ErrTeamNotFound
+		}
+		return 0, false, fmt.Errorf("get team id: %w", err)
+	}
+	return teamID, archived, nil
+}
+
+// SetNotificationChannel задаёт URL incoming webhook чата команды (пустой — уведомления выключены).
+// Если команда не найдена, возвращает ErrTeamNotFound.
+func (r *TeamRepo) SetNotificationChannel(ctx context.Context, teamName, webhookURL string) error {
+	q := r.db.GetQueryExecutor(ctx)
+	cmdTag, err := q.Exec(ctx, `UPDATE teams SET chat_webhook_url = $2 WHERE team_name = $1`, teamName, webhookURL)
+	if err != nil {
+		return fmt.Errorf("set notification channel: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return ErrTeamNotFound
+	}
+	return nil
+}
+
+// SetLead назначает руководителя команды (пустой userID снимает его). Руководитель получает
+// сводку по зависшим PR команды и не обязан в ней состоять.
+// Возвращает ErrTeamNotFound или ErrUserNotFound, если команда или пользователь не найдены.
+func (r *TeamRepo) SetLead(ctx context.Context, teamName, userID string) error {
+	q := r.db.GetQueryExecutor(ctx)
+	cmdTag, err := q.Exec(ctx, `UPDATE teams SET lead_user_id = NULLIF($2, '') WHERE team_name = $1`, teamName, userID)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return ErrUserNotFound
+		}
+		return fmt.Errorf("set team lead: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return ErrTeamNotFound
+	}
+	return nil
+}
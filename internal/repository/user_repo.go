@@ -4,10 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"pull-request-service/internal/model"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 // UserRepo реализует репозиторий пользователей на базе PostgreSQL.
@@ -21,12 +24,14 @@ func NewUserRepo(db *Postgres) *UserRepo {
 }
 
 // GetByUserID возвращает пользователя по user_id вместе с именем его команды.
+// Для пользователя вне команды TeamName будет пустым.
 // Если пользователь не найден, возвращает ErrUserNotFound.
 func (r *UserRepo) GetByUserID(ctx context.Context, userID string) (model.User, error) {
-	row := r.db.Pool.QueryRow(ctx, `
-SELECT u.user_id, u.username, t.team_name, u.is_active
+	q := r.db.GetQueryExecutor(ctx)
+	row := q.QueryRow(ctx, `
+SELECT u.user_id, u.username, COALESCE(t.team_name, ''), u.is_active
 FROM users u
-JOIN teams t ON u.team_id = t.id
+LEFT JOIN teams t ON u.team_id = t.id
 WHERE u.user_id = $1
 `, userID)
 
@@ -43,12 +48,14 @@ WHERE u.user_id = $1
 // SetIsActive обновляет флаг активности пользователя и возвращает обновлённого пользователя
 // вместе с именем его команды. Если пользователь не найден, возвращает ErrUserNotFound.
 func (r *UserRepo) SetIsActive(ctx context.Context, userID string, isActive bool) (model.User, error) {
-	row := r.db.Pool.QueryRow(ctx, `
+	q := r.db.GetQueryExecutor(ctx)
+	row := q.QueryRow(ctx, `
 UPDATE users u
 SET is_active = $2
-FROM teams t
-WHERE u.user_id = $1 AND u.team_id = t.id
-RETURNING u.user_id, u.username, t.team_name, u.is_active
+WHERE u.user_id = $1
+RETURNING u.user_id, u.username,
+          COALESCE((SELECT t.team_name FROM teams t WHERE t.id = u.team_id), ''),
+          u.is_active
 `, userID, isActive)
 
 	var u model.User
@@ -64,7 +71,8 @@ RETURNING u.user_id, u.username, t.team_name, u.is_active
 // ListActiveTeamMembersExcept возвращает список активных участников команды по её имени,
 // исключая переданные user_id (exclude). Используется для выбора кандидатов в ревьюверы.
 func (r *UserRepo) ListActiveTeamMembersExcept(ctx context.Context, teamName string, exclude []string) ([]model.User, error) {
-	rows, err := r.db.Pool.Query(ctx, `
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
 SELECT u.user_id, u.username, t.team_name, u.is_active
 FROM users u
 JOIN teams t ON u.team_id = t.id
@@ -99,6 +107,305 @@ ORDER BY u.user_id
 	return users, nil
 }
 
+// ListActiveAncestorMembersExcept возвращает активных участников команд-предков teamName,
+// исключая переданные user_id. Участники упорядочены от ближайшего предка к корню,
+// а TeamName у каждого — имя команды-предка, в которой он состоит.
+func (r *UserRepo) ListActiveAncestorMembersExcept(ctx context.Context, teamName string, exclude []string) ([]model.User, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	if exclude == nil {
+		exclude = []string{}
+	}
+	rows, err := q.Query(ctx, `
+WITH RECURSIVE ancestors AS (
+    SELECT p.id, p.team_name, p.parent_team_id, 1 AS depth
+    FROM teams t
+    JOIN teams p ON p.id = t.parent_team_id
+    WHERE t.team_name = $1
+    UNION ALL
+    SELECT p.id, p.team_name, p.parent_team_id, a.depth + 1
+    FROM ancestors a
+    JOIN teams p ON p.id = a.parent_team_id
+)
+SELECT u.user_id, u.username, a.team_name, u.is_active
+FROM ancestors a
+JOIN users u ON u.team_id = a.id
+WHERE u.is_active = TRUE AND NOT (u.user_id = ANY($2))
+ORDER BY a.depth, u.user_id
+`, teamName, exclude)
+	if err != nil {
+		return nil, fmt.Errorf("query ancestor members: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]model.User, 0)
+	for rows.Next() {
+		var u model.User
+		if err := rows.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return users, nil
+}
+
+// ListRampUp возвращает пользователей из userIDs, которые были активированы не раньше since
+// и с тех пор не деактивировались, вместе с числом ревью, назначенных им после активации.
+func (r *UserRepo) ListRampUp(ctx context.Context, userIDs []string, since time.Time) ([]model.UserRampUp, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT e.user_id, e.changed_at, COUNT(r.pull_request_id)
+FROM (
+    SELECT DISTINCT ON (user_id) user_id, is_active, changed_at
+    FROM user_activity_events
+    WHERE user_id = ANY($1)
+    ORDER BY user_id, changed_at DESC, id DESC
+) e
+LEFT JOIN pull_request_reviewers r ON r.reviewer_id = e.user_id AND r.assigned_at >= e.changed_at
+WHERE e.is_active AND e.changed_at >= $2
+GROUP BY e.user_id, e.changed_at
+`, userIDs, since)
+	if err != nil {
+		return nil, fmt.Errorf("query ramp-up: %w", err)
+	}
+	defer rows.Close()
+
+	states := make([]model.UserRampUp, 0)
+	for rows.Next() {
+		var st model.UserRampUp
+		if err := rows.Scan(&st.UserID, &st.ActiveSince, &st.AssignedSince); err != nil {
+			return nil, fmt.Errorf("scan ramp-up: %w", err)
+		}
+		states = append(states, st)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return states, nil
+}
+
+// ListActivityEvents возвращает историю активаций и деактиваций пользователя, новые события первыми.
+// Если пользователь не найден, возвращает ErrUserNotFound.
+func (r *UserRepo) ListActivityEvents(ctx context.Context, userID string) ([]model.UserActivityEvent, error) {
+	q := r.db.GetQueryExecutor(ctx)
+
+	var exists bool
+	if err := q.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM users WHERE user_id = $1)`, userID).Scan(&exists); err != nil {
+		return nil, fmt.Errorf("check user: %w", err)
+	}
+	if !exists {
+		return nil, ErrUserNotFound
+	}
+
+	rows, err := q.Query(ctx, `
+SELECT user_id, is_active, changed_at
+FROM user_activity_events
+WHERE user_id = $1
+ORDER BY changed_at DESC, id DESC
+`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query activity events: %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]model.UserActivityEvent, 0)
+	for rows.Next() {
+		var e model.UserActivityEvent
+		if err := rows.Scan(&e.UserID, &e.IsActive, &e.ChangedAt); err != nil {
+			return nil, fmt.Errorf("scan activity event: %w", err)
+		}
+		events = append(events, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return events, nil
+}
+
+// ListActiveUserIDs возвращает идентификаторы всех активных пользователей в порядке user_id.
+func (r *UserRepo) ListActiveUserIDs(ctx context.Context) ([]string, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `SELECT user_id FROM users WHERE is_active = TRUE ORDER BY user_id`)
+	if err != nil {
+		return nil, fmt.Errorf("query active users: %w", err)
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan user id: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return ids, nil
+}
+
+// GetByUserIDs возвращает найденных пользователей из переданного списка user_id.
+// Отсутствующие в БД идентификаторы просто пропускаются.
+func (r *UserRepo) GetByUserIDs(ctx context.Context, userIDs []string) ([]model.User, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT u.user_id, u.username, COALESCE(t.team_name, ''), u.is_active
+FROM users u
+LEFT JOIN teams t ON u.team_id = t.id
+WHERE u.user_id = ANY($1)
+ORDER BY u.user_id
+`, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("query users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]model.User, 0, len(userIDs))
+	for rows.Next() {
+		var u model.User
+		if err := rows.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return users, nil
+}
+
+// ListUsers возвращает страницу пользователей, упорядоченных по user_id, с учётом фильтров.
+// Поиск по Username и UsernamePrefix регистронезависимый и использует индекс по lower(username).
+func (r *UserRepo) ListUsers(ctx context.Context, filter model.UserFilter) ([]model.User, error) {
+	q := r.db.GetQueryExecutor(ctx)
+
+	where, args := userFilterWhere(filter)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := q.Query(ctx, fmt.Sprintf(`
+SELECT u.user_id, u.username, COALESCE(t.team_name, ''), u.is_active
+FROM users u
+LEFT JOIN teams t ON u.team_id = t.id
+%s
+ORDER BY u.user_id
+LIMIT $%d OFFSET $%d
+`, where, len(args)-1, len(args)), args...)
+	if err != nil {
+		return nil, fmt.Errorf("query users: %w", err)
+	}
+	defer rows.Close()
+
+	users := make([]model.User, 0)
+	for rows.Next() {
+		var u model.User
+		if err := rows.Scan(&u.UserID, &u.Username, &u.TeamName, &u.IsActive); err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return users, nil
+}
+
+// CountUsers возвращает число пользователей, подходящих под фильтры (After, Offset и Limit не учитываются).
+func (r *UserRepo) CountUsers(ctx context.Context, filter model.UserFilter) (int, error) {
+	q := r.db.GetQueryExecutor(ctx)
+
+	filter.After = ""
+	where, args := userFilterWhere(filter)
+
+	var total int
+	err := q.QueryRow(ctx, fmt.Sprintf(`
+SELECT count(*)
+FROM users u
+LEFT JOIN teams t ON u.team_id = t.id
+%s
+`, where), args...).Scan(&total)
+	if err != nil {
+		return 0, fmt.Errorf("count users: %w", err)
+	}
+	return total, nil
+}
+
+// userFilterWhere строит условие WHERE и его аргументы для выборок пользователей.
+func userFilterWhere(filter model.UserFilter) (string, []any) {
+	conds := make([]string, 0, 6)
+	args := make([]any, 0, 8)
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		conds = append(conds, fmt.Sprintf("u.user_id = $%d", len(args)))
+	}
+	if filter.TeamName != "" {
+		args = append(args, filter.TeamName)
+		conds = append(conds, fmt.Sprintf("t.team_name = $%d", len(args)))
+	}
+	if filter.IsActive != nil {
+		args = append(args, *filter.IsActive)
+		conds = append(conds, fmt.Sprintf("u.is_active = $%d", len(args)))
+	}
+	if filter.Username != "" {
+		args = append(args, strings.ToLower(filter.Username))
+		conds = append(conds, fmt.Sprintf("lower(u.username) = $%d", len(args)))
+	}
+	if filter.UsernamePrefix != "" {
+		args = append(args, escapeLike(strings.ToLower(filter.UsernamePrefix))+"%")
+		conds = append(conds, fmt.Sprintf("lower(u.username) LIKE $%d", len(args)))
+	}
+	if filter.After != "" {
+		args = append(args, filter.After)
+		conds = append(conds, fmt.Sprintf("u.user_id > $%d", len(args)))
+	}
+	if len(conds) == 0 {
+		return "", args
+	}
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// CreateUser создаёт пользователя вне команды. Если user_id уже занят, возвращает ErrUserExists.
+func (r *UserRepo) CreateUser(ctx context.Context, u model.User) error {
+	q := r.db.GetQueryExecutor(ctx)
+	_, err := q.Exec(ctx, `
+INSERT INTO users (user_id, username, team_id, is_active)
+VALUES ($1, $2, NULL, $3)
+`, u.UserID, u.Username, u.IsActive)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrUserExists
+		}
+		return fmt.Errorf("insert user: %w", err)
+	}
+	return nil
+}
+
+// SetUsername меняет username пользователя. Если пользователь не найден, возвращает ErrUserNotFound,
+// если в его команде username уже занят — ErrUsernameTaken.
+func (r *UserRepo) SetUsername(ctx context.Context, userID, username string) error {
+	q := r.db.GetQueryExecutor(ctx)
+	cmdTag, err := q.Exec(ctx, `UPDATE users SET username = $2 WHERE user_id = $1`, userID, username)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return ErrUsernameTaken
+		}
+		return fmt.Errorf("update username: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// escapeLike экранирует спецсимволы LIKE, чтобы пользовательский ввод искался буквально.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
 // DeactivateUsers массово деактивирует пользователей по списку ID.
 func (r *UserRepo) DeactivateUsers(ctx context.Context, userIDs []string) error {
 	q := r.db.GetQueryExecutor(ctx)
@@ -112,3 +419,92 @@ func (r *UserRepo) DeactivateUsers(ctx context.Context, userIDs []string) error
 	}
 	return nil
 }
+
+// SetChatHandle задаёт упоминание пользователя в чате (пустое — упоминать по username).
+// Если пользователь не найден, возвращает ErrUserNotFound.
+func (r *UserRepo) SetChatHandle(ctx context.Context, userID, handle string) error {
+	q := r.db.GetQueryExecutor(ctx)
+	cmdTag, err := q.Exec(ctx, `UPDATE users SET chat_handle = $2 WHERE user_id = $1`, userID, handle)
+	if err != nil {
+		return fmt.Errorf("set chat handle: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// SetEmailPreferences задаёт адрес и режим почтовых уведомлений пользователя. При смене режима
+// отсчёт до следующей сводки начинается заново, чтобы первая сводка не пришла сразу.
+// Если пользователь не найден, возвращает ErrUserNotFound.
+func (r *UserRepo) SetEmailPreferences(ctx context.Context, userID, email string, mode model.EmailMode) error {
+	q := r.db.GetQueryExecutor(ctx)
+	cmdTag, err := q.Exec(ctx, `
+UPDATE users
+SET email = $2,
+    email_mode = $3,
+    email_digest_at = CASE WHEN email_mode = $3 THEN email_digest_at ELSE now() END
+WHERE user_id = $1
+`, userID, email, string(mode))
+	if err != nil {
+		return fmt.Errorf("set email preferences: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// SetWorkingHours задаёт рабочие часы пользователя; nil снимает расписание.
+// Если пользователь не найден, возвращает ErrUserNotFound.
+func (r *UserRepo) SetWorkingHours(ctx context.Context, userID string, hours *model.WorkingHours) error {
+	q := r.db.GetQueryExecutor(ctx)
+
+	var (
+		cmdTag pgconn.CommandTag
+		err    error
+	)
+	if hours == nil {
+		cmdTag, err = q.Exec(ctx, `
+UPDATE users SET work_timezone = 'UTC', work_start_hour = NULL, work_end_hour = NULL WHERE user_id = $1
+`, userID)
+	} else {
+		cmdTag, err = q.Exec(ctx, `
+UPDATE users SET work_timezone = $2, work_start_hour = $3, work_end_hour = $4 WHERE user_id = $1
+`, userID, hours.TimeZone, hours.StartHour, hours.EndHour)
+	}
+	if err != nil {
+		return fmt.Errorf("set working hours: %w", err)
+	}
+	if cmdTag.RowsAffected() == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// ListWorkingHours возвращает рабочие часы тех пользователей из userIDs, у которых они заданы.
+func (r *UserRepo) ListWorkingHours(ctx context.Context, userIDs []string) ([]model.WorkingHours, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT user_id, work_timezone, work_start_hour, work_end_hour
+FROM users
+WHERE user_id = ANY($1) AND work_start_hour IS NOT NULL
+`, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list working hours: %w", err)
+	}
+	defer rows.Close()
+
+	result := make([]model.WorkingHours, 0)
+	for rows.Next() {
+		var h model.WorkingHours
+		if err := rows.Scan(&h.UserID, &h.TimeZone, &h.StartHour, &h.EndHour); err != nil {
+			return nil, fmt.Errorf("scan working hours: %w", err)
+		}
+		result = append(result, h)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,242 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"pull-request-service/internal/model"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// WebhookRepo хранит подписки на исходящие вебхуки и очередь их доставок в PostgreSQL.
+type WebhookRepo struct {
+	db *Postgres
+}
+
+// NewWebhookRepo создаёт новый экземпляр WebhookRepo c переданным подключением к PostgreSQL.
+func NewWebhookRepo(db *Postgres) *WebhookRepo {
+	return &WebhookRepo{db: db}
+}
+
+// CreateSubscription сохраняет подписку и возвращает её с присвоенными id и created_at.
+func (r *WebhookRepo) CreateSubscription(ctx context.Context, sub model.WebhookSubscription) (model.WebhookSubscription, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	row := q.QueryRow(ctx, `
+INSERT INTO webhook_subscriptions (url, secret, event_types)
+VALUES ($1, $2, $3)
+RETURNING id, created_at
+`, sub.URL, sub.Secret, eventTypeStrings(sub.EventTypes))
+
+	if err := row.Scan(&sub.ID, &sub.CreatedAt); err != nil {
+		return model.WebhookSubscription{}, fmt.Errorf("insert webhook subscription: %w", err)
+	}
+	return sub, nil
+}
+
+// ListSubscriptions возвращает все подписки в порядке создания. Секреты не выбираются.
+func (r *WebhookRepo) ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT id, url, event_types, created_at
+FROM webhook_subscriptions
+ORDER BY id
+`)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook subscriptions: %w", err)
+	}
+	defer rows.Close()
+
+	subs := make([]model.WebhookSubscription, 0)
+	for rows.Next() {
+		var (
+			sub   model.WebhookSubscription
+			types []string
+		)
+		if err := rows.Scan(&sub.ID, &sub.URL, &types, &sub.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan webhook subscription: %w", err)
+		}
+		sub.EventTypes = make([]model.EventType, 0, len(types))
+		for _, t := range types {
+			sub.EventTypes = append(sub.EventTypes, model.EventType(t))
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+// DeleteSubscription удаляет подписку вместе с её недоставленными событиями.
+// Если подписка не найдена, возвращает ErrSubscriptionNotFound.
+func (r *WebhookRepo) DeleteSubscription(ctx context.Context, id int64) error {
+	q := r.db.GetQueryExecutor(ctx)
+	tag, err := q.Exec(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("delete webhook subscription: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSubscriptionNotFound
+	}
+	return nil
+}
+
+// EnqueueDeliveries ставит события в очередь доставки каждой подписке, которой они интересны.
+// Вызывается relay outbox внутри транзакции, помечающей события опубликованными.
+func (r *WebhookRepo) EnqueueDeliveries(ctx context.Context, events []model.Event) error {
+	q := r.db.GetQueryExecutor(ctx)
+	for _, ev := range events {
+		payload, err := json.Marshal(ev)
+		if err != nil {
+			return fmt.Errorf("marshal event: %w", err)
+		}
+		if _, err := q.Exec(ctx, `
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+SELECT id, $1, $2, $3
+FROM webhook_subscriptions
+WHERE cardinality(event_types) = 0 OR $2 = ANY(event_types)
+`, ev.ID, string(ev.Type), payload); err != nil {
+			return fmt.Errorf("enqueue webhook deliveries: %w", err)
+		}
+	}
+	return nil
+}
+
+// ClaimDeliveries выбирает до limit доставок, время попытки которых наступило, и откладывает
+// их на lease, чтобы другие экземпляры сервиса не взяли их в работу одновременно.
+// Если результат попытки не будет записан за lease, доставка снова станет доступной.
+func (r *WebhookRepo) ClaimDeliveries(ctx context.Context, limit int, lease time.Duration) ([]model.WebhookDelivery, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+UPDATE webhook_deliveries d
+SET next_attempt_at = now() + make_interval(secs => $2)
+FROM webhook_subscriptions s
+WHERE s.id = d.subscription_id
+  AND d.id IN (
+      SELECT id
+      FROM webhook_deliveries
+      WHERE next_attempt_at <= now()
+      ORDER BY next_attempt_at, id
+      LIMIT $1
+      FOR UPDATE SKIP LOCKED
+  )
+RETURNING d.id, d.subscription_id, s.url, s.secret, d.event_id, d.event_type, d.payload::text, d.attempts
+`, limit, lease.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := make([]model.WebhookDelivery, 0)
+	for rows.Next() {
+		var (
+			d         model.WebhookDelivery
+			eventType string
+			payload   string
+		)
+		if err := rows.Scan(&d.ID, &d.SubscriptionID, &d.URL, &d.Secret, &d.EventID, &eventType, &payload, &d.Attempts); err != nil {
+			return nil, fmt.Errorf("scan webhook delivery: %w", err)
+		}
+		d.EventType = model.EventType(eventType)
+		d.Payload = []byte(payload)
+		deliveries = append(deliveries, d)
+	}
+	return deliveries, rows.Err()
+}
+
+// CompleteDelivery удаляет успешно доставленное событие из очереди.
+func (r *WebhookRepo) CompleteDelivery(ctx context.Context, id int64) error {
+	if err := deliveryQueue.complete(ctx, r.db.GetQueryExecutor(ctx), id); err != nil {
+		return fmt.Errorf("complete webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// RetryDelivery засчитывает неудачную попытку и назначает следующую на nextAttemptAt.
+func (r *WebhookRepo) RetryDelivery(ctx context.Context, id int64, nextAttemptAt time.Time, lastErr string) error {
+	if err := deliveryQueue.retry(ctx, r.db.GetQueryExecutor(ctx), id, nextAttemptAt, lastErr); err != nil {
+		return fmt.Errorf("retry webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// DeadLetterDelivery засчитывает последнюю неудачную попытку и переносит доставку
+// из очереди в webhook_dead_letters.
+func (r *WebhookRepo) DeadLetterDelivery(ctx context.Context, id int64, lastErr string) error {
+	q := r.db.GetQueryExecutor(ctx)
+	if _, err := q.Exec(ctx, `
+WITH moved AS (
+    DELETE FROM webhook_deliveries WHERE id = $1
+    RETURNING subscription_id, event_id, event_type, payload, attempts, created_at
+)
+INSERT INTO webhook_dead_letters (subscription_id, event_id, event_type, payload, attempts, last_error, created_at)
+SELECT subscription_id, event_id, event_type, payload, attempts + 1, $2, created_at
+FROM moved
+`, id, lastErr); err != nil {
+		return fmt.Errorf("dead-letter webhook delivery: %w", err)
+	}
+	return nil
+}
+
+// ListDeadLetters возвращает до limit недоставленных событий, начиная с последних.
+func (r *WebhookRepo) ListDeadLetters(ctx context.Context, limit int) ([]model.WebhookDeadLetter, error) {
+	q := r.db.GetQueryExecutor(ctx)
+	rows, err := q.Query(ctx, `
+SELECT id, subscription_id, event_id, event_type, attempts, last_error, failed_at
+FROM webhook_dead_letters
+ORDER BY id DESC
+LIMIT $1
+`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("list dead letters: %w", err)
+	}
+	defer rows.Close()
+
+	letters := make([]model.WebhookDeadLetter, 0)
+	for rows.Next() {
+		var (
+			l         model.WebhookDeadLetter
+			eventType string
+		)
+		if err := rows.Scan(&l.ID, &l.SubscriptionID, &l.EventID, &eventType, &l.Attempts, &l.LastError, &l.FailedAt); err != nil {
+			return nil, fmt.Errorf("scan dead letter: %w", err)
+		}
+		l.EventType = model.EventType(eventType)
+		letters = append(letters, l)
+	}
+	return letters, rows.Err()
+}
+
+// RedeliverDeadLetter возвращает недоставленное событие в очередь с обнулённым счётчиком попыток.
+// Если событие не найдено, возвращает ErrDeadLetterNotFound.
+func (r *WebhookRepo) RedeliverDeadLetter(ctx context.Context, id int64) error {
+	q := r.db.GetQueryExecutor(ctx)
+	row := q.QueryRow(ctx, `
+WITH moved AS (
+    DELETE FROM webhook_dead_letters WHERE id = $1
+    RETURNING subscription_id, event_id, event_type, payload
+)
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+SELECT subscription_id, event_id, event_type, payload
+FROM moved
+RETURNING id
+`, id)
+
+	var deliveryID int64
+	if err := row.Scan(&deliveryID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrDeadLetterNotFound
+		}
+		return fmt.Errorf("redeliver dead letter: %w", err)
+	}
+	return nil
+}
+
+func eventTypeStrings(types []model.EventType) []string {
+	out := make([]string, 0, len(types))
+	for _, t := range types {
+		out = append(out, string(t))
+	}
+	return out
+}
@@ -0,0 +1,253 @@
+// Package roster читает и записывает состав команд в форматах CSV, YAML и JSON
+// для массового импорта и экспорта.
+package roster
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"pull-request-service/internal/model"
+)
+
+// Format — формат файла состава.
+type Format string
+
+// Поддерживаемые форматы.
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatCSV  Format = "csv"
+)
+
+// csvHeader — колонки CSV-файла: по строке на участника. Строка с пустым user_id
+// объявляет команду без собственных участников (например, отдел).
+var csvHeader = []string{"team_name", "parent_team_name", "user_id", "username", "is_active"}
+
+// ParseFormat разбирает имя формата (json, yaml/yml, csv) без учёта регистра.
+func ParseFormat(s string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "json":
+		return FormatJSON, nil
+	case "yaml", "yml":
+		return FormatYAML, nil
+	case "csv":
+		return FormatCSV, nil
+	}
+	return "", fmt.Errorf("unsupported roster format %q: use csv, yaml or json", s)
+}
+
+// FormatFromContentType определяет формат по заголовку Content-Type.
+// Для неизвестного или пустого типа возвращает JSON.
+func FormatFromContentType(contentType string) Format {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return FormatJSON
+	}
+	switch mediaType {
+	case "text/csv":
+		return FormatCSV
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return FormatYAML
+	}
+	return FormatJSON
+}
+
+// ContentType возвращает MIME-тип формата.
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatYAML:
+		return "application/yaml"
+	}
+	return "application/json"
+}
+
+// document — представление файла состава для JSON и YAML.
+type document struct {
+	Teams []teamDoc `json:"teams" yaml:"teams"`
+}
+
+type teamDoc struct {
+	TeamName       string      `json:"team_name" yaml:"team_name"`
+	ParentTeamName string      `json:"parent_team_name,omitempty" yaml:"parent_team_name,omitempty"`
+	Members        []memberDoc `json:"members" yaml:"members"`
+}
+
+type memberDoc struct {
+	UserID   string `json:"user_id" yaml:"user_id"`
+	Username string `json:"username" yaml:"username"`
+	IsActive bool   `json:"is_active" yaml:"is_active"`
+}
+
+// Decode читает состав команд из r в указанном формате.
+func Decode(r io.Reader, f Format) (model.Roster, error) {
+	switch f {
+	case FormatCSV:
+		return decodeCSV(r)
+	case FormatYAML:
+		var doc document
+		if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				return model.Roster{}, errors.New("roster file is empty")
+			}
+			return model.Roster{}, fmt.Errorf("invalid YAML: %w", err)
+		}
+		return doc.toModel(), nil
+	case FormatJSON:
+		var doc document
+		if err := json.NewDecoder(r).Decode(&doc); err != nil {
+			return model.Roster{}, fmt.Errorf("invalid JSON: %w", err)
+		}
+		return doc.toModel(), nil
+	}
+	return model.Roster{}, fmt.Errorf("unsupported roster format %q", f)
+}
+
+// Encode записывает состав команд в w в указанном формате.
+func Encode(w io.Writer, f Format, roster model.Roster) error {
+	switch f {
+	case FormatCSV:
+		return encodeCSV(w, roster)
+	case FormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2)
+		if err := enc.Encode(fromModel(roster)); err != nil {
+			return err
+		}
+		return enc.Close()
+	case FormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(fromModel(roster))
+	}
+	return fmt.Errorf("unsupported roster format %q", f)
+}
+
+func (d document) toModel() model.Roster {
+	roster := model.Roster{Teams: make([]model.Team, 0, len(d.Teams))}
+	for _, t := range d.Teams {
+		team := model.Team{
+			TeamName:       strings.TrimSpace(t.TeamName),
+			ParentTeamName: strings.TrimSpace(t.ParentTeamName),
+			Members:        make([]model.TeamMember, 0, len(t.Members)),
+		}
+		for _, m := range t.Members {
+			team.Members = append(team.Members, model.TeamMember{
+				UserID:   strings.TrimSpace(m.UserID),
+				Username: strings.TrimSpace(m.Username),
+				IsActive: m.IsActive,
+			})
+		}
+		roster.Teams = append(roster.Teams, team)
+	}
+	return roster
+}
+
+func fromModel(roster model.Roster) document {
+	doc := document{Teams: make([]teamDoc, 0, len(roster.Teams))}
+	for _, t := range roster.Teams {
+		team := teamDoc{
+			TeamName:       t.TeamName,
+			ParentTeamName: t.ParentTeamName,
+			Members:        make([]memberDoc, 0, len(t.Members)),
+		}
+		for _, m := range t.Members {
+			team.Members = append(team.Members, memberDoc(m))
+		}
+		doc.Teams = append(doc.Teams, team)
+	}
+	return doc
+}
+
+func decodeCSV(r io.Reader) (model.Roster, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return model.Roster{}, errors.New("roster file is empty")
+		}
+		return model.Roster{}, fmt.Errorf("invalid CSV header: %w", err)
+	}
+	for i, col := range csvHeader {
+		if strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff")) != col {
+			return model.Roster{}, fmt.Errorf("invalid CSV header: expected %s", strings.Join(csvHeader, ","))
+		}
+	}
+
+	roster := model.Roster{Teams: make([]model.Team, 0)}
+	index := make(map[string]int)
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return model.Roster{}, fmt.Errorf("invalid CSV: %w", err)
+		}
+
+		teamName := strings.TrimSpace(rec[0])
+		parentName := strings.TrimSpace(rec[1])
+		i, ok := index[teamName]
+		if !ok {
+			i = len(roster.Teams)
+			index[teamName] = i
+			roster.Teams = append(roster.Teams, model.Team{
+				TeamName:       teamName,
+				ParentTeamName: parentName,
+				Members:        make([]model.TeamMember, 0),
+			})
+		} else if roster.Teams[i].ParentTeamName != parentName {
+			return model.Roster{}, fmt.Errorf("line %d: team %s has conflicting parent_team_name", line, teamName)
+		}
+
+		userID := strings.TrimSpace(rec[2])
+		if userID == "" {
+			continue
+		}
+		isActive, err := strconv.ParseBool(strings.TrimSpace(rec[4]))
+		if err != nil {
+			return model.Roster{}, fmt.Errorf("line %d: is_active must be true or false", line)
+		}
+		roster.Teams[i].Members = append(roster.Teams[i].Members, model.TeamMember{
+			UserID:   userID,
+			Username: strings.TrimSpace(rec[3]),
+			IsActive: isActive,
+		})
+	}
+	return roster, nil
+}
+
+func encodeCSV(w io.Writer, roster model.Roster) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, t := range roster.Teams {
+		if len(t.Members) == 0 {
+			if err := cw.Write([]string{t.TeamName, t.ParentTeamName, "", "", ""}); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, m := range t.Members {
+			rec := []string{t.TeamName, t.ParentTeamName, m.UserID, m.Username, strconv.FormatBool(m.IsActive)}
+			if err := cw.Write(rec); err != nil {
+				return err
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
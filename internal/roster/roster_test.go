@@ -0,0 +1,88 @@
+package roster_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/roster"
+)
+
+func TestDecode_CSV(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    model.Roster
+		wantErr bool
+	}{
+		{
+			name: "Success: Department row without members",
+			input: "team_name,parent_team_name,user_id,username,is_active\n" +
+				"engineering,,,,\n" +
+				"backend,engineering,u1,Alice,true\n" +
+				"backend,engineering,u2,Bob,false\n",
+			want: model.Roster{Teams: []model.Team{
+				{TeamName: "engineering", Members: []model.TeamMember{}},
+				{TeamName: "backend", ParentTeamName: "engineering", Members: []model.TeamMember{
+					{UserID: "u1", Username: "Alice", IsActive: true},
+					{UserID: "u2", Username: "Bob", IsActive: false},
+				}},
+			}},
+		},
+		{
+			name: "Fail: Conflicting parent",
+			input: "team_name,parent_team_name,user_id,username,is_active\n" +
+				"backend,engineering,u1,Alice,true\n" +
+				"backend,platform,u2,Bob,true\n",
+			wantErr: true,
+		},
+		{
+			name:    "Fail: Wrong header",
+			input:   "team,user\nbackend,u1\n",
+			wantErr: true,
+		},
+		{
+			name: "Fail: Bad is_active",
+			input: "team_name,parent_team_name,user_id,username,is_active\n" +
+				"backend,,u1,Alice,yes\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := roster.Decode(strings.NewReader(tt.input), roster.FormatCSV)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestEncodeDecode_RoundTrip(t *testing.T) {
+	src := model.Roster{Teams: []model.Team{
+		{TeamName: "engineering", Members: []model.TeamMember{}},
+		{TeamName: "backend", ParentTeamName: "engineering", Members: []model.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true},
+			{UserID: "u2", Username: "Bob, Jr.", IsActive: false},
+		}},
+	}}
+
+	for _, f := range []roster.Format{roster.FormatJSON, roster.FormatYAML, roster.FormatCSV} {
+		t.Run(string(f), func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.NoError(t, roster.Encode(&buf, f, src))
+
+			got, err := roster.Decode(&buf, f)
+			assert.NoError(t, err)
+			assert.Equal(t, src, got)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package scheduler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/scheduler"
+)
+
+func TestSchedule_Next(t *testing.T) {
+	// 2025-11-03 — понедельник
+	from := time.Date(2025, 11, 3, 9, 30, 15, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		spec string
+		want time.Time
+	}{
+		{"Every minute", "* * * * *", time.Date(2025, 11, 3, 9, 31, 0, 0, time.UTC)},
+		{"Step", "*/20 * * * *", time.Date(2025, 11, 3, 9, 40, 0, 0, time.UTC)},
+		{"Weekdays next day", "0 9 * * 1-5", time.Date(2025, 11, 4, 9, 0, 0, 0, time.UTC)},
+		{"Weekly already passed", "0 9 * * 1", time.Date(2025, 11, 10, 9, 0, 0, 0, time.UTC)},
+		{"Sunday as 7", "0 0 * * 7", time.Date(2025, 11, 9, 0, 0, 0, 0, time.UTC)},
+		{"Macro", "@monthly", time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC)},
+		{"List and range", "15,45 8-10 * * *", time.Date(2025, 11, 3, 9, 45, 0, 0, time.UTC)},
+		{"Day of month or day of week", "0 12 15 * 5", time.Date(2025, 11, 7, 12, 0, 0, 0, time.UTC)},
+		{"Leap day", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"Never", "0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := scheduler.ParseCron(tt.spec)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, s.Next(from))
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "@yearly"} {
+		t.Run(spec, func(t *testing.T) {
+			_, err := scheduler.ParseCron(spec)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// JobFunc is an autogenerated mock type for the JobFunc type
+type JobFunc struct {
+	mock.Mock
+}
+
+// Execute provides a mock function with given fields: ctx, scheduledAt
+func (_m *JobFunc) Execute(ctx context.Context, scheduledAt time.Time) (string, error) {
+	ret := _m.Called(ctx, scheduledAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (string, error)); ok {
+		return rf(ctx, scheduledAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) string); ok {
+		r0 = rf(ctx, scheduledAt)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, scheduledAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewJobFunc creates a new instance of JobFunc. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewJobFunc(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *JobFunc {
+	mock := &JobFunc{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,167 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// RunStore is an autogenerated mock type for the RunStore type
+type RunStore struct {
+	mock.Mock
+}
+
+// FailRunningRuns provides a mock function with given fields: ctx, runErr
+func (_m *RunStore) FailRunningRuns(ctx context.Context, runErr string) (int64, error) {
+	ret := _m.Called(ctx, runErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailRunningRuns")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, runErr)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, runErr)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, runErr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FinishRun provides a mock function with given fields: ctx, id, summary, runErr
+func (_m *RunStore) FinishRun(ctx context.Context, id int64, summary string, runErr string) error {
+	ret := _m.Called(ctx, id, summary, runErr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FinishRun")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, string, string) error); ok {
+		r0 = rf(ctx, id, summary, runErr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// LastScheduledAt provides a mock function with given fields: ctx, job
+func (_m *RunStore) LastScheduledAt(ctx context.Context, job string) (time.Time, error) {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LastScheduledAt")
+	}
+
+	var r0 time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (time.Time, error)); ok {
+		return rf(ctx, job)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) time.Time); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Get(0).(time.Time)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, job)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PruneRuns provides a mock function with given fields: ctx, before
+func (_m *RunStore) PruneRuns(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PruneRuns")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StartRun provides a mock function with given fields: ctx, job, scheduledAt
+func (_m *RunStore) StartRun(ctx context.Context, job string, scheduledAt time.Time) (int64, bool, error) {
+	ret := _m.Called(ctx, job, scheduledAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for StartRun")
+	}
+
+	var r0 int64
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) (int64, bool, error)); ok {
+		return rf(ctx, job, scheduledAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) int64); ok {
+		r0 = rf(ctx, job, scheduledAt)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, time.Time) bool); ok {
+		r1 = rf(ctx, job, scheduledAt)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, time.Time) error); ok {
+		r2 = rf(ctx, job, scheduledAt)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// NewRunStore creates a new instance of RunStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewRunStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *RunStore {
+	mock := &RunStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
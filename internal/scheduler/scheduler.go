@@ -0,0 +1,201 @@
+// Package scheduler запускает периодические задачи по cron-расписаниям. Задачи выполняет
+// только один экземпляр сервиса — лидер, удерживающий advisory-блокировку PostgreSQL;
+// каждый запуск записывается в историю, по которой новый лидер продолжает расписание
+// и досылает пропущенное срабатывание. Запуски, прерванные падением прежнего лидера,
+// новый лидер помечает неудачными; история старше runRetention удаляется.
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"pull-request-service/internal/outbound"
+)
+
+const (
+	// tickInterval — как часто лидер проверяет расписания, а остальные экземпляры пытаются стать лидером.
+	tickInterval = 30 * time.Second
+	// pruneInterval — как часто лидер удаляет старую историю запусков.
+	pruneInterval = time.Hour
+	// runRetention — сколько хранится история запусков. Последний запуск каждой задачи
+	// хранится дольше: по нему продолжается расписание.
+	runRetention = 30 * 24 * time.Hour
+	// abandonedRunError — ошибка, которой помечаются запуски, прерванные падением лидера.
+	abandonedRunError = "abandoned: scheduler leader stopped before the run finished"
+)
+
+// JobFunc выполняет задачу для срабатывания расписания в момент scheduledAt и возвращает краткий итог.
+// Вызывается в транзакции: при ошибке изменения задачи откатываются.
+type JobFunc func(ctx context.Context, scheduledAt time.Time) (string, error)
+
+// Job — задача планировщика.
+type Job struct {
+	Name     string
+	Schedule *Schedule
+	Run      JobFunc
+}
+
+// Leader описывает лидерство среди экземпляров сервиса. Acquire пытается стать лидером
+// или, если лидерство уже получено, проверяет, что оно не потеряно; Release от него отказывается.
+type Leader interface {
+	Acquire(ctx context.Context) (bool, error)
+	Release()
+}
+
+// RunStore хранит историю запусков задач.
+type RunStore interface {
+	LastScheduledAt(ctx context.Context, job string) (time.Time, error)
+	StartRun(ctx context.Context, job string, scheduledAt time.Time) (int64, bool, error)
+	FinishRun(ctx context.Context, id int64, summary string, runErr string) error
+	FailRunningRuns(ctx context.Context, runErr string) (int64, error)
+	PruneRuns(ctx context.Context, before time.Time) (int64, error)
+}
+
+// TransactionManager описывает запуск функции в транзакции БД.
+type TransactionManager interface {
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Scheduler запускает задачи по расписаниям, пока экземпляр сервиса остаётся лидером.
+type Scheduler struct {
+	leader  Leader
+	runs    RunStore
+	tx      TransactionManager
+	jobs    []Job
+	log     *slog.Logger
+	started time.Time
+}
+
+// New создаёт планировщик. Задачи, ни разу не запускавшиеся, впервые срабатывают
+// по расписанию после создания планировщика.
+func New(leader Leader, runs RunStore, tx TransactionManager, log *slog.Logger) *Scheduler {
+	return &Scheduler{leader: leader, runs: runs, tx: tx, log: log, started: time.Now()}
+}
+
+// Add регистрирует задачу с cron-расписанием spec.
+func (s *Scheduler) Add(name, spec string, run JobFunc) error {
+	schedule, err := ParseCron(spec)
+	if err != nil {
+		return fmt.Errorf("job %s: %w", name, err)
+	}
+	s.jobs = append(s.jobs, Job{Name: name, Schedule: schedule, Run: run})
+	return nil
+}
+
+// Run проверяет расписания раз в tickInterval, пока не отменён ctx. При отмене лидерство освобождается.
+// Став лидером, экземпляр сначала закрывает запуски, брошенные прежним лидером.
+func (s *Scheduler) Run(ctx context.Context) {
+	defer s.leader.Release()
+
+	ticker := time.NewTicker(tickInterval)
+	defer ticker.Stop()
+	wasLeading := false
+	var lastPrune time.Time
+	for {
+		leading, err := s.leader.Acquire(ctx)
+		switch {
+		case err != nil && ctx.Err() == nil:
+			s.log.Error("scheduler leader election failed", slog.Any("err", err))
+		case leading:
+			if !wasLeading {
+				s.failAbandoned(ctx)
+			}
+			s.RunDue(ctx, time.Now())
+			if time.Since(lastPrune) >= pruneInterval {
+				lastPrune = time.Now()
+				if _, err := s.runs.PruneRuns(ctx, lastPrune.Add(-runRetention)); err != nil && ctx.Err() == nil {
+					s.log.Error("scheduler: prune job history failed", slog.Any("err", err))
+				}
+			}
+		}
+		wasLeading = leading
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// failAbandoned помечает неудачными запуски, оставшиеся в статусе running. Задачи выполняет
+// только лидер, поэтому у только что ставшего лидером экземпляра таких запусков нет: их начал
+// прежний лидер, упавший до записи итога, а транзакция задачи при этом откатилась.
+func (s *Scheduler) failAbandoned(ctx context.Context) {
+	n, err := s.runs.FailRunningRuns(ctx, abandonedRunError)
+	if err != nil {
+		if ctx.Err() == nil {
+			s.log.Error("scheduler: close abandoned runs failed", slog.Any("err", err))
+		}
+		return
+	}
+	if n > 0 {
+		s.log.Warn("scheduler: marked abandoned runs as failed", slog.Int64("runs", n))
+	}
+}
+
+// RunDue запускает задачи, срабатывание которых наступило к моменту now. Если с прошлого
+// запуска пропущено несколько срабатываний (например, лидера не было), задача выполняется
+// один раз — для последнего из них.
+func (s *Scheduler) RunDue(ctx context.Context, now time.Time) {
+	for _, job := range s.jobs {
+		due, err := s.dueAt(ctx, job, now)
+		if err != nil {
+			if ctx.Err() == nil {
+				s.log.Error("scheduler: read job history failed", slog.String("job", job.Name), slog.Any("err", err))
+			}
+			continue
+		}
+		if due.IsZero() {
+			continue
+		}
+		if err := s.runJob(ctx, job, due); err != nil && ctx.Err() == nil {
+			s.log.Error("scheduler: record job run failed", slog.String("job", job.Name), slog.Any("err", err))
+		}
+	}
+}
+
+// dueAt возвращает последнее наступившее срабатывание задачи после её предыдущего запуска
+// или нулевое время, если запускать нечего.
+func (s *Scheduler) dueAt(ctx context.Context, job Job, now time.Time) (time.Time, error) {
+	last, err := s.runs.LastScheduledAt(ctx, job.Name)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if last.IsZero() {
+		last = s.started
+	}
+
+	var due time.Time
+	for next := job.Schedule.Next(last); !next.IsZero() && !next.After(now); next = job.Schedule.Next(next) {
+		due = next
+	}
+	return due, nil
+}
+
+// runJob записывает начало запуска, выполняет задачу в транзакции и записывает итог.
+// Если запуск для этого срабатывания уже записан (другим лидером), задача не выполняется.
+func (s *Scheduler) runJob(ctx context.Context, job Job, scheduledAt time.Time) error {
+	id, started, err := s.runs.StartRun(ctx, job.Name, scheduledAt)
+	if err != nil || !started {
+		return err
+	}
+
+	var summary string
+	runErr := s.tx.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		summary, err = job.Run(ctx, scheduledAt)
+		return err
+	})
+
+	var msg string
+	if runErr != nil {
+		msg = outbound.ErrorText(runErr)
+		s.log.Warn("scheduled job failed", slog.String("job", job.Name), slog.String("err", msg))
+	} else {
+		s.log.Info("scheduled job finished", slog.String("job", job.Name), slog.String("summary", summary))
+	}
+	return s.runs.FinishRun(context.WithoutCancel(ctx), id, summary, msg)
+}
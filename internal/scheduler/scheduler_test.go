@@ -0,0 +1,127 @@
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/scheduler"
+	"pull-request-service/internal/scheduler/mocks"
+)
+
+func runInTx() *mocks.TransactionManager {
+	tx := new(mocks.TransactionManager)
+	tx.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	}).Maybe()
+	return tx
+}
+
+func TestScheduler_RunDue(t *testing.T) {
+	now := time.Date(2025, 11, 5, 9, 0, 30, 0, time.UTC)
+	today := time.Date(2025, 11, 5, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		setupMocks func(rs *mocks.RunStore)
+		jobErr     error
+		wantRun    bool
+	}{
+		{
+			name: "Due: runs latest missed fire time once",
+			setupMocks: func(rs *mocks.RunStore) {
+				rs.On("LastScheduledAt", mock.Anything, "reminders").Return(today.AddDate(0, 0, -3), nil)
+				rs.On("StartRun", mock.Anything, "reminders", today).Return(int64(7), true, nil)
+				rs.On("FinishRun", mock.Anything, int64(7), "sent 2", "").Return(nil)
+			},
+			wantRun: true,
+		},
+		{
+			name: "Not due: already ran for today",
+			setupMocks: func(rs *mocks.RunStore) {
+				rs.On("LastScheduledAt", mock.Anything, "reminders").Return(today, nil)
+			},
+		},
+		{
+			name: "Skip: another leader started this fire time",
+			setupMocks: func(rs *mocks.RunStore) {
+				rs.On("LastScheduledAt", mock.Anything, "reminders").Return(today.AddDate(0, 0, -1), nil)
+				rs.On("StartRun", mock.Anything, "reminders", today).Return(int64(0), false, nil)
+			},
+		},
+		{
+			name: "Failure is recorded",
+			setupMocks: func(rs *mocks.RunStore) {
+				rs.On("LastScheduledAt", mock.Anything, "reminders").Return(today.AddDate(0, 0, -1), nil)
+				rs.On("StartRun", mock.Anything, "reminders", today).Return(int64(8), true, nil)
+				rs.On("FinishRun", mock.Anything, int64(8), "", "smtp queue unavailable").Return(nil)
+			},
+			jobErr:  errors.New("smtp queue unavailable"),
+			wantRun: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := new(mocks.RunStore)
+			tt.setupMocks(rs)
+
+			var ran []time.Time
+			s := scheduler.New(new(mocks.Leader), rs, runInTx(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+			require.NoError(t, s.Add("reminders", "0 9 * * *", func(_ context.Context, at time.Time) (string, error) {
+				ran = append(ran, at)
+				if tt.jobErr != nil {
+					return "", tt.jobErr
+				}
+				return "sent 2", nil
+			}))
+
+			s.RunDue(context.Background(), now)
+
+			if tt.wantRun {
+				assert.Equal(t, []time.Time{today}, ran)
+			} else {
+				assert.Empty(t, ran)
+			}
+			rs.AssertExpectations(t)
+		})
+	}
+}
+
+func TestScheduler_Add_InvalidSpec(t *testing.T) {
+	s := scheduler.New(new(mocks.Leader), new(mocks.RunStore), runInTx(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	err := s.Add("reminders", "every morning", nil)
+	assert.ErrorContains(t, err, "job reminders")
+}
+
+func TestScheduler_Run_NewLeaderClosesAbandonedRuns(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	leader := new(mocks.Leader)
+	leader.On("Acquire", mock.Anything).Return(true, nil).Once()
+	leader.On("Release").Return().Once()
+
+	rs := new(mocks.RunStore)
+	rs.On("FailRunningRuns", mock.Anything, mock.MatchedBy(func(msg string) bool {
+		return strings.HasPrefix(msg, "abandoned")
+	})).Return(int64(1), nil).Once()
+	rs.On("PruneRuns", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		age := time.Since(before)
+		return age > 29*24*time.Hour && age < 31*24*time.Hour
+	})).Return(int64(3), nil).Once().Run(func(mock.Arguments) { cancel() })
+
+	s := scheduler.New(leader, rs, runInTx(), slog.New(slog.NewTextHandler(io.Discard, nil)))
+	s.Run(ctx)
+
+	leader.AssertExpectations(t)
+	rs.AssertExpectations(t)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"pull-request-service/internal/model"
+)
+
+// CycleTimeRepository описывает контракт rollup-таблиц времени цикла ревью.
+type CycleTimeRepository interface {
+	RefreshRollups(ctx context.Context, now time.Time, windowDays []int, weeks int) (int64, error)
+	ListWindowRollups(ctx context.Context, dimension model.CycleTimeDimension, windowDays int, subject string) ([]model.CycleTimeRollup, *time.Time, error)
+	ListWeeklyRollups(ctx context.Context, dimension model.CycleTimeDimension, subject string) ([]model.CycleTimeRollup, error)
+}
+
+// CycleTimeConfig задаёт окна отчёта в днях (первое — окно по умолчанию) и глубину недельного тренда.
+type CycleTimeConfig struct {
+	WindowDays []int
+	TrendWeeks int
+}
+
+// DefaultCycleTimeConfig — окна 30 (по умолчанию), 7 и 90 дней и тренд за 12 недель.
+var DefaultCycleTimeConfig = CycleTimeConfig{WindowDays: []int{30, 7, 90}, TrendWeeks: 12}
+
+// CycleTimeService отдаёт процентили времени цикла ревью из rollup-таблиц
+// и пересчитывает их по расписанию (RefreshRollups подходит как задача планировщика).
+type CycleTimeService struct {
+	repo CycleTimeRepository
+	cfg  CycleTimeConfig
+}
+
+// NewCycleTimeService создаёт сервис аналитики времени цикла.
+func NewCycleTimeService(repo CycleTimeRepository, cfg CycleTimeConfig) *CycleTimeService {
+	return &CycleTimeService{repo: repo, cfg: cfg}
+}
+
+// RefreshRollups пересчитывает rollup-таблицы на момент scheduledAt.
+func (s *CycleTimeService) RefreshRollups(ctx context.Context, scheduledAt time.Time) (string, error) {
+	n, err := s.repo.RefreshRollups(ctx, scheduledAt, s.cfg.WindowDays, s.cfg.TrendWeeks)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("refreshed %d rollup rows", n), nil
+}
+
+// GetCycleTime возвращает процентили метрик за окно filter.WindowDays (0 — окно по умолчанию)
+// и недельный тренд для каждого субъекта разреза filter.Dimension (по умолчанию — команды).
+func (s *CycleTimeService) GetCycleTime(ctx context.Context, filter model.CycleTimeFilter) (model.CycleTimeReport, error) {
+	if filter.Dimension == "" {
+		filter.Dimension = model.CycleTimeByTeam
+	}
+	if !filter.Dimension.IsValid() {
+		return model.CycleTimeReport{}, ErrBadRequest("dimension must be one of team, reviewer, author")
+	}
+	if filter.WindowDays == 0 {
+		filter.WindowDays = s.cfg.WindowDays[0]
+	}
+	if !slices.Contains(s.cfg.WindowDays, filter.WindowDays) {
+		return model.CycleTimeReport{}, ErrBadRequest("window_days must be one of " + joinInts(s.cfg.WindowDays))
+	}
+
+	current, refreshedAt, err := s.repo.ListWindowRollups(ctx, filter.Dimension, filter.WindowDays, filter.Subject)
+	if err != nil {
+		return model.CycleTimeReport{}, &AppError{Code: "INTERNAL", Message: "failed to get cycle time", Status: 500, Err: err}
+	}
+	weekly, err := s.repo.ListWeeklyRollups(ctx, filter.Dimension, filter.Subject)
+	if err != nil {
+		return model.CycleTimeReport{}, &AppError{Code: "INTERNAL", Message: "failed to get cycle time trend", Status: 500, Err: err}
+	}
+
+	items := make(map[string]*model.CycleTimeItem)
+	item := func(subject string) *model.CycleTimeItem {
+		it, ok := items[subject]
+		if !ok {
+			it = &model.CycleTimeItem{Subject: subject, Metrics: map[model.CycleTimeMetric]model.Percentiles{}, Trend: []model.CycleTimeWeek{}}
+			items[subject] = it
+		}
+		return it
+	}
+	for _, ru := range current {
+		item(ru.Subject).Metrics[ru.Metric] = ru.Percentiles
+	}
+	// Недели приходят по возрастанию, метрики одной недели — подряд
+	for _, ru := range weekly {
+		it := item(ru.Subject)
+		week := ru.WeekStart.Format(time.DateOnly)
+		if n := len(it.Trend); n == 0 || it.Trend[n-1].WeekStart != week {
+			it.Trend = append(it.Trend, model.CycleTimeWeek{WeekStart: week, Metrics: map[model.CycleTimeMetric]model.Percentiles{}})
+		}
+		it.Trend[len(it.Trend)-1].Metrics[ru.Metric] = ru.Percentiles
+	}
+
+	report := model.CycleTimeReport{
+		Dimension:   filter.Dimension,
+		WindowDays:  filter.WindowDays,
+		RefreshedAt: refreshedAt,
+		Items:       make([]model.CycleTimeItem, 0, len(items)),
+	}
+	for _, it := range items {
+		report.Items = append(report.Items, *it)
+	}
+	sort.Slice(report.Items, func(i, j int) bool { return report.Items[i].Subject < report.Items[j].Subject })
+	return report, nil
+}
+
+func joinInts(values []int) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = strconv.Itoa(v)
+	}
+	return strings.Join(parts, ", ")
+}
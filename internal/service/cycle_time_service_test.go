@@ -0,0 +1,134 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/service"
+	"pull-request-service/internal/service/mocks"
+)
+
+func TestCycleTimeService_GetCycleTime(t *testing.T) {
+	refreshedAt := time.Date(2025, 11, 5, 12, 0, 0, 0, time.UTC)
+	week1 := time.Date(2025, 10, 27, 0, 0, 0, 0, time.UTC)
+	week2 := time.Date(2025, 11, 3, 0, 0, 0, 0, time.UTC)
+	p := func(samples int, p50 float64) model.Percentiles {
+		return model.Percentiles{Samples: samples, P50Hours: p50, P90Hours: p50 * 2, P99Hours: p50 * 3}
+	}
+
+	tests := []struct {
+		name       string
+		filter     model.CycleTimeFilter
+		setupMocks func(r *mocks.CycleTimeRepository)
+		want       model.CycleTimeReport
+		wantCode   string
+	}{
+		{
+			name:   "Success: defaults to teams and the first window",
+			filter: model.CycleTimeFilter{},
+			setupMocks: func(r *mocks.CycleTimeRepository) {
+				r.On("ListWindowRollups", mock.Anything, model.CycleTimeByTeam, 30, "").Return([]model.CycleTimeRollup{
+					{Subject: "backend", Metric: model.MetricFirstReview, Percentiles: p(4, 2)},
+					{Subject: "backend", Metric: model.MetricMerge, Percentiles: p(3, 20)},
+				}, &refreshedAt, nil)
+				r.On("ListWeeklyRollups", mock.Anything, model.CycleTimeByTeam, "").Return([]model.CycleTimeRollup{
+					{Subject: "backend", WeekStart: week1, Metric: model.MetricFirstReview, Percentiles: p(1, 5)},
+					{Subject: "backend", WeekStart: week2, Metric: model.MetricFirstReview, Percentiles: p(3, 1)},
+					{Subject: "backend", WeekStart: week2, Metric: model.MetricMerge, Percentiles: p(3, 20)},
+					// Команда без замеров в окне, но с трендом
+					{Subject: "android", WeekStart: week1, Metric: model.MetricApproval, Percentiles: p(1, 8)},
+				}, nil)
+			},
+			want: model.CycleTimeReport{
+				Dimension:   model.CycleTimeByTeam,
+				WindowDays:  30,
+				RefreshedAt: &refreshedAt,
+				Items: []model.CycleTimeItem{
+					{
+						Subject: "android",
+						Metrics: map[model.CycleTimeMetric]model.Percentiles{},
+						Trend: []model.CycleTimeWeek{
+							{WeekStart: "2025-10-27", Metrics: map[model.CycleTimeMetric]model.Percentiles{model.MetricApproval: p(1, 8)}},
+						},
+					},
+					{
+						Subject: "backend",
+						Metrics: map[model.CycleTimeMetric]model.Percentiles{model.MetricFirstReview: p(4, 2), model.MetricMerge: p(3, 20)},
+						Trend: []model.CycleTimeWeek{
+							{WeekStart: "2025-10-27", Metrics: map[model.CycleTimeMetric]model.Percentiles{model.MetricFirstReview: p(1, 5)}},
+							{WeekStart: "2025-11-03", Metrics: map[model.CycleTimeMetric]model.Percentiles{model.MetricFirstReview: p(3, 1), model.MetricMerge: p(3, 20)}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:   "Success: single reviewer, nothing computed yet",
+			filter: model.CycleTimeFilter{Dimension: model.CycleTimeByReviewer, WindowDays: 7, Subject: "u2"},
+			setupMocks: func(r *mocks.CycleTimeRepository) {
+				r.On("ListWindowRollups", mock.Anything, model.CycleTimeByReviewer, 7, "u2").Return([]model.CycleTimeRollup{}, (*time.Time)(nil), nil)
+				r.On("ListWeeklyRollups", mock.Anything, model.CycleTimeByReviewer, "u2").Return([]model.CycleTimeRollup{}, nil)
+			},
+			want: model.CycleTimeReport{Dimension: model.CycleTimeByReviewer, WindowDays: 7, Items: []model.CycleTimeItem{}},
+		},
+		{
+			name:       "Fail: Unknown dimension",
+			filter:     model.CycleTimeFilter{Dimension: "repository"},
+			setupMocks: func(r *mocks.CycleTimeRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "Fail: Window is not configured",
+			filter:     model.CycleTimeFilter{WindowDays: 14},
+			setupMocks: func(r *mocks.CycleTimeRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:   "Fail: Repository error",
+			filter: model.CycleTimeFilter{Dimension: model.CycleTimeByAuthor},
+			setupMocks: func(r *mocks.CycleTimeRepository) {
+				r.On("ListWindowRollups", mock.Anything, model.CycleTimeByAuthor, 30, "").Return(nil, (*time.Time)(nil), errors.New("db down"))
+			},
+			wantCode: "INTERNAL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.CycleTimeRepository)
+			tt.setupMocks(repo)
+
+			svc := service.NewCycleTimeService(repo, service.DefaultCycleTimeConfig)
+			report, err := svc.GetCycleTime(context.Background(), tt.filter)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.want, report)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestCycleTimeService_RefreshRollups(t *testing.T) {
+	at := time.Date(2025, 11, 5, 12, 15, 0, 0, time.UTC)
+	repo := new(mocks.CycleTimeRepository)
+	repo.On("RefreshRollups", mock.Anything, at, []int{30, 7, 90}, 12).Return(int64(42), nil)
+
+	summary, err := service.NewCycleTimeService(repo, service.DefaultCycleTimeConfig).RefreshRollups(context.Background(), at)
+
+	require.NoError(t, err)
+	assert.Equal(t, "refreshed 42 rollup rows", summary)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"pull-request-service/internal/model"
+)
+
+const (
+	// dashboardStaleLimit — сколько зависших PR показывает дашборд.
+	dashboardStaleLimit = 20
+	// dashboardReassignmentLimit — сколько последних переназначений показывает дашборд.
+	dashboardReassignmentLimit = 20
+)
+
+// DashboardRepository описывает данные, из которых собирается дашборд нагрузки.
+type DashboardRepository interface {
+	ListReviewerStats(ctx context.Context, filter model.StatsFilter, now time.Time) ([]model.ReviewerStats, error)
+	ListStalePullRequests(ctx context.Context, createdBefore time.Time, limit int) ([]model.StalledPR, error)
+	ListRecentReassignments(ctx context.Context, limit int) ([]model.ReviewerReassignment, error)
+}
+
+// DashboardService собирает данные страницы нагрузки ревьюверов для руководителей.
+type DashboardService struct {
+	repo       DashboardRepository
+	staleHours int
+}
+
+// NewDashboardService создаёт сервис дашборда. PR считается зависшим, если открыт дольше staleHours часов
+// и всё ещё ждёт ревью.
+func NewDashboardService(repo DashboardRepository, staleHours int) *DashboardService {
+	return &DashboardService{repo: repo, staleHours: staleHours}
+}
+
+// GetDashboard возвращает участников команд с текущей нагрузкой, зависшие PR и последние переназначения.
+// Пользователи без команды и неактивные без открытых ревью на дашборд не попадают.
+func (s *DashboardService) GetDashboard(ctx context.Context) (model.Dashboard, error) {
+	now := time.Now().UTC()
+
+	reviewers, err := s.repo.ListReviewerStats(ctx, model.StatsFilter{}, now)
+	if err != nil {
+		return model.Dashboard{}, &AppError{Code: "INTERNAL", Message: "failed to get reviewer load", Status: 500, Err: err}
+	}
+	stale, err := s.repo.ListStalePullRequests(ctx, now.Add(-time.Duration(s.staleHours)*time.Hour), dashboardStaleLimit)
+	if err != nil {
+		return model.Dashboard{}, &AppError{Code: "INTERNAL", Message: "failed to get stale pull requests", Status: 500, Err: err}
+	}
+	reassignments, err := s.repo.ListRecentReassignments(ctx, dashboardReassignmentLimit)
+	if err != nil {
+		return model.Dashboard{}, &AppError{Code: "INTERNAL", Message: "failed to get reassignments", Status: 500, Err: err}
+	}
+
+	d := model.Dashboard{
+		GeneratedAt:     now,
+		StaleAfterHours: s.staleHours,
+		StalePRs:        stale,
+		Reassignments:   reassignments,
+	}
+	index := make(map[string]int)
+	for _, r := range reviewers {
+		if r.TeamName == "" || (!r.IsActive && r.Open == 0) {
+			continue
+		}
+		i, ok := index[r.TeamName]
+		if !ok {
+			i = len(d.Teams)
+			index[r.TeamName] = i
+			d.Teams = append(d.Teams, model.DashboardTeam{TeamName: r.TeamName})
+		}
+		team := &d.Teams[i]
+		team.Members = append(team.Members, model.DashboardMember{
+			UserID: r.ReviewerID, Username: r.Username, IsActive: r.IsActive, OpenReviews: r.Open,
+		})
+		team.OpenReviews += r.Open
+		d.MaxOpenReviews = max(d.MaxOpenReviews, r.Open)
+	}
+
+	sort.Slice(d.Teams, func(i, j int) bool { return d.Teams[i].TeamName < d.Teams[j].TeamName })
+	for _, team := range d.Teams {
+		sort.SliceStable(team.Members, func(i, j int) bool {
+			a, b := team.Members[i], team.Members[j]
+			if a.OpenReviews != b.OpenReviews {
+				return a.OpenReviews > b.OpenReviews
+			}
+			return a.Username < b.Username
+		})
+	}
+	return d, nil
+}
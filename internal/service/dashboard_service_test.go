@@ -0,0 +1,63 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/service"
+	"pull-request-service/internal/service/mocks"
+)
+
+func TestDashboardService_GetDashboard(t *testing.T) {
+	repo := new(mocks.DashboardRepository)
+	repo.On("ListReviewerStats", mock.Anything, model.StatsFilter{}, mock.Anything).Return([]model.ReviewerStats{
+		{ReviewerID: "u2", Username: "Bob", TeamName: "backend", IsActive: true, Open: 1},
+		{ReviewerID: "u5", Username: "Eve", TeamName: "android", IsActive: true, Open: 2},
+		{ReviewerID: "u3", Username: "Carol", TeamName: "backend", IsActive: true, Open: 3},
+		{ReviewerID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		// Неактивный без открытых ревью и пользователь без команды не показываются
+		{ReviewerID: "u9", Username: "Ivan", TeamName: "backend", IsActive: false, Merged: 4},
+		{ReviewerID: "u8", Username: "Hank", IsActive: true, Open: 5},
+	}, nil)
+	repo.On("ListStalePullRequests", mock.Anything, mock.MatchedBy(func(before time.Time) bool {
+		return time.Since(before) > 47*time.Hour && time.Since(before) < 49*time.Hour
+	}), 20).Return([]model.StalledPR{{PullRequestID: "pr-1"}}, nil)
+	repo.On("ListRecentReassignments", mock.Anything, 20).Return([]model.ReviewerReassignment{{PullRequestID: "pr-1", ReviewerID: "u4"}}, nil)
+
+	d, err := service.NewDashboardService(repo, 48).GetDashboard(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, 48, d.StaleAfterHours)
+	assert.Equal(t, 3, d.MaxOpenReviews)
+	assert.Equal(t, []model.DashboardTeam{
+		{TeamName: "android", OpenReviews: 2, Members: []model.DashboardMember{
+			{UserID: "u5", Username: "Eve", IsActive: true, OpenReviews: 2},
+		}},
+		{TeamName: "backend", OpenReviews: 4, Members: []model.DashboardMember{
+			{UserID: "u3", Username: "Carol", IsActive: true, OpenReviews: 3},
+			{UserID: "u2", Username: "Bob", IsActive: true, OpenReviews: 1},
+			{UserID: "u1", Username: "Alice", IsActive: true},
+		}},
+	}, d.Teams)
+	assert.Len(t, d.StalePRs, 1)
+	assert.Len(t, d.Reassignments, 1)
+	repo.AssertExpectations(t)
+}
+
+func TestDashboardService_GetDashboard_Error(t *testing.T) {
+	repo := new(mocks.DashboardRepository)
+	repo.On("ListReviewerStats", mock.Anything, model.StatsFilter{}, mock.Anything).Return(nil, errors.New("db down"))
+
+	_, err := service.NewDashboardService(repo, 48).GetDashboard(context.Background())
+
+	var appErr *service.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "INTERNAL", appErr.Code)
+}
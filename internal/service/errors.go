@@ -7,11 +7,13 @@ import (
 
 // AppError описывает прикладную ошибку сервиса:
 // код для клиента, человекочитаемое сообщение, HTTP-статус и вложенная ошибка.
+// Details — необязательные структурированные подробности, которые отдаются клиенту как есть.
 type AppError struct {
 	Code    string
 	Message string
 	Status  int
 	Err     error
+	Details any
 }
 
 // Error реализует интерфейс error для AppError.
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"time"
+
+	"pull-request-service/internal/model"
+)
+
+// EventRecorder сохраняет доменные события для последующей рассылки.
+// RecordEvents вызывается внутри транзакции изменения, породившего события:
+// если транзакция откатывается, события исчезают вместе с ним.
+type EventRecorder interface {
+	RecordEvents(ctx context.Context, events []model.Event) error
+}
+
+// recordEvents проставляет событиям ID и время и передаёт их recorder.
+// Без recorder (рассылка не настроена) события отбрасываются.
+func recordEvents(ctx context.Context, recorder EventRecorder, events ...model.Event) error {
+	if recorder == nil || len(events) == 0 {
+		return nil
+	}
+	now := time.Now().UTC()
+	for i := range events {
+		if events[i].ID == "" {
+			events[i].ID = newEventID()
+		}
+		if events[i].OccurredAt.IsZero() {
+			events[i].OccurredAt = now
+		}
+	}
+	return recorder.RecordEvents(ctx, events)
+}
+
+// newEventID возвращает случайный 128-битный идентификатор события в hex.
+func newEventID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
+
+// reassignmentEvent описывает одно переназначение из reassignOpenReviews как событие.
+func reassignmentEvent(change model.ReviewReassignment) model.Event {
+	if change.Action == model.ReassignActionRemoved {
+		return model.Event{
+			Type:          model.EventReviewerRemoved,
+			PullRequestID: change.PullRequestID,
+			ReviewerID:    change.OldReviewerID,
+			Reason:        string(change.Reason),
+		}
+	}
+	return model.Event{
+		Type:               model.EventReviewerAssigned,
+		PullRequestID:      change.PullRequestID,
+		ReviewerID:         change.NewReviewerID,
+		ReplacedReviewerID: change.OldReviewerID,
+		Reason:             string(change.Reason),
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"pull-request-service/internal/model"
+)
+
+// JobRunRepository описывает контракт хранилища истории запусков задач планировщика.
+type JobRunRepository interface {
+	ListRuns(ctx context.Context, job string, limit int) ([]model.JobRun, error)
+}
+
+// JobService отдаёт историю запусков периодических задач. Сами задачи запускает scheduler.Scheduler.
+type JobService struct {
+	repo JobRunRepository
+}
+
+// NewJobService создаёт новый сервис истории запусков задач.
+func NewJobService(repo JobRunRepository) *JobService {
+	return &JobService{repo: repo}
+}
+
+// ListRuns возвращает последние запуски задачи job (пустой job — всех задач), начиная с новых.
+func (s *JobService) ListRuns(ctx context.Context, job string, limit int) ([]model.JobRun, error) {
+	limit, err := normalizeLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	runs, err := s.repo.ListRuns(ctx, job, limit)
+	if err != nil {
+		return nil, &AppError{Code: "INTERNAL", Message: "failed to list job runs", Status: 500, Err: err}
+	}
+	return runs, nil
+}
@@ -0,0 +1,128 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "pull-request-service/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// CycleTimeRepository is an autogenerated mock type for the CycleTimeRepository type
+type CycleTimeRepository struct {
+	mock.Mock
+}
+
+// ListWeeklyRollups provides a mock function with given fields: ctx, dimension, subject
+func (_m *CycleTimeRepository) ListWeeklyRollups(ctx context.Context, dimension model.CycleTimeDimension, subject string) ([]model.CycleTimeRollup, error) {
+	ret := _m.Called(ctx, dimension, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWeeklyRollups")
+	}
+
+	var r0 []model.CycleTimeRollup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.CycleTimeDimension, string) ([]model.CycleTimeRollup, error)); ok {
+		return rf(ctx, dimension, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.CycleTimeDimension, string) []model.CycleTimeRollup); ok {
+		r0 = rf(ctx, dimension, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CycleTimeRollup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.CycleTimeDimension, string) error); ok {
+		r1 = rf(ctx, dimension, subject)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWindowRollups provides a mock function with given fields: ctx, dimension, windowDays, subject
+func (_m *CycleTimeRepository) ListWindowRollups(ctx context.Context, dimension model.CycleTimeDimension, windowDays int, subject string) ([]model.CycleTimeRollup, *time.Time, error) {
+	ret := _m.Called(ctx, dimension, windowDays, subject)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWindowRollups")
+	}
+
+	var r0 []model.CycleTimeRollup
+	var r1 *time.Time
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.CycleTimeDimension, int, string) ([]model.CycleTimeRollup, *time.Time, error)); ok {
+		return rf(ctx, dimension, windowDays, subject)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.CycleTimeDimension, int, string) []model.CycleTimeRollup); ok {
+		r0 = rf(ctx, dimension, windowDays, subject)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.CycleTimeRollup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.CycleTimeDimension, int, string) *time.Time); ok {
+		r1 = rf(ctx, dimension, windowDays, subject)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, model.CycleTimeDimension, int, string) error); ok {
+		r2 = rf(ctx, dimension, windowDays, subject)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// RefreshRollups provides a mock function with given fields: ctx, now, windowDays, weeks
+func (_m *CycleTimeRepository) RefreshRollups(ctx context.Context, now time.Time, windowDays []int, weeks int) (int64, error) {
+	ret := _m.Called(ctx, now, windowDays, weeks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RefreshRollups")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, []int, int) (int64, error)); ok {
+		return rf(ctx, now, windowDays, weeks)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, []int, int) int64); ok {
+		r0 = rf(ctx, now, windowDays, weeks)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, []int, int) error); ok {
+		r1 = rf(ctx, now, windowDays, weeks)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewCycleTimeRepository creates a new instance of CycleTimeRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewCycleTimeRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *CycleTimeRepository {
+	mock := &CycleTimeRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
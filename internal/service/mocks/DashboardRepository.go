@@ -0,0 +1,121 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "pull-request-service/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// DashboardRepository is an autogenerated mock type for the DashboardRepository type
+type DashboardRepository struct {
+	mock.Mock
+}
+
+// ListRecentReassignments provides a mock function with given fields: ctx, limit
+func (_m *DashboardRepository) ListRecentReassignments(ctx context.Context, limit int) ([]model.ReviewerReassignment, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRecentReassignments")
+	}
+
+	var r0 []model.ReviewerReassignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) ([]model.ReviewerReassignment, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) []model.ReviewerReassignment); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ReviewerReassignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReviewerStats provides a mock function with given fields: ctx, filter, now
+func (_m *DashboardRepository) ListReviewerStats(ctx context.Context, filter model.StatsFilter, now time.Time) ([]model.ReviewerStats, error) {
+	ret := _m.Called(ctx, filter, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReviewerStats")
+	}
+
+	var r0 []model.ReviewerStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.StatsFilter, time.Time) ([]model.ReviewerStats, error)); ok {
+		return rf(ctx, filter, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.StatsFilter, time.Time) []model.ReviewerStats); ok {
+		r0 = rf(ctx, filter, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ReviewerStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.StatsFilter, time.Time) error); ok {
+		r1 = rf(ctx, filter, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListStalePullRequests provides a mock function with given fields: ctx, createdBefore, limit
+func (_m *DashboardRepository) ListStalePullRequests(ctx context.Context, createdBefore time.Time, limit int) ([]model.StalledPR, error) {
+	ret := _m.Called(ctx, createdBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListStalePullRequests")
+	}
+
+	var r0 []model.StalledPR
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) ([]model.StalledPR, error)); ok {
+		return rf(ctx, createdBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) []model.StalledPR); ok {
+		r0 = rf(ctx, createdBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.StalledPR)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, int) error); ok {
+		r1 = rf(ctx, createdBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewDashboardRepository creates a new instance of DashboardRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewDashboardRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *DashboardRepository {
+	mock := &DashboardRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,117 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "pull-request-service/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventLog is an autogenerated mock type for the EventLog type
+type EventLog struct {
+	mock.Mock
+}
+
+// LatestPublishedSeq provides a mock function with given fields: ctx
+func (_m *EventLog) LatestPublishedSeq(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LatestPublishedSeq")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPublishedSince provides a mock function with given fields: ctx, after, limit
+func (_m *EventLog) ListPublishedSince(ctx context.Context, after int64, limit int) ([]model.Event, error) {
+	ret := _m.Called(ctx, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPublishedSince")
+	}
+
+	var r0 []model.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) ([]model.Event, error)); ok {
+		return rf(ctx, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int64, int) []model.Event); ok {
+		r0 = rf(ctx, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int64, int) error); ok {
+		r1 = rf(ctx, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReviewerEventsSince provides a mock function with given fields: ctx, userID, after, limit
+func (_m *EventLog) ListReviewerEventsSince(ctx context.Context, userID string, after int64, limit int) ([]model.Event, error) {
+	ret := _m.Called(ctx, userID, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReviewerEventsSince")
+	}
+
+	var r0 []model.Event
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int) ([]model.Event, error)); ok {
+		return rf(ctx, userID, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int) []model.Event); ok {
+		r0 = rf(ctx, userID, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Event)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int) error); ok {
+		r1 = rf(ctx, userID, after, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewEventLog creates a new instance of EventLog. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventLog(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventLog {
+	mock := &EventLog{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	model "pull-request-service/internal/model"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// JobRunRepository is an autogenerated mock type for the JobRunRepository type
+type JobRunRepository struct {
+	mock.Mock
+}
+
+// ListRuns provides a mock function with given fields: ctx, job, limit
+func (_m *JobRunRepository) ListRuns(ctx context.Context, job string, limit int) ([]model.JobRun, error) {
+	ret := _m.Called(ctx, job, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRuns")
+	}
+
+	var r0 []model.JobRun
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) ([]model.JobRun, error)); ok {
+		return rf(ctx, job, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) []model.JobRun); ok {
+		r0 = rf(ctx, job, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.JobRun)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, job, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewJobRunRepository creates a new instance of JobRunRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewJobRunRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *JobRunRepository {
+	mock := &JobRunRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -162,6 +162,96 @@ func (_m *PRRepository) ListAssignedToUser(ctx context.Context, userID string) (
 	return r0, r1
 }
 
+// ListAuthoredByUser provides a mock function with given fields: ctx, authorID
+func (_m *PRRepository) ListAuthoredByUser(ctx context.Context, authorID string) ([]model.AuthoredPullRequest, error) {
+	ret := _m.Called(ctx, authorID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAuthoredByUser")
+	}
+
+	var r0 []model.AuthoredPullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.AuthoredPullRequest, error)); ok {
+		return rf(ctx, authorID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.AuthoredPullRequest); ok {
+		r0 = rf(ctx, authorID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.AuthoredPullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, authorID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPullRequests provides a mock function with given fields: ctx, filter
+func (_m *PRRepository) ListPullRequests(ctx context.Context, filter model.PullRequestFilter) ([]model.PullRequest, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPullRequests")
+	}
+
+	var r0 []model.PullRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.PullRequestFilter) ([]model.PullRequest, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.PullRequestFilter) []model.PullRequest); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.PullRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.PullRequestFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListReviewerStats provides a mock function with given fields: ctx, filter, now
+func (_m *PRRepository) ListReviewerStats(ctx context.Context, filter model.StatsFilter, now time.Time) ([]model.ReviewerStats, error) {
+	ret := _m.Called(ctx, filter, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListReviewerStats")
+	}
+
+	var r0 []model.ReviewerStats
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.StatsFilter, time.Time) ([]model.ReviewerStats, error)); ok {
+		return rf(ctx, filter, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.StatsFilter, time.Time) []model.ReviewerStats); ok {
+		r0 = rf(ctx, filter, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.ReviewerStats)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.StatsFilter, time.Time) error); ok {
+		r1 = rf(ctx, filter, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MarkMerged provides a mock function with given fields: ctx, prID, mergedAt
 func (_m *PRRepository) MarkMerged(ctx context.Context, prID string, mergedAt time.Time) (model.PullRequest, error) {
 	ret := _m.Called(ctx, prID, mergedAt)
@@ -236,6 +326,42 @@ func (_m *PRRepository) RemoveReviewer(ctx context.Context, prID string, reviewe
 	return r0
 }
 
+// SetReviewState provides a mock function with given fields: ctx, prID, reviewerID, state, at
+func (_m *PRRepository) SetReviewState(ctx context.Context, prID string, reviewerID string, state model.ReviewState, at time.Time) error {
+	ret := _m.Called(ctx, prID, reviewerID, state, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetReviewState")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.ReviewState, time.Time) error); ok {
+		r0 = rf(ctx, prID, reviewerID, state, at)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetStatus provides a mock function with given fields: ctx, prID, from, to
+func (_m *PRRepository) SetStatus(ctx context.Context, prID string, from model.PullRequestStatus, to model.PullRequestStatus) error {
+	ret := _m.Called(ctx, prID, from, to)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, model.PullRequestStatus, model.PullRequestStatus) error); ok {
+		r0 = rf(ctx, prID, from, to)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewPRRepository creates a new instance of PRRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewPRRepository(t interface {
@@ -7,6 +7,8 @@ import (
 	model "pull-request-service/internal/model"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // TeamRepository is an autogenerated mock type for the TeamRepository type
@@ -14,6 +16,42 @@ type TeamRepository struct {
 	mock.Mock
 }
 
+// AddMembers provides a mock function with given fields: ctx, teamName, members
+func (_m *TeamRepository) AddMembers(ctx context.Context, teamName string, members []model.TeamMember) error {
+	ret := _m.Called(ctx, teamName, members)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMembers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []model.TeamMember) error); ok {
+		r0 = rf(ctx, teamName, members)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ArchiveTeam provides a mock function with given fields: ctx, teamName, archivedAt
+func (_m *TeamRepository) ArchiveTeam(ctx context.Context, teamName string, archivedAt time.Time) error {
+	ret := _m.Called(ctx, teamName, archivedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ArchiveTeam")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = rf(ctx, teamName, archivedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateTeamWithMembers provides a mock function with given fields: ctx, team
 func (_m *TeamRepository) CreateTeamWithMembers(ctx context.Context, team model.Team) (model.Team, error) {
 	ret := _m.Called(ctx, team)
@@ -42,6 +80,24 @@ func (_m *TeamRepository) CreateTeamWithMembers(ctx context.Context, team model.
 	return r0, r1
 }
 
+// DeleteTeam provides a mock function with given fields: ctx, teamName
+func (_m *TeamRepository) DeleteTeam(ctx context.Context, teamName string) error {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTeam")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GetTeamByName provides a mock function with given fields: ctx, name
 func (_m *TeamRepository) GetTeamByName(ctx context.Context, name string) (model.Team, error) {
 	ret := _m.Called(ctx, name)
@@ -70,6 +126,244 @@ func (_m *TeamRepository) GetTeamByName(ctx context.Context, name string) (model
 	return r0, r1
 }
 
+// HasHistory provides a mock function with given fields: ctx, teamName
+func (_m *TeamRepository) HasHistory(ctx context.Context, teamName string) (bool, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for HasHistory")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDescendants provides a mock function with given fields: ctx, teamName
+func (_m *TeamRepository) ListDescendants(ctx context.Context, teamName string) ([]model.TeamNode, error) {
+	ret := _m.Called(ctx, teamName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListDescendants")
+	}
+
+	var r0 []model.TeamNode
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.TeamNode, error)); ok {
+		return rf(ctx, teamName)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.TeamNode); ok {
+		r0 = rf(ctx, teamName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TeamNode)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, teamName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRoster provides a mock function with given fields: ctx
+func (_m *TeamRepository) ListRoster(ctx context.Context) ([]model.Team, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRoster")
+	}
+
+	var r0 []model.Team
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.Team, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.Team); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Team)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTeamStats provides a mock function with given fields: ctx
+func (_m *TeamRepository) ListTeamStats(ctx context.Context) ([]model.TeamStatsDTO, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTeamStats")
+	}
+
+	var r0 []model.TeamStatsDTO
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]model.TeamStatsDTO, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []model.TeamStatsDTO); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TeamStatsDTO)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTeams provides a mock function with given fields: ctx, filter
+func (_m *TeamRepository) ListTeams(ctx context.Context, filter model.TeamFilter) ([]model.TeamSummary, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListTeams")
+	}
+
+	var r0 []model.TeamSummary
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.TeamFilter) ([]model.TeamSummary, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.TeamFilter) []model.TeamSummary); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.TeamSummary)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.TeamFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MoveMembers provides a mock function with given fields: ctx, teamName, userIDs
+func (_m *TeamRepository) MoveMembers(ctx context.Context, teamName string, userIDs []string) error {
+	ret := _m.Called(ctx, teamName, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveMembers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, teamName, userIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RemoveMembers provides a mock function with given fields: ctx, teamName, userIDs
+func (_m *TeamRepository) RemoveMembers(ctx context.Context, teamName string, userIDs []string) error {
+	ret := _m.Called(ctx, teamName, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMembers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) error); ok {
+		r0 = rf(ctx, teamName, userIDs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetLead provides a mock function with given fields: ctx, teamName, userID
+func (_m *TeamRepository) SetLead(ctx context.Context, teamName string, userID string) error {
+	ret := _m.Called(ctx, teamName, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetLead")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, teamName, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetNotificationChannel provides a mock function with given fields: ctx, teamName, webhookURL
+func (_m *TeamRepository) SetNotificationChannel(ctx context.Context, teamName string, webhookURL string) error {
+	ret := _m.Called(ctx, teamName, webhookURL)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetNotificationChannel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, teamName, webhookURL)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetParent provides a mock function with given fields: ctx, teamName, parentName
+func (_m *TeamRepository) SetParent(ctx context.Context, teamName string, parentName string) error {
+	ret := _m.Called(ctx, teamName, parentName)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetParent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, teamName, parentName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTeamRepository creates a new instance of TeamRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTeamRepository(t interface {
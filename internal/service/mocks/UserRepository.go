@@ -7,6 +7,8 @@ import (
 	model "pull-request-service/internal/model"
 
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // UserRepository is an autogenerated mock type for the UserRepository type
@@ -14,6 +16,52 @@ type UserRepository struct {
 	mock.Mock
 }
 
+// CountUsers provides a mock function with given fields: ctx, filter
+func (_m *UserRepository) CountUsers(ctx context.Context, filter model.UserFilter) (int, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountUsers")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserFilter) (int, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserFilter) int); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateUser provides a mock function with given fields: ctx, u
+func (_m *UserRepository) CreateUser(ctx context.Context, u model.User) error {
+	ret := _m.Called(ctx, u)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateUser")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.User) error); ok {
+		r0 = rf(ctx, u)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DeactivateUsers provides a mock function with given fields: ctx, userIDs
 func (_m *UserRepository) DeactivateUsers(ctx context.Context, userIDs []string) error {
 	ret := _m.Called(ctx, userIDs)
@@ -60,6 +108,66 @@ func (_m *UserRepository) GetByUserID(ctx context.Context, userID string) (model
 	return r0, r1
 }
 
+// GetByUserIDs provides a mock function with given fields: ctx, userIDs
+func (_m *UserRepository) GetByUserIDs(ctx context.Context, userIDs []string) ([]model.User, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByUserIDs")
+	}
+
+	var r0 []model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]model.User, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []model.User); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListActiveAncestorMembersExcept provides a mock function with given fields: ctx, teamName, exclude
+func (_m *UserRepository) ListActiveAncestorMembersExcept(ctx context.Context, teamName string, exclude []string) ([]model.User, error) {
+	ret := _m.Called(ctx, teamName, exclude)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveAncestorMembersExcept")
+	}
+
+	var r0 []model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) ([]model.User, error)); ok {
+		return rf(ctx, teamName, exclude)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []model.User); ok {
+		r0 = rf(ctx, teamName, exclude)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, teamName, exclude)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListActiveTeamMembersExcept provides a mock function with given fields: ctx, teamName, exclude
 func (_m *UserRepository) ListActiveTeamMembersExcept(ctx context.Context, teamName string, exclude []string) ([]model.User, error) {
 	ret := _m.Called(ctx, teamName, exclude)
@@ -90,6 +198,192 @@ func (_m *UserRepository) ListActiveTeamMembersExcept(ctx context.Context, teamN
 	return r0, r1
 }
 
+// ListActiveUserIDs provides a mock function with given fields: ctx
+func (_m *UserRepository) ListActiveUserIDs(ctx context.Context) ([]string, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActiveUserIDs")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]string, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListActivityEvents provides a mock function with given fields: ctx, userID
+func (_m *UserRepository) ListActivityEvents(ctx context.Context, userID string) ([]model.UserActivityEvent, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListActivityEvents")
+	}
+
+	var r0 []model.UserActivityEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]model.UserActivityEvent, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []model.UserActivityEvent); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserActivityEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRampUp provides a mock function with given fields: ctx, userIDs, since
+func (_m *UserRepository) ListRampUp(ctx context.Context, userIDs []string, since time.Time) ([]model.UserRampUp, error) {
+	ret := _m.Called(ctx, userIDs, since)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListRampUp")
+	}
+
+	var r0 []model.UserRampUp
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time) ([]model.UserRampUp, error)); ok {
+		return rf(ctx, userIDs, since)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string, time.Time) []model.UserRampUp); ok {
+		r0 = rf(ctx, userIDs, since)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.UserRampUp)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string, time.Time) error); ok {
+		r1 = rf(ctx, userIDs, since)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUsers provides a mock function with given fields: ctx, filter
+func (_m *UserRepository) ListUsers(ctx context.Context, filter model.UserFilter) ([]model.User, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUsers")
+	}
+
+	var r0 []model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserFilter) ([]model.User, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, model.UserFilter) []model.User); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, model.UserFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWorkingHours provides a mock function with given fields: ctx, userIDs
+func (_m *UserRepository) ListWorkingHours(ctx context.Context, userIDs []string) ([]model.WorkingHours, error) {
+	ret := _m.Called(ctx, userIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWorkingHours")
+	}
+
+	var r0 []model.WorkingHours
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]model.WorkingHours, error)); ok {
+		return rf(ctx, userIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []model.WorkingHours); ok {
+		r0 = rf(ctx, userIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.WorkingHours)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, userIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetChatHandle provides a mock function with given fields: ctx, userID, handle
+func (_m *UserRepository) SetChatHandle(ctx context.Context, userID string, handle string) error {
+	ret := _m.Called(ctx, userID, handle)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetChatHandle")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, handle)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetEmailPreferences provides a mock function with given fields: ctx, userID, email, mode
+func (_m *UserRepository) SetEmailPreferences(ctx context.Context, userID string, email string, mode model.EmailMode) error {
+	ret := _m.Called(ctx, userID, email, mode)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetEmailPreferences")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, model.EmailMode) error); ok {
+		r0 = rf(ctx, userID, email, mode)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetIsActive provides a mock function with given fields: ctx, userID, isActive
 func (_m *UserRepository) SetIsActive(ctx context.Context, userID string, isActive bool) (model.User, error) {
 	ret := _m.Called(ctx, userID, isActive)
@@ -118,6 +412,42 @@ func (_m *UserRepository) SetIsActive(ctx context.Context, userID string, isActi
 	return r0, r1
 }
 
+// SetUsername provides a mock function with given fields: ctx, userID, username
+func (_m *UserRepository) SetUsername(ctx context.Context, userID string, username string) error {
+	ret := _m.Called(ctx, userID, username)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetUsername")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userID, username)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetWorkingHours provides a mock function with given fields: ctx, userID, hours
+func (_m *UserRepository) SetWorkingHours(ctx context.Context, userID string, hours *model.WorkingHours) error {
+	ret := _m.Called(ctx, userID, hours)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetWorkingHours")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *model.WorkingHours) error); ok {
+		r0 = rf(ctx, userID, hours)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewUserRepository creates a new instance of UserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserRepository(t interface {
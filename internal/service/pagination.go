@@ -0,0 +1,85 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"pull-request-service/internal/model"
+)
+
+const (
+	// DefaultPageLimit — размер страницы списков, если клиент его не указал.
+	DefaultPageLimit = 50
+	// MaxPageLimit — максимальный размер страницы списков.
+	MaxPageLimit = 200
+)
+
+// normalizeLimit приводит запрошенный размер страницы к допустимому диапазону.
+func normalizeLimit(limit int) (int, error) {
+	if limit == 0 {
+		return DefaultPageLimit, nil
+	}
+	if limit < 0 || limit > MaxPageLimit {
+		return 0, ErrBadRequest("limit must be between 1 and 200")
+	}
+	return limit, nil
+}
+
+// encodeCursor упаковывает ключ последнего элемента страницы в непрозрачный курсор.
+func encodeCursor(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+// decodeCursor распаковывает курсор, полученный от клиента. Пустой курсор означает первую страницу.
+func decodeCursor(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+	key, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil || len(key) == 0 {
+		return "", ErrBadRequest("invalid cursor")
+	}
+	return string(key), nil
+}
+
+// prCursor — содержимое курсора списка PR. Sort фиксирует порядок, для которого курсор выдан.
+type prCursor struct {
+	Sort      model.PullRequestSort `json:"s"`
+	CreatedAt time.Time             `json:"c"`
+	Name      string                `json:"n"`
+	ID        string                `json:"id"`
+}
+
+// encodePRCursor упаковывает ключ последнего PR страницы в непрозрачный курсор.
+func encodePRCursor(sort model.PullRequestSort, pr model.PullRequest) string {
+	c := prCursor{Sort: sort, Name: pr.PullRequestName, ID: pr.PullRequestID}
+	if pr.CreatedAt != nil {
+		c.CreatedAt = *pr.CreatedAt
+	}
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodePRCursor распаковывает курсор списка PR и проверяет, что он выдан для того же порядка сортировки.
+func decodePRCursor(cursor string, sort model.PullRequestSort) (*model.PullRequestKey, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrBadRequest("invalid cursor")
+	}
+	var c prCursor
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return nil, ErrBadRequest("invalid cursor")
+	}
+	if c.Sort != sort {
+		return nil, ErrBadRequest("cursor was issued for a different sort order")
+	}
+	return &model.PullRequestKey{
+		CreatedAt:       c.CreatedAt,
+		PullRequestName: c.Name,
+		PullRequestID:   c.ID,
+	}, nil
+}
@@ -4,7 +4,6 @@ package service
 import (
 	"context"
 	"errors"
-	"math/rand"
 	"time"
 
 	"pull-request-service/internal/model"
@@ -21,10 +20,15 @@ type PRRepository interface {
 	CreatePRWithReviewers(ctx context.Context, pr model.PullRequest, reviewerIDs []string) (model.PullRequest, error)
 	GetPR(ctx context.Context, prID string) (model.PullRequest, error)
 	MarkMerged(ctx context.Context, prID string, mergedAt time.Time) (model.PullRequest, error)
+	SetStatus(ctx context.Context, prID string, from, to model.PullRequestStatus) error
 	ReassignReviewer(ctx context.Context, prID, oldUserID, newUserID string) (model.PullRequest, error)
 	ListAssignedToUser(ctx context.Context, userID string) ([]model.PullRequestShort, error)
+	ListPullRequests(ctx context.Context, filter model.PullRequestFilter) ([]model.PullRequest, error)
+	ListAuthoredByUser(ctx context.Context, authorID string) ([]model.AuthoredPullRequest, error)
+	SetReviewState(ctx context.Context, prID, reviewerID string, state model.ReviewState, at time.Time) error
 	GetOpenPRsByReviewers(ctx context.Context, reviewerIDs []string) (map[string][]string, error)
 	GetReviewerStats(ctx context.Context) ([]model.StatsDTO, error)
+	ListReviewerStats(ctx context.Context, filter model.StatsFilter, now time.Time) ([]model.ReviewerStats, error)
 	RemoveReviewer(ctx context.Context, prID, reviewerID string) error
 }
 
@@ -34,6 +38,8 @@ type PRService struct {
 	prRepo    PRRepository
 	userRepo  UserRepository
 	txManager TransactionManager
+	rampUp    RampUpPolicy
+	events    EventRecorder
 }
 
 // NewPRService создаёт новый сервис для работы с pull request'ами.
@@ -45,13 +51,33 @@ func NewPRService(prRepo PRRepository, userRepo UserRepository, txManager Transa
 	}
 }
 
+// SetRampUpPolicy задаёт ограничение назначений для вернувшихся пользователей.
+func (s *PRService) SetRampUpPolicy(p RampUpPolicy) {
+	s.rampUp = p
+}
+
+// SetEventRecorder задаёт получателя событий о создании и слиянии PR и назначении ревьюверов.
+func (s *PRService) SetEventRecorder(r EventRecorder) {
+	s.events = r
+}
+
 // CreatePR создаёт новый pull request и автоматически назначает до двух ревьюверов
-// из команды автора. Валидирует вход и оборачивает ошибки репозитория в AppError.
+// из команды автора. Если в команде не хватает кандидатов, недостающие места заполняются
+// участниками команд-предков, начиная с ближайшей. Валидирует вход и оборачивает ошибки репозитория в AppError.
+// Если приоритет не указан, PR создаётся с приоритетом normal. Для hotfix-PR лимит периода разгона не применяется,
+// а ревьюверы выбираются в первую очередь среди тех, у кого сейчас рабочее время.
 func (s *PRService) CreatePR(ctx context.Context, input model.PullRequest) (model.PullRequest, error) {
 	if input.PullRequestID == "" || input.PullRequestName == "" || input.AuthorID == "" {
 		return model.PullRequest{}, ErrBadRequest("pull_request_id, pull_request_name and author_id are required")
 	}
 
+	if input.Priority == "" {
+		input.Priority = model.PriorityNormal
+	}
+	if !input.Priority.IsValid() {
+		return model.PullRequest{}, ErrBadRequest("priority must be one of low, normal, high, hotfix")
+	}
+
 	author, err := s.userRepo.GetByUserID(ctx, input.AuthorID)
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
@@ -65,8 +91,12 @@ func (s *PRService) CreatePR(ctx context.Context, input model.PullRequest) (mode
 		}
 	}
 
+	rampUp := s.rampUp.forPriority(input.Priority)
 	exclude := []string{author.UserID}
 	members, err := s.userRepo.ListActiveTeamMembersExcept(ctx, author.TeamName, exclude)
+	if err == nil {
+		members, err = rampUp.filterRampUp(ctx, s.userRepo, members)
+	}
 	if err != nil {
 		return model.PullRequest{}, &AppError{
 			Code:    "INTERNAL",
@@ -76,6 +106,33 @@ func (s *PRService) CreatePR(ctx context.Context, input model.PullRequest) (mode
 		}
 	}
 
+	if len(members) < 2 {
+		exclude = append(exclude, idsOf(members)...)
+		ancestors, err := s.userRepo.ListActiveAncestorMembersExcept(ctx, author.TeamName, exclude)
+		if err == nil {
+			ancestors, err = rampUp.filterRampUp(ctx, s.userRepo, ancestors)
+		}
+		if err != nil {
+			return model.PullRequest{}, &AppError{
+				Code:    "INTERNAL",
+				Message: "failed to list parent team members",
+				Status:  500,
+				Err:     err,
+			}
+		}
+		members = append(members, ancestors...)
+	}
+
+	members, _, err = preferWorkingHours(ctx, s.userRepo, input.Priority, members, 2)
+	if err != nil {
+		return model.PullRequest{}, &AppError{
+			Code:    "INTERNAL",
+			Message: "failed to get working hours",
+			Status:  500,
+			Err:     err,
+		}
+	}
+
 	reviewers := chooseReviewers(members, 2)
 	reviewerIDs := make([]string, 0, len(reviewers))
 	for _, u := range reviewers {
@@ -88,7 +145,10 @@ func (s *PRService) CreatePR(ctx context.Context, input model.PullRequest) (mode
 	err = s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
 		var errTx error
 		pr, errTx = s.prRepo.CreatePRWithReviewers(ctx, input, reviewerIDs)
-		return errTx
+		if errTx != nil {
+			return errTx
+		}
+		return recordEvents(ctx, s.events, createdEvents(pr)...)
 	})
 
 	if err != nil {
@@ -115,12 +175,89 @@ func chooseReviewers(candidates []model.User, limit int) []model.User {
 	return candidates[:limit]
 }
 
+// reviewCandidates возвращает кандидатов на замену ревьювера: активных участников команды teamName,
+// а если таких нет — участников ближайшей команды-предка, в которой они есть.
+// Вернувшиеся пользователи, исчерпавшие лимит периода разгона, кандидатами не считаются
+// (для hotfix-PR вызывающий передаёт политику из RampUpPolicy.forPriority).
+func reviewCandidates(ctx context.Context, repo UserRepository, rampUp RampUpPolicy, teamName string, exclude []string) ([]model.User, error) {
+	candidates, err := repo.ListActiveTeamMembersExcept(ctx, teamName, exclude)
+	if err == nil {
+		candidates, err = rampUp.filterRampUp(ctx, repo, candidates)
+	}
+	if err != nil || len(candidates) > 0 {
+		return candidates, err
+	}
+
+	ancestors, err := repo.ListActiveAncestorMembersExcept(ctx, teamName, exclude)
+	if err == nil {
+		ancestors, err = rampUp.filterRampUp(ctx, repo, ancestors)
+	}
+	if err != nil || len(ancestors) == 0 {
+		return nil, err
+	}
+	nearest := ancestors[0].TeamName
+	for _, u := range ancestors {
+		if u.TeamName == nearest {
+			candidates = append(candidates, u)
+		}
+	}
+	return candidates, nil
+}
+
+// idsOf возвращает идентификаторы пользователей в исходном порядке.
+func idsOf(users []model.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.UserID)
+	}
+	return ids
+}
+
+// createdEvents описывает создание PR: событие pull_request.created
+// и по событию reviewer.assigned на каждого назначенного ревьювера.
+func createdEvents(pr model.PullRequest) []model.Event {
+	events := []model.Event{{
+		Type:            model.EventPRCreated,
+		PullRequestID:   pr.PullRequestID,
+		PullRequestName: pr.PullRequestName,
+		AuthorID:        pr.AuthorID,
+		Reviewers:       pr.AssignedReviewers,
+	}}
+	for _, id := range pr.AssignedReviewers {
+		events = append(events, model.Event{
+			Type:          model.EventReviewerAssigned,
+			PullRequestID: pr.PullRequestID,
+			ReviewerID:    id,
+		})
+	}
+	return events
+}
+
 // MergePR помечает pull request как MERGED (идемпотентно) и возвращает обновлённое состояние PR.
+// Событие pull_request.merged записывается только при первом слиянии.
 func (s *PRService) MergePR(ctx context.Context, prID string) (model.PullRequest, error) {
 	if prID == "" {
 		return model.PullRequest{}, ErrBadRequest("pull_request_id is required")
 	}
-	pr, err := s.prRepo.MarkMerged(ctx, prID, time.Now().UTC())
+
+	// Время обрезается до точности PostgreSQL, чтобы по merged_at отличить первое слияние от повторного
+	mergedAt := time.Now().UTC().Truncate(time.Microsecond)
+	var pr model.PullRequest
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prRepo.MarkMerged(ctx, prID, mergedAt)
+		if err != nil || pr.MergedAt == nil || !pr.MergedAt.Equal(mergedAt) {
+			return err
+		}
+		return recordEvents(ctx, s.events, model.Event{
+			Type:            model.EventPRMerged,
+			OccurredAt:      mergedAt,
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Reviewers:       pr.AssignedReviewers,
+		})
+	})
 	if err != nil {
 		if errors.Is(err, repository.ErrPRNotFound) {
 			return model.PullRequest{}, ErrNotFound("pull request not found")
@@ -135,7 +272,131 @@ func (s *PRService) MergePR(ctx context.Context, prID string) (model.PullRequest
 	return pr, nil
 }
 
+// ClosePR закрывает открытый PR без слияния: его ревьюверы освобождаются (событие reviewer.removed
+// на каждого), но остаются в PR как история. Повторное закрытие ничего не меняет, влитый PR закрыть нельзя.
+func (s *PRService) ClosePR(ctx context.Context, prID string) (model.PullRequest, error) {
+	if prID == "" {
+		return model.PullRequest{}, ErrBadRequest("pull_request_id is required")
+	}
+
+	var pr model.PullRequest
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prRepo.GetPR(ctx, prID)
+		if err != nil {
+			return err
+		}
+		switch pr.Status {
+		case model.StatusClosed:
+			return nil
+		case model.StatusMerged:
+			return ErrDomain("PR_MERGED", "cannot close merged PR")
+		}
+		if err := s.prRepo.SetStatus(ctx, prID, model.StatusOpen, model.StatusClosed); err != nil {
+			return err
+		}
+		pr.Status = model.StatusClosed
+
+		events := make([]model.Event, 0, len(pr.AssignedReviewers))
+		for _, id := range pr.AssignedReviewers {
+			events = append(events, model.Event{
+				Type:          model.EventReviewerRemoved,
+				PullRequestID: pr.PullRequestID,
+				ReviewerID:    id,
+				Reason:        string(model.ReasonPRClosed),
+			})
+		}
+		return recordEvents(ctx, s.events, events...)
+	})
+	if err != nil {
+		var appErr *AppError
+		if errors.As(err, &appErr) {
+			return model.PullRequest{}, appErr
+		}
+		if errors.Is(err, repository.ErrPRNotFound) {
+			return model.PullRequest{}, ErrNotFound("pull request not found")
+		}
+		return model.PullRequest{}, &AppError{
+			Code:    "INTERNAL",
+			Message: "failed to close PR",
+			Status:  500,
+			Err:     err,
+		}
+	}
+	return pr, nil
+}
+
+// ReopenPR снова открывает закрытый PR с прежними ревьюверами. Ревьюверы, деактивированные,
+// пока PR был закрыт, заменяются так же, как при деактивации. PR в другом статусе
+// возвращает ошибку PR_NOT_CLOSED.
+func (s *PRService) ReopenPR(ctx context.Context, prID string) (model.PullRequest, error) {
+	if prID == "" {
+		return model.PullRequest{}, ErrBadRequest("pull_request_id is required")
+	}
+
+	var pr model.PullRequest
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prRepo.GetPR(ctx, prID)
+		if err != nil {
+			return err
+		}
+		if pr.Status != model.StatusClosed {
+			return ErrDomain("PR_NOT_CLOSED", "only closed PR can be reopened")
+		}
+		if err := s.prRepo.SetStatus(ctx, prID, model.StatusClosed, model.StatusOpen); err != nil {
+			return err
+		}
+
+		events := make([]model.Event, 0, len(pr.AssignedReviewers))
+		var inactive []string
+		for _, id := range pr.AssignedReviewers {
+			u, err := s.userRepo.GetByUserID(ctx, id)
+			if err != nil {
+				return err
+			}
+			if !u.IsActive {
+				inactive = append(inactive, id)
+				continue
+			}
+			events = append(events, model.Event{
+				Type:          model.EventReviewerAssigned,
+				PullRequestID: pr.PullRequestID,
+				ReviewerID:    id,
+			})
+		}
+		if err := recordEvents(ctx, s.events, events...); err != nil {
+			return err
+		}
+		if len(inactive) > 0 {
+			if _, err := reassignOpenReviews(ctx, s.userRepo, s.prRepo, s.rampUp, s.events, inactive); err != nil {
+				return err
+			}
+		}
+
+		pr, err = s.prRepo.GetPR(ctx, prID)
+		return err
+	})
+	if err != nil {
+		var appErr *AppError
+		if errors.As(err, &appErr) {
+			return model.PullRequest{}, appErr
+		}
+		if errors.Is(err, repository.ErrPRNotFound) {
+			return model.PullRequest{}, ErrNotFound("pull request not found")
+		}
+		return model.PullRequest{}, &AppError{
+			Code:    "INTERNAL",
+			Message: "failed to reopen PR",
+			Status:  500,
+			Err:     err,
+		}
+	}
+	return pr, nil
+}
+
 // ReassignReviewer переназначает одного из текущих ревьюверов PR на другого участника той же команды.
+// Если в команде заменить некем, замена ищется в ближайшей команде-предке.
 // Учитывает статус PR, проверяет, что пользователь был назначен, и выбирает замену случайным образом.
 func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldUserID string) (model.PullRequest, string, error) {
 	if prID == "" || oldUserID == "" {
@@ -155,8 +416,11 @@ func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldUserID string
 		}
 	}
 
-	if pr.Status == model.StatusMerged {
+	switch pr.Status {
+	case model.StatusMerged:
 		return model.PullRequest{}, "", ErrDomain("PR_MERGED", "cannot reassign on merged PR")
+	case model.StatusClosed:
+		return model.PullRequest{}, "", ErrDomain("PR_CLOSED", "cannot reassign on closed PR")
 	}
 
 	assigned := false
@@ -190,7 +454,7 @@ func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldUserID string
 		}
 	}
 
-	candidates, err := s.userRepo.ListActiveTeamMembersExcept(ctx, oldUser.TeamName, exclude)
+	candidates, err := reviewCandidates(ctx, s.userRepo, s.rampUp.forPriority(pr.Priority), oldUser.TeamName, exclude)
 	if err != nil {
 		return model.PullRequest{}, "", &AppError{
 			Code:    "INTERNAL",
@@ -200,18 +464,40 @@ func (s *PRService) ReassignReviewer(ctx context.Context, prID, oldUserID string
 		}
 	}
 	if len(candidates) == 0 {
-		return model.PullRequest{}, "", ErrDomain("NO_CANDIDATE", "no active replacement candidate in team")
+		return model.PullRequest{}, "", ErrDomain("NO_CANDIDATE", "no active replacement candidate in team or its parents")
 	}
 
-	var newReviewer model.User
-	if len(candidates) == 1 {
-		newReviewer = candidates[0]
-	} else {
-		idx := rand.Intn(len(candidates))
-		newReviewer = candidates[idx]
+	candidates, available, err := preferWorkingHours(ctx, s.userRepo, pr.Priority, candidates, 1)
+	if err != nil {
+		return model.PullRequest{}, "", &AppError{
+			Code:    "INTERNAL",
+			Message: "failed to get working hours",
+			Status:  500,
+			Err:     err,
+		}
+	}
+	newReviewer := pickAvailable(candidates, available)
+
+	reason := model.ReasonTeamMember
+	if newReviewer.TeamName != oldUser.TeamName {
+		reason = model.ReasonParentTeamMember
 	}
 
-	updated, err := s.prRepo.ReassignReviewer(ctx, prID, oldUserID, newReviewer.UserID)
+	var updated model.PullRequest
+	err = s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		updated, err = s.prRepo.ReassignReviewer(ctx, prID, oldUserID, newReviewer.UserID)
+		if err != nil {
+			return err
+		}
+		return recordEvents(ctx, s.events, reassignmentEvent(model.ReviewReassignment{
+			PullRequestID: prID,
+			OldReviewerID: oldUserID,
+			NewReviewerID: newReviewer.UserID,
+			Action:        model.ReassignActionReassigned,
+			Reason:        reason,
+		}))
+	})
 	if err != nil {
 		if errors.Is(err, repository.ErrPRNotFound) {
 			return model.PullRequest{}, "", ErrNotFound("pull request not found")
@@ -244,3 +530,148 @@ func (s *PRService) ListAssignedToUser(ctx context.Context, userID string) ([]mo
 	}
 	return prs, nil
 }
+
+// ListAuthoredByUser возвращает PR, автором которых является пользователь, с ревьюверами,
+// состоянием их ревью и возрастом PR и каждого назначения.
+func (s *PRService) ListAuthoredByUser(ctx context.Context, userID string) ([]model.AuthoredPullRequest, error) {
+	if userID == "" {
+		return nil, ErrBadRequest("user_id is required")
+	}
+	prs, err := s.prRepo.ListAuthoredByUser(ctx, userID)
+	if err != nil {
+		return nil, &AppError{
+			Code:    "INTERNAL",
+			Message: "failed to list authored PRs",
+			Status:  500,
+			Err:     err,
+		}
+	}
+
+	now := time.Now().UTC()
+	for i := range prs {
+		prs[i].AgeSeconds = ageSeconds(prs[i].CreatedAt, prs[i].MergedAt, now)
+		for j := range prs[i].Reviewers {
+			rs := &prs[i].Reviewers[j]
+			rs.AgeSeconds = ageSeconds(rs.AssignedAt, rs.ReviewedAt, now)
+		}
+	}
+	return prs, nil
+}
+
+// ageSeconds возвращает длительность в секундах от start до end, а если end не задан — до now.
+func ageSeconds(start time.Time, end *time.Time, now time.Time) int64 {
+	if end != nil {
+		now = *end
+	}
+	if now.Before(start) {
+		return 0
+	}
+	return int64(now.Sub(start).Seconds())
+}
+
+// SubmitReview фиксирует состояние ревью ревьювера в открытом PR и возвращает PR.
+func (s *PRService) SubmitReview(ctx context.Context, prID, reviewerID string, state model.ReviewState) (model.PullRequest, error) {
+	if prID == "" || reviewerID == "" {
+		return model.PullRequest{}, ErrBadRequest("pull_request_id and reviewer_id are required")
+	}
+	if !state.IsValid() {
+		return model.PullRequest{}, ErrBadRequest("state must be one of PENDING, APPROVED, CHANGES_REQUESTED")
+	}
+
+	var pr model.PullRequest
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		pr, err = s.prRepo.GetPR(ctx, prID)
+		if err != nil {
+			return err
+		}
+		switch pr.Status {
+		case model.StatusMerged:
+			return ErrDomain("PR_MERGED", "cannot review merged PR")
+		case model.StatusClosed:
+			return ErrDomain("PR_CLOSED", "cannot review closed PR")
+		}
+		if err := s.prRepo.SetReviewState(ctx, prID, reviewerID, state, time.Now().UTC()); err != nil {
+			return err
+		}
+		return recordEvents(ctx, s.events, model.Event{
+			Type:            model.EventReviewSubmitted,
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Reviewers:       pr.AssignedReviewers,
+			ReviewerID:      reviewerID,
+			ReviewState:     state,
+		})
+	})
+	if err != nil {
+		var appErr *AppError
+		if errors.As(err, &appErr) {
+			return model.PullRequest{}, appErr
+		}
+		if errors.Is(err, repository.ErrPRNotFound) {
+			if pr.PullRequestID == "" {
+				return model.PullRequest{}, ErrNotFound("pull request not found")
+			}
+			return model.PullRequest{}, ErrDomain("NOT_ASSIGNED", "reviewer is not assigned to this PR")
+		}
+		return model.PullRequest{}, &AppError{
+			Code:    "INTERNAL",
+			Message: "failed to submit review",
+			Status:  500,
+			Err:     err,
+		}
+	}
+	return pr, nil
+}
+
+// ListPullRequests возвращает страницу PR по фильтрам и курсор следующей страницы
+// (пустой, если страниц больше нет). По умолчанию PR отсортированы от новых к старым.
+func (s *PRService) ListPullRequests(ctx context.Context, filter model.PullRequestFilter, cursor string) ([]model.PullRequest, string, error) {
+	if filter.Sort == "" {
+		filter.Sort = model.SortCreatedDesc
+	}
+	if !filter.Sort.IsValid() {
+		return nil, "", ErrBadRequest("sort must be one of created_at, -created_at, pull_request_name, -pull_request_name")
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return nil, "", ErrBadRequest("status must be OPEN, MERGED or CLOSED")
+	}
+	if filter.Priority != "" && !filter.Priority.IsValid() {
+		return nil, "", ErrBadRequest("priority must be one of low, normal, high, hotfix")
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		return nil, "", ErrBadRequest("created_from must be before created_to")
+	}
+	if filter.MergedFrom != nil && filter.MergedTo != nil && !filter.MergedFrom.Before(*filter.MergedTo) {
+		return nil, "", ErrBadRequest("merged_from must be before merged_to")
+	}
+
+	limit, err := normalizeLimit(filter.Limit)
+	if err != nil {
+		return nil, "", err
+	}
+	after, err := decodePRCursor(cursor, filter.Sort)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filter.Limit = limit + 1
+	filter.After = after
+	prs, err := s.prRepo.ListPullRequests(ctx, filter)
+	if err != nil {
+		return nil, "", &AppError{
+			Code:    "INTERNAL",
+			Message: "failed to list PRs",
+			Status:  500,
+			Err:     err,
+		}
+	}
+
+	next := ""
+	if len(prs) > limit {
+		prs = prs[:limit]
+		next = encodePRCursor(filter.Sort, prs[limit-1])
+	}
+	return prs, next, nil
+}
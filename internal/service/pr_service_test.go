@@ -3,9 +3,11 @@ package service_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"pull-request-service/internal/model"
 	"pull-request-service/internal/repository"
@@ -24,6 +26,7 @@ func TestPRService_CreatePR(t *testing.T) {
 		input         model.PullRequest
 		setupMocks    func(userRepo *mocks.UserRepository, prRepo *mocks.PRRepository, txManager *mocks.TransactionManager)
 		wantReviewers int
+		wantPriority  model.PullRequestPriority
 		wantErr       bool
 	}{
 		{
@@ -51,8 +54,87 @@ func TestPRService_CreatePR(t *testing.T) {
 					}, nil)
 			},
 			wantReviewers: 2,
+			wantPriority:  model.PriorityNormal,
 			wantErr:       false,
 		},
+		{
+			name: "Success: Hotfix priority is kept",
+			input: model.PullRequest{
+				PullRequestID:   "pr-2",
+				PullRequestName: "Hotfix",
+				AuthorID:        "u1",
+				Priority:        model.PriorityHotfix,
+			},
+			setupMocks: func(userRepo *mocks.UserRepository, prRepo *mocks.PRRepository, txManager *mocks.TransactionManager) {
+				userRepo.On("GetByUserID", mock.Anything, "u1").Return(author, nil)
+
+				userRepo.On("ListActiveTeamMembersExcept", mock.Anything, "backend", []string{"u1"}).
+					Return([]model.User{u2}, nil)
+				userRepo.On("ListActiveAncestorMembersExcept", mock.Anything, "backend", []string{"u1", "u2"}).
+					Return([]model.User{}, nil)
+
+				txManager.On("RunInTransaction", mock.Anything, mock.Anything).
+					Return(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				prRepo.On("CreatePRWithReviewers", mock.Anything, mock.MatchedBy(func(pr model.PullRequest) bool {
+					return pr.Priority == model.PriorityHotfix
+				}), mock.Anything).
+					Return(func(ctx context.Context, pr model.PullRequest, rIDs []string) model.PullRequest {
+						pr.AssignedReviewers = rIDs
+						return pr
+					}, nil)
+			},
+			wantReviewers: 1,
+			wantPriority:  model.PriorityHotfix,
+			wantErr:       false,
+		},
+		{
+			name: "Success: Missing slot filled from parent team",
+			input: model.PullRequest{
+				PullRequestID:   "pr-5",
+				PullRequestName: "Fix",
+				AuthorID:        "u1",
+			},
+			setupMocks: func(userRepo *mocks.UserRepository, prRepo *mocks.PRRepository, txManager *mocks.TransactionManager) {
+				userRepo.On("GetByUserID", mock.Anything, "u1").Return(author, nil)
+
+				userRepo.On("ListActiveTeamMembersExcept", mock.Anything, "backend", []string{"u1"}).
+					Return([]model.User{u2}, nil)
+				// В команде один кандидат — второй берётся из родительской команды
+				userRepo.On("ListActiveAncestorMembersExcept", mock.Anything, "backend", []string{"u1", "u2"}).
+					Return([]model.User{{UserID: "u10", TeamName: "engineering", IsActive: true}}, nil)
+
+				txManager.On("RunInTransaction", mock.Anything, mock.Anything).
+					Return(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+
+				prRepo.On("CreatePRWithReviewers", mock.Anything, mock.AnythingOfType("model.PullRequest"), []string{"u2", "u10"}).
+					Return(func(ctx context.Context, pr model.PullRequest, rIDs []string) model.PullRequest {
+						pr.AssignedReviewers = rIDs
+						return pr
+					}, nil)
+			},
+			wantReviewers: 2,
+			wantPriority:  model.PriorityNormal,
+			wantErr:       false,
+		},
+		{
+			name: "Fail: Unknown priority",
+			input: model.PullRequest{
+				PullRequestID:   "pr-4",
+				PullRequestName: "Fix",
+				AuthorID:        "u1",
+				Priority:        "urgent",
+			},
+			setupMocks: func(userRepo *mocks.UserRepository, prRepo *mocks.PRRepository, txManager *mocks.TransactionManager) {
+				// Репозитории не должны вызываться
+			},
+			wantReviewers: 0,
+			wantErr:       true,
+		},
 		{
 			name: "Fail: Author not found",
 			input: model.PullRequest{
@@ -87,6 +169,7 @@ func TestPRService_CreatePR(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Len(t, got.AssignedReviewers, tt.wantReviewers)
 				assert.NotContains(t, got.AssignedReviewers, tt.input.AuthorID, "Author should not be a reviewer")
+				assert.Equal(t, tt.wantPriority, got.Priority)
 			}
 
 			userRepo.AssertExpectations(t)
@@ -95,3 +178,618 @@ func TestPRService_CreatePR(t *testing.T) {
 		})
 	}
 }
+
+func TestPRService_ListPullRequests(t *testing.T) {
+	t1 := time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC)
+	t2 := time.Date(2025, 10, 2, 10, 0, 0, 0, time.UTC)
+	page := []model.PullRequest{
+		{PullRequestID: "pr-2", PullRequestName: "B", CreatedAt: &t2, AssignedReviewers: []string{"u2"}},
+		{PullRequestID: "pr-1", PullRequestName: "A", CreatedAt: &t1, AssignedReviewers: []string{"u3"}},
+	}
+
+	prRepo := new(mocks.PRRepository)
+	svc := service.NewPRService(prRepo, new(mocks.UserRepository), new(mocks.TransactionManager))
+
+	// Первая страница: запрашиваем на один PR больше лимита, получаем курсор
+	prRepo.On("ListPullRequests", mock.Anything, mock.MatchedBy(func(f model.PullRequestFilter) bool {
+		return f.After == nil && f.Limit == 2 && f.Sort == model.SortCreatedDesc && f.Status == model.StatusOpen
+	})).Return(page, nil).Once()
+
+	got, next, err := svc.ListPullRequests(context.Background(), model.PullRequestFilter{Status: model.StatusOpen, Limit: 1}, "")
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.NotEmpty(t, next)
+
+	// Вторая страница: курсор превращается в ключ последнего PR
+	prRepo.On("ListPullRequests", mock.Anything, mock.MatchedBy(func(f model.PullRequestFilter) bool {
+		return f.After != nil && f.After.PullRequestID == "pr-2" && f.After.CreatedAt.Equal(t2)
+	})).Return(page[1:], nil).Once()
+
+	got, next, err = svc.ListPullRequests(context.Background(), model.PullRequestFilter{Status: model.StatusOpen, Limit: 1}, next)
+	assert.NoError(t, err)
+	assert.Equal(t, "pr-1", got[0].PullRequestID)
+	assert.Empty(t, next)
+
+	// Курсор другой сортировки и неизвестный статус отклоняются без похода в репозиторий
+	_, _, err = svc.ListPullRequests(context.Background(), model.PullRequestFilter{Sort: model.SortNameAsc}, "eyJpZCI6InByLTIifQ")
+	assert.Error(t, err)
+	_, _, err = svc.ListPullRequests(context.Background(), model.PullRequestFilter{Status: "DRAFT"}, "")
+	assert.Error(t, err)
+
+	prRepo.AssertExpectations(t)
+}
+
+func TestPRService_ListAuthoredByUser(t *testing.T) {
+	created := time.Now().UTC().Add(-2 * time.Hour)
+	merged := created.Add(90 * time.Minute)
+	reviewed := created.Add(30 * time.Minute)
+
+	prRepo := new(mocks.PRRepository)
+	prRepo.On("ListAuthoredByUser", mock.Anything, "u1").Return([]model.AuthoredPullRequest{
+		{
+			PullRequestID: "pr-1",
+			Status:        model.StatusMerged,
+			CreatedAt:     created,
+			MergedAt:      &merged,
+			Reviewers: []model.ReviewerState{
+				{ReviewerID: "u2", State: model.ReviewApproved, AssignedAt: created, ReviewedAt: &reviewed},
+				{ReviewerID: "u3", State: model.ReviewPending, AssignedAt: created},
+			},
+		},
+	}, nil)
+
+	svc := service.NewPRService(prRepo, new(mocks.UserRepository), new(mocks.TransactionManager))
+	prs, err := svc.ListAuthoredByUser(context.Background(), "u1")
+
+	assert.NoError(t, err)
+	assert.Len(t, prs, 1)
+	// Возраст влитого PR считается до мержа, ревью — до момента ревью
+	assert.Equal(t, int64(90*60), prs[0].AgeSeconds)
+	assert.Equal(t, int64(30*60), prs[0].Reviewers[0].AgeSeconds)
+	// Ожидающее ревью стареет до текущего момента
+	assert.InDelta(t, int64(2*60*60), prs[0].Reviewers[1].AgeSeconds, 5)
+	prRepo.AssertExpectations(t)
+}
+
+func TestPRService_SubmitReview(t *testing.T) {
+	tests := []struct {
+		name       string
+		setupMocks func(prRepo *mocks.PRRepository, txManager *mocks.TransactionManager, events *mocks.EventRecorder)
+		wantCode   string
+	}{
+		{
+			name: "Success: Approved",
+			setupMocks: func(prRepo *mocks.PRRepository, txManager *mocks.TransactionManager, events *mocks.EventRecorder) {
+				txManager.On("RunInTransaction", mock.Anything, mock.Anything).
+					Return(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				prRepo.On("GetPR", mock.Anything, "pr-1").
+					Return(model.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: model.StatusOpen, AssignedReviewers: []string{"u2", "u3"}}, nil)
+				prRepo.On("SetReviewState", mock.Anything, "pr-1", "u2", model.ReviewApproved, mock.AnythingOfType("time.Time")).
+					Return(nil)
+				events.On("RecordEvents", mock.Anything, mock.MatchedBy(func(evs []model.Event) bool {
+					return len(evs) == 1 && evs[0].Type == model.EventReviewSubmitted &&
+						evs[0].ReviewerID == "u2" && evs[0].ReviewState == model.ReviewApproved &&
+						evs[0].AuthorID == "u1" && len(evs[0].Reviewers) == 2
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Fail: Merged PR",
+			setupMocks: func(prRepo *mocks.PRRepository, txManager *mocks.TransactionManager, events *mocks.EventRecorder) {
+				txManager.On("RunInTransaction", mock.Anything, mock.Anything).
+					Return(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				prRepo.On("GetPR", mock.Anything, "pr-1").
+					Return(model.PullRequest{PullRequestID: "pr-1", Status: model.StatusMerged}, nil)
+			},
+			wantCode: "PR_MERGED",
+		},
+		{
+			name: "Fail: Reviewer not assigned",
+			setupMocks: func(prRepo *mocks.PRRepository, txManager *mocks.TransactionManager, events *mocks.EventRecorder) {
+				txManager.On("RunInTransaction", mock.Anything, mock.Anything).
+					Return(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				prRepo.On("GetPR", mock.Anything, "pr-1").
+					Return(model.PullRequest{PullRequestID: "pr-1", Status: model.StatusOpen}, nil)
+				prRepo.On("SetReviewState", mock.Anything, "pr-1", "u2", model.ReviewApproved, mock.AnythingOfType("time.Time")).
+					Return(repository.ErrPRNotFound)
+			},
+			wantCode: "NOT_ASSIGNED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := new(mocks.PRRepository)
+			txManager := new(mocks.TransactionManager)
+			events := new(mocks.EventRecorder)
+			tt.setupMocks(prRepo, txManager, events)
+
+			svc := service.NewPRService(prRepo, new(mocks.UserRepository), txManager)
+			svc.SetEventRecorder(events)
+			_, err := svc.SubmitReview(context.Background(), "pr-1", "u2", model.ReviewApproved)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+			}
+			prRepo.AssertExpectations(t)
+			events.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPRService_CreatePR_RampUp(t *testing.T) {
+	author := model.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	members := []model.User{
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+		{UserID: "u3", TeamName: "backend", IsActive: true},
+		{UserID: "u4", TeamName: "backend", IsActive: true},
+	}
+
+	tests := []struct {
+		name          string
+		priority      model.PullRequestPriority
+		policy        service.RampUpPolicy
+		wantRampUp    bool
+		wantReviewers []string
+	}{
+		{
+			name:          "Exhausted returning user is skipped",
+			priority:      model.PriorityNormal,
+			policy:        service.RampUpPolicy{Days: 7, MaxAssignments: 1},
+			wantRampUp:    true,
+			wantReviewers: []string{"u3", "u4"},
+		},
+		{
+			name:          "Hotfix bypasses ramp-up limit",
+			priority:      model.PriorityHotfix,
+			policy:        service.RampUpPolicy{Days: 7, MaxAssignments: 1},
+			wantReviewers: []string{"u2", "u3"},
+		},
+		{
+			name:          "Days without limit does not restrict",
+			priority:      model.PriorityNormal,
+			policy:        service.RampUpPolicy{Days: 7},
+			wantReviewers: []string{"u2", "u3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(mocks.UserRepository)
+			prRepo := new(mocks.PRRepository)
+			txManager := new(mocks.TransactionManager)
+
+			userRepo.On("GetByUserID", mock.Anything, "u1").Return(author, nil)
+			userRepo.On("ListActiveTeamMembersExcept", mock.Anything, "backend", []string{"u1"}).Return(members, nil)
+			if tt.wantRampUp {
+				// u2 вернулся и уже получил своё ревью за период разгона, u3 вернулся, но лимит не исчерпан
+				userRepo.On("ListRampUp", mock.Anything, []string{"u2", "u3", "u4"}, mock.AnythingOfType("time.Time")).
+					Return([]model.UserRampUp{
+						{UserID: "u2", ActiveSince: time.Now().Add(-24 * time.Hour), AssignedSince: 1},
+						{UserID: "u3", ActiveSince: time.Now().Add(-24 * time.Hour), AssignedSince: 0},
+					}, nil)
+			}
+			if tt.priority == model.PriorityHotfix {
+				userRepo.On("ListWorkingHours", mock.Anything, []string{"u2", "u3", "u4"}).Return([]model.WorkingHours{}, nil)
+			}
+			txManager.On("RunInTransaction", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+			prRepo.On("CreatePRWithReviewers", mock.Anything, mock.AnythingOfType("model.PullRequest"), tt.wantReviewers).
+				Return(func(ctx context.Context, pr model.PullRequest, rIDs []string) model.PullRequest {
+					pr.AssignedReviewers = rIDs
+					return pr
+				}, nil)
+
+			svc := service.NewPRService(prRepo, userRepo, txManager)
+			svc.SetRampUpPolicy(tt.policy)
+
+			got, err := svc.CreatePR(context.Background(), model.PullRequest{
+				PullRequestID: "pr-1", PullRequestName: "Fix", AuthorID: "u1", Priority: tt.priority,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantReviewers, got.AssignedReviewers)
+			userRepo.AssertExpectations(t)
+			prRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// shiftAround возвращает рабочие часы в UTC, в которые текущий момент попадает (onShift) или нет,
+// с запасом в час от границ смены.
+func shiftAround(userID string, onShift bool) model.WorkingHours {
+	hour := time.Now().UTC().Hour()
+	if onShift {
+		return model.WorkingHours{UserID: userID, TimeZone: "UTC", StartHour: (hour + 23) % 24, EndHour: (hour + 2) % 24}
+	}
+	return model.WorkingHours{UserID: userID, TimeZone: "UTC", StartHour: (hour + 6) % 24, EndHour: (hour + 9) % 24}
+}
+
+func TestPRService_CreatePR_WorkingHours(t *testing.T) {
+	author := model.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	members := []model.User{
+		{UserID: "u2", TeamName: "backend", IsActive: true},
+		{UserID: "u3", TeamName: "backend", IsActive: true},
+		{UserID: "u4", TeamName: "backend", IsActive: true},
+	}
+
+	tests := []struct {
+		name          string
+		priority      model.PullRequestPriority
+		hours         []model.WorkingHours
+		wantReviewers []string
+	}{
+		{
+			name:          "Hotfix prefers reviewers on shift",
+			priority:      model.PriorityHotfix,
+			hours:         []model.WorkingHours{shiftAround("u2", false), shiftAround("u3", true)},
+			wantReviewers: []string{"u3", "u4"},
+		},
+		{
+			name:          "Hotfix falls back to off-shift reviewers",
+			priority:      model.PriorityHotfix,
+			hours:         []model.WorkingHours{shiftAround("u2", false), shiftAround("u3", false), shiftAround("u4", true)},
+			wantReviewers: []string{"u4", "u2"},
+		},
+		{
+			name:     "Shift wrapping past midnight excludes its off hours",
+			priority: model.PriorityHotfix,
+			hours: []model.WorkingHours{
+				shiftAround("u2", false),
+				{UserID: "u3", TimeZone: "UTC", StartHour: (time.Now().UTC().Hour() + 1) % 24, EndHour: time.Now().UTC().Hour()},
+			},
+			wantReviewers: []string{"u4", "u2"},
+		},
+		{
+			name:          "Normal priority ignores working hours",
+			priority:      model.PriorityNormal,
+			wantReviewers: []string{"u2", "u3"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(mocks.UserRepository)
+			prRepo := new(mocks.PRRepository)
+			txManager := new(mocks.TransactionManager)
+
+			userRepo.On("GetByUserID", mock.Anything, "u1").Return(author, nil)
+			userRepo.On("ListActiveTeamMembersExcept", mock.Anything, "backend", []string{"u1"}).Return(members, nil)
+			if tt.hours != nil {
+				userRepo.On("ListWorkingHours", mock.Anything, []string{"u2", "u3", "u4"}).Return(tt.hours, nil)
+			}
+			txManager.On("RunInTransaction", mock.Anything, mock.Anything).
+				Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+			prRepo.On("CreatePRWithReviewers", mock.Anything, mock.AnythingOfType("model.PullRequest"), tt.wantReviewers).
+				Return(func(ctx context.Context, pr model.PullRequest, rIDs []string) model.PullRequest {
+					pr.AssignedReviewers = rIDs
+					return pr
+				}, nil)
+
+			svc := service.NewPRService(prRepo, userRepo, txManager)
+
+			got, err := svc.CreatePR(context.Background(), model.PullRequest{
+				PullRequestID: "pr-1", PullRequestName: "Fix", AuthorID: "u1", Priority: tt.priority,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantReviewers, got.AssignedReviewers)
+			userRepo.AssertExpectations(t)
+			prRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPRService_ReassignReviewer_WorkingHours(t *testing.T) {
+	userRepo := new(mocks.UserRepository)
+	prRepo := new(mocks.PRRepository)
+	txManager := new(mocks.TransactionManager)
+
+	prRepo.On("GetPR", mock.Anything, "pr-1").Return(model.PullRequest{
+		PullRequestID: "pr-1", AuthorID: "u1", Status: model.StatusOpen, Priority: model.PriorityHotfix,
+		AssignedReviewers: []string{"u2"},
+	}, nil)
+	userRepo.On("GetByUserID", mock.Anything, "u2").Return(model.User{UserID: "u2", TeamName: "backend"}, nil)
+	userRepo.On("ListActiveTeamMembersExcept", mock.Anything, "backend", []string{"u2", "u1"}).
+		Return([]model.User{
+			{UserID: "u3", TeamName: "backend", IsActive: true},
+			{UserID: "u4", TeamName: "backend", IsActive: true},
+		}, nil)
+	userRepo.On("ListWorkingHours", mock.Anything, []string{"u3", "u4"}).
+		Return([]model.WorkingHours{shiftAround("u3", false), shiftAround("u4", true)}, nil)
+	txManager.On("RunInTransaction", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(context.Context) error) error {
+			return fn(ctx)
+		})
+	prRepo.On("ReassignReviewer", mock.Anything, "pr-1", "u2", "u4").
+		Return(model.PullRequest{PullRequestID: "pr-1", AssignedReviewers: []string{"u4"}}, nil)
+
+	svc := service.NewPRService(prRepo, userRepo, txManager)
+
+	_, replacedBy, err := svc.ReassignReviewer(context.Background(), "pr-1", "u2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "u4", replacedBy)
+	userRepo.AssertExpectations(t)
+	prRepo.AssertExpectations(t)
+}
+
+func TestPRService_ReassignReviewer_RampUp(t *testing.T) {
+	tests := []struct {
+		name     string
+		priority model.PullRequestPriority
+		wantCode string
+	}{
+		{
+			name:     "Only candidate is exhausted returning user",
+			priority: model.PriorityHigh,
+			wantCode: "NO_CANDIDATE",
+		},
+		{
+			name:     "Hotfix reassigns to ramping-up user",
+			priority: model.PriorityHotfix,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := new(mocks.UserRepository)
+			prRepo := new(mocks.PRRepository)
+			txManager := new(mocks.TransactionManager)
+
+			prRepo.On("GetPR", mock.Anything, "pr-1").Return(model.PullRequest{
+				PullRequestID: "pr-1", AuthorID: "u1", Status: model.StatusOpen, Priority: tt.priority,
+				AssignedReviewers: []string{"u2"},
+			}, nil)
+			userRepo.On("GetByUserID", mock.Anything, "u2").Return(model.User{UserID: "u2", TeamName: "backend"}, nil)
+			userRepo.On("ListActiveTeamMembersExcept", mock.Anything, "backend", []string{"u2", "u1"}).
+				Return([]model.User{{UserID: "u3", TeamName: "backend", IsActive: true}}, nil)
+			if tt.wantCode != "" {
+				userRepo.On("ListRampUp", mock.Anything, []string{"u3"}, mock.AnythingOfType("time.Time")).
+					Return([]model.UserRampUp{{UserID: "u3", ActiveSince: time.Now().Add(-time.Hour), AssignedSince: 1}}, nil)
+				userRepo.On("ListActiveAncestorMembersExcept", mock.Anything, "backend", []string{"u2", "u1"}).
+					Return([]model.User{}, nil)
+			} else {
+				txManager.On("RunInTransaction", mock.Anything, mock.Anything).
+					Return(func(ctx context.Context, fn func(context.Context) error) error {
+						return fn(ctx)
+					})
+				prRepo.On("ReassignReviewer", mock.Anything, "pr-1", "u2", "u3").
+					Return(model.PullRequest{PullRequestID: "pr-1", AssignedReviewers: []string{"u3"}}, nil)
+			}
+
+			svc := service.NewPRService(prRepo, userRepo, txManager)
+			svc.SetRampUpPolicy(service.RampUpPolicy{Days: 7, MaxAssignments: 1})
+
+			_, replacedBy, err := svc.ReassignReviewer(context.Background(), "pr-1", "u2")
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "u3", replacedBy)
+			}
+			userRepo.AssertExpectations(t)
+			prRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPRService_MergePR_Events(t *testing.T) {
+	earlier := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		setupMocks func(prRepo *mocks.PRRepository, events *mocks.EventRecorder)
+	}{
+		{
+			name: "First merge records pull_request.merged",
+			setupMocks: func(prRepo *mocks.PRRepository, events *mocks.EventRecorder) {
+				prRepo.On("MarkMerged", mock.Anything, "pr-1", mock.AnythingOfType("time.Time")).
+					Return(func(_ context.Context, id string, at time.Time) model.PullRequest {
+						return model.PullRequest{PullRequestID: id, AuthorID: "u1", Status: model.StatusMerged, MergedAt: &at}
+					}, nil)
+				events.On("RecordEvents", mock.Anything, mock.MatchedBy(func(evs []model.Event) bool {
+					return len(evs) == 1 && evs[0].Type == model.EventPRMerged &&
+						evs[0].PullRequestID == "pr-1" && evs[0].ID != ""
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Repeated merge records nothing",
+			setupMocks: func(prRepo *mocks.PRRepository, events *mocks.EventRecorder) {
+				prRepo.On("MarkMerged", mock.Anything, "pr-1", mock.AnythingOfType("time.Time")).
+					Return(model.PullRequest{PullRequestID: "pr-1", Status: model.StatusMerged, MergedAt: &earlier}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := new(mocks.PRRepository)
+			events := new(mocks.EventRecorder)
+			txManager := new(mocks.TransactionManager)
+			runInTx(txManager)
+			tt.setupMocks(prRepo, events)
+
+			svc := service.NewPRService(prRepo, new(mocks.UserRepository), txManager)
+			svc.SetEventRecorder(events)
+			pr, err := svc.MergePR(context.Background(), "pr-1")
+
+			assert.NoError(t, err)
+			assert.Equal(t, model.StatusMerged, pr.Status)
+			prRepo.AssertExpectations(t)
+			events.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPRService_ClosePR(t *testing.T) {
+	open := model.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: model.StatusOpen, AssignedReviewers: []string{"u2", "u3"}}
+
+	tests := []struct {
+		name       string
+		setupMocks func(prRepo *mocks.PRRepository, events *mocks.EventRecorder)
+		wantCode   string
+	}{
+		{
+			name: "Success: Reviewers are released",
+			setupMocks: func(prRepo *mocks.PRRepository, events *mocks.EventRecorder) {
+				prRepo.On("GetPR", mock.Anything, "pr-1").Return(open, nil)
+				prRepo.On("SetStatus", mock.Anything, "pr-1", model.StatusOpen, model.StatusClosed).Return(nil)
+				events.On("RecordEvents", mock.Anything, mock.MatchedBy(func(evs []model.Event) bool {
+					return len(evs) == 2 && evs[0].Type == model.EventReviewerRemoved &&
+						evs[0].ReviewerID == "u2" && evs[1].ReviewerID == "u3" &&
+						evs[1].Reason == string(model.ReasonPRClosed)
+				})).Return(nil)
+			},
+		},
+		{
+			name: "Success: Already closed",
+			setupMocks: func(prRepo *mocks.PRRepository, _ *mocks.EventRecorder) {
+				closed := open
+				closed.Status = model.StatusClosed
+				prRepo.On("GetPR", mock.Anything, "pr-1").Return(closed, nil)
+			},
+		},
+		{
+			name: "Fail: Merged PR",
+			setupMocks: func(prRepo *mocks.PRRepository, _ *mocks.EventRecorder) {
+				prRepo.On("GetPR", mock.Anything, "pr-1").Return(model.PullRequest{PullRequestID: "pr-1", Status: model.StatusMerged}, nil)
+			},
+			wantCode: "PR_MERGED",
+		},
+		{
+			name: "Fail: Unknown PR",
+			setupMocks: func(prRepo *mocks.PRRepository, _ *mocks.EventRecorder) {
+				prRepo.On("GetPR", mock.Anything, "pr-1").Return(model.PullRequest{}, repository.ErrPRNotFound)
+			},
+			wantCode: "NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := new(mocks.PRRepository)
+			events := new(mocks.EventRecorder)
+			txManager := new(mocks.TransactionManager)
+			runInTx(txManager)
+			tt.setupMocks(prRepo, events)
+
+			svc := service.NewPRService(prRepo, new(mocks.UserRepository), txManager)
+			svc.SetEventRecorder(events)
+			pr, err := svc.ClosePR(context.Background(), "pr-1")
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, model.StatusClosed, pr.Status)
+			}
+			prRepo.AssertExpectations(t)
+			events.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPRService_ReopenPR(t *testing.T) {
+	closed := model.PullRequest{PullRequestID: "pr-1", AuthorID: "u1", Status: model.StatusClosed, AssignedReviewers: []string{"u2", "u3"}}
+	reopened := closed
+	reopened.Status = model.StatusOpen
+
+	tests := []struct {
+		name       string
+		setupMocks func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, events *mocks.EventRecorder)
+		wantCode   string
+	}{
+		{
+			name: "Success: Previous reviewers are restored",
+			setupMocks: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, events *mocks.EventRecorder) {
+				prRepo.On("GetPR", mock.Anything, "pr-1").Return(closed, nil).Once()
+				prRepo.On("SetStatus", mock.Anything, "pr-1", model.StatusClosed, model.StatusOpen).Return(nil)
+				userRepo.On("GetByUserID", mock.Anything, "u2").Return(model.User{UserID: "u2", TeamName: "backend", IsActive: true}, nil)
+				userRepo.On("GetByUserID", mock.Anything, "u3").Return(model.User{UserID: "u3", TeamName: "backend", IsActive: true}, nil)
+				events.On("RecordEvents", mock.Anything, mock.MatchedBy(func(evs []model.Event) bool {
+					return len(evs) == 2 && evs[0].Type == model.EventReviewerAssigned && evs[1].ReviewerID == "u3"
+				})).Return(nil)
+				prRepo.On("GetPR", mock.Anything, "pr-1").Return(reopened, nil).Once()
+			},
+		},
+		{
+			name: "Success: Deactivated reviewer is replaced",
+			setupMocks: func(prRepo *mocks.PRRepository, userRepo *mocks.UserRepository, events *mocks.EventRecorder) {
+				prRepo.On("GetPR", mock.Anything, "pr-1").Return(closed, nil).Once()
+				prRepo.On("SetStatus", mock.Anything, "pr-1", model.StatusClosed, model.StatusOpen).Return(nil)
+				userRepo.On("GetByUserID", mock.Anything, "u2").Return(model.User{UserID: "u2", TeamName: "backend", IsActive: true}, nil)
+				userRepo.On("GetByUserID", mock.Anything, "u3").Return(model.User{UserID: "u3", TeamName: "backend"}, nil)
+				events.On("RecordEvents", mock.Anything, mock.MatchedBy(func(evs []model.Event) bool {
+					return len(evs) == 1 && evs[0].Type == model.EventReviewerAssigned && evs[0].ReviewerID == "u2"
+				})).Return(nil).Once()
+
+				prRepo.On("GetOpenPRsByReviewers", mock.Anything, []string{"u3"}).Return(map[string][]string{"u3": {"pr-1"}}, nil)
+				prRepo.On("GetPR", mock.Anything, "pr-1").Return(reopened, nil).Once()
+				userRepo.On("ListActiveTeamMembersExcept", mock.Anything, "backend", []string{"u1", "u2", "u3", "u3"}).
+					Return([]model.User{{UserID: "u4", TeamName: "backend", IsActive: true}}, nil)
+				prRepo.On("ReassignReviewer", mock.Anything, "pr-1", "u3", "u4").Return(model.PullRequest{}, nil)
+				events.On("RecordEvents", mock.Anything, mock.MatchedBy(func(evs []model.Event) bool {
+					return len(evs) == 1 && evs[0].ReviewerID == "u4" && evs[0].ReplacedReviewerID == "u3"
+				})).Return(nil).Once()
+				prRepo.On("GetPR", mock.Anything, "pr-1").Return(model.PullRequest{
+					PullRequestID: "pr-1", Status: model.StatusOpen, AssignedReviewers: []string{"u2", "u4"},
+				}, nil).Once()
+			},
+		},
+		{
+			name: "Fail: Open PR",
+			setupMocks: func(prRepo *mocks.PRRepository, _ *mocks.UserRepository, _ *mocks.EventRecorder) {
+				prRepo.On("GetPR", mock.Anything, "pr-1").Return(reopened, nil)
+			},
+			wantCode: "PR_NOT_CLOSED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prRepo := new(mocks.PRRepository)
+			userRepo := new(mocks.UserRepository)
+			events := new(mocks.EventRecorder)
+			txManager := new(mocks.TransactionManager)
+			runInTx(txManager)
+			tt.setupMocks(prRepo, userRepo, events)
+
+			svc := service.NewPRService(prRepo, userRepo, txManager)
+			svc.SetEventRecorder(events)
+			pr, err := svc.ReopenPR(context.Background(), "pr-1")
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				require.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, model.StatusOpen, pr.Status)
+			}
+			prRepo.AssertExpectations(t)
+			userRepo.AssertExpectations(t)
+			events.AssertExpectations(t)
+		})
+	}
+}
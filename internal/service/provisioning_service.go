@@ -0,0 +1,387 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/repository"
+)
+
+// ProvisioningService управляет пользователями и командами от имени внешнего
+// провайдера учётных записей (SCIM): провайдер присылает желаемое состояние ресурса,
+// а сервис приводит к нему БД теми же шагами, что и ручные операции, —
+// с переназначением открытых ревью при деактивации и смене команды.
+type ProvisioningService struct {
+	teamRepo  TeamRepository
+	userRepo  UserRepository
+	prRepo    PRRepository
+	txManager TransactionManager
+	rampUp    RampUpPolicy
+	events    EventRecorder
+}
+
+// NewProvisioningService создаёт новый сервис провижининга.
+func NewProvisioningService(
+	teamRepo TeamRepository,
+	userRepo UserRepository,
+	prRepo PRRepository,
+	txManager TransactionManager,
+) *ProvisioningService {
+	return &ProvisioningService{
+		teamRepo:  teamRepo,
+		userRepo:  userRepo,
+		prRepo:    prRepo,
+		txManager: txManager,
+	}
+}
+
+// SetRampUpPolicy задаёт ограничение назначений для вернувшихся пользователей
+// при переназначении ревью.
+func (s *ProvisioningService) SetRampUpPolicy(p RampUpPolicy) {
+	s.rampUp = p
+}
+
+// SetEventRecorder задаёт получателя событий о ревью, переназначенных при провижининге.
+func (s *ProvisioningService) SetEventRecorder(r EventRecorder) {
+	s.events = r
+}
+
+// ListUsers возвращает страницу пользователей по фильтрам (Offset/Limit) и общее число подходящих.
+// При Limit = 0 считается только общее число.
+func (s *ProvisioningService) ListUsers(ctx context.Context, filter model.UserFilter) ([]model.User, int, error) {
+	if filter.Offset < 0 || filter.Limit < 0 {
+		return nil, 0, ErrBadRequest("offset and limit must not be negative")
+	}
+
+	total, err := s.userRepo.CountUsers(ctx, filter)
+	if err != nil {
+		return nil, 0, &AppError{Code: "INTERNAL", Message: "failed to count users", Status: 500, Err: err}
+	}
+	if filter.Limit == 0 || filter.Offset >= total {
+		return make([]model.User, 0), total, nil
+	}
+
+	users, err := s.userRepo.ListUsers(ctx, filter)
+	if err != nil {
+		return nil, 0, &AppError{Code: "INTERNAL", Message: "failed to list users", Status: 500, Err: err}
+	}
+	return users, total, nil
+}
+
+// GetUser возвращает пользователя по user_id.
+func (s *ProvisioningService) GetUser(ctx context.Context, userID string) (model.User, error) {
+	if userID == "" {
+		return model.User{}, ErrBadRequest("user_id is required")
+	}
+	user, err := s.userRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return model.User{}, membershipError(err, "failed to get user")
+	}
+	return user, nil
+}
+
+// CreateUser создаёт пользователя и, если указана TeamName, сразу включает его в команду.
+// Занятый user_id возвращает USER_EXISTS.
+func (s *ProvisioningService) CreateUser(ctx context.Context, u model.User) (model.User, error) {
+	if u.UserID == "" {
+		return model.User{}, ErrBadRequest("user_id is required")
+	}
+	if u.Username == "" {
+		return model.User{}, ErrBadRequest("username is required")
+	}
+
+	var user model.User
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.CreateUser(ctx, model.User{UserID: u.UserID, Username: u.Username, IsActive: u.IsActive}); err != nil {
+			return err
+		}
+		if u.TeamName != "" {
+			if err := s.teamRepo.MoveMembers(ctx, u.TeamName, []string{u.UserID}); err != nil {
+				return err
+			}
+		}
+
+		var err error
+		user, err = s.userRepo.GetByUserID(ctx, u.UserID)
+		return err
+	})
+	if err != nil {
+		return model.User{}, membershipError(err, "failed to create user")
+	}
+	return user, nil
+}
+
+// ReplaceUser приводит username, команду и активность пользователя к u.
+// При деактивации или уходе из команды открытые ревью пользователя переназначаются
+// так же, как в MassDeactivate; затронутые ревью возвращаются вторым значением.
+// Пустая TeamName исключает пользователя из команды.
+func (s *ProvisioningService) ReplaceUser(ctx context.Context, u model.User) (model.User, []model.ReviewReassignment, error) {
+	if u.UserID == "" {
+		return model.User{}, nil, ErrBadRequest("user_id is required")
+	}
+	if u.Username == "" {
+		return model.User{}, nil, ErrBadRequest("username is required")
+	}
+
+	var (
+		user          model.User
+		reassignments = make([]model.ReviewReassignment, 0)
+	)
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.userRepo.GetByUserID(ctx, u.UserID)
+		if err != nil {
+			return err
+		}
+
+		if u.Username != current.Username {
+			if err := s.userRepo.SetUsername(ctx, u.UserID, u.Username); err != nil {
+				return err
+			}
+		}
+		if u.IsActive != current.IsActive {
+			if _, err := s.userRepo.SetIsActive(ctx, u.UserID, u.IsActive); err != nil {
+				return err
+			}
+		}
+
+		// Ревью передаются до смены команды, пока замена ищется среди прежних коллег
+		teamChanged := u.TeamName != current.TeamName
+		if teamChanged || (current.IsActive && !u.IsActive) {
+			reassignments, err = reassignOpenReviews(ctx, s.userRepo, s.prRepo, s.rampUp, s.events, []string{u.UserID})
+			if err != nil {
+				return err
+			}
+		}
+		if teamChanged {
+			if u.TeamName == "" {
+				err = s.teamRepo.RemoveMembers(ctx, current.TeamName, []string{u.UserID})
+			} else {
+				err = s.teamRepo.MoveMembers(ctx, u.TeamName, []string{u.UserID})
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		user, err = s.userRepo.GetByUserID(ctx, u.UserID)
+		return err
+	})
+	if err != nil {
+		return model.User{}, nil, membershipError(err, "failed to update user")
+	}
+	return user, reassignments, nil
+}
+
+// ListGroups возвращает страницу неархивных команд с участниками и общее число подходящих.
+// Непустой name оставляет только команду с таким именем (без учёта регистра).
+func (s *ProvisioningService) ListGroups(ctx context.Context, name string, offset, limit int) ([]model.Team, int, error) {
+	if offset < 0 || limit < 0 {
+		return nil, 0, ErrBadRequest("offset and limit must not be negative")
+	}
+
+	teams, err := s.teamRepo.ListRoster(ctx)
+	if err != nil {
+		return nil, 0, &AppError{Code: "INTERNAL", Message: "failed to list teams", Status: 500, Err: err}
+	}
+	if name != "" {
+		matched := make([]model.Team, 0, 1)
+		for _, t := range teams {
+			if strings.EqualFold(t.TeamName, name) {
+				matched = append(matched, t)
+			}
+		}
+		teams = matched
+	}
+
+	total := len(teams)
+	if offset >= total {
+		return make([]model.Team, 0), total, nil
+	}
+	teams = teams[offset:]
+	if len(teams) > limit {
+		teams = teams[:limit]
+	}
+	return teams, total, nil
+}
+
+// GetGroup возвращает неархивную команду с участниками.
+func (s *ProvisioningService) GetGroup(ctx context.Context, name string) (model.Team, error) {
+	if name == "" {
+		return model.Team{}, ErrBadRequest("team_name is required")
+	}
+	team, err := s.teamRepo.GetTeamByName(ctx, name)
+	if err != nil {
+		return model.Team{}, membershipError(err, "failed to get team")
+	}
+	if team.ArchivedAt != nil {
+		return model.Team{}, ErrNotFound("team not found")
+	}
+	return team, nil
+}
+
+// CreateGroup создаёт команду и переводит в неё существующих пользователей userIDs
+// (из прежних команд — с переназначением их открытых ревью).
+func (s *ProvisioningService) CreateGroup(ctx context.Context, name string, userIDs []string) (model.Team, error) {
+	if name == "" {
+		return model.Team{}, ErrBadRequest("team_name is required")
+	}
+
+	var team model.Team
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.teamRepo.CreateTeamWithMembers(ctx, model.Team{TeamName: name}); err != nil {
+			return err
+		}
+		if err := s.addToGroup(ctx, name, userIDs); err != nil {
+			return err
+		}
+
+		var err error
+		team, err = s.teamRepo.GetTeamByName(ctx, name)
+		return err
+	})
+	if err != nil {
+		return model.Team{}, membershipError(err, "failed to create team")
+	}
+	return team, nil
+}
+
+// SetGroupMembers приводит состав команды к userIDs: недостающие пользователи переводятся
+// в команду, лишние исключаются из неё. Открытые ревью всех, кто сменил команду, переназначаются.
+func (s *ProvisioningService) SetGroupMembers(ctx context.Context, name string, userIDs []string) (model.Team, error) {
+	if name == "" {
+		return model.Team{}, ErrBadRequest("team_name is required")
+	}
+
+	var team model.Team
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.teamRepo.GetTeamByName(ctx, name)
+		if err != nil {
+			return err
+		}
+		if current.ArchivedAt != nil {
+			return repository.ErrTeamArchived
+		}
+
+		wanted := make(map[string]struct{}, len(userIDs))
+		for _, id := range userIDs {
+			wanted[id] = struct{}{}
+		}
+		removed := make([]string, 0)
+		for _, m := range current.Members {
+			if _, ok := wanted[m.UserID]; !ok {
+				removed = append(removed, m.UserID)
+			}
+		}
+		if len(removed) > 0 {
+			if _, err := reassignOpenReviews(ctx, s.userRepo, s.prRepo, s.rampUp, s.events, removed); err != nil {
+				return err
+			}
+			if err := s.teamRepo.RemoveMembers(ctx, name, removed); err != nil {
+				return err
+			}
+		}
+		if err := s.addToGroup(ctx, name, userIDs); err != nil {
+			return err
+		}
+
+		team, err = s.teamRepo.GetTeamByName(ctx, name)
+		return err
+	})
+	if err != nil {
+		return model.Team{}, membershipError(err, "failed to update team members")
+	}
+	return team, nil
+}
+
+// DeleteGroup убирает команду из провижининга. Участники исключаются из команды с переназначением
+// их открытых ревью, но остаются активными: удаление группы в IdP не означает увольнения.
+// Команда, участники которой писали или ревьюили PR, затем архивируется, чтобы сохранить историю,
+// а команда без истории удаляется. Команду с подкомандами удалить нельзя — возвращается TEAM_NOT_EMPTY,
+// архивную — NOT_FOUND.
+func (s *ProvisioningService) DeleteGroup(ctx context.Context, name string) error {
+	if name == "" {
+		return ErrBadRequest("team_name is required")
+	}
+
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.teamRepo.GetTeamByName(ctx, name)
+		if err != nil {
+			return err
+		}
+		if current.ArchivedAt != nil {
+			return repository.ErrTeamNotFound
+		}
+
+		if len(current.Members) > 0 {
+			ids := make([]string, 0, len(current.Members))
+			for _, m := range current.Members {
+				ids = append(ids, m.UserID)
+			}
+			if _, err := reassignOpenReviews(ctx, s.userRepo, s.prRepo, s.rampUp, s.events, ids); err != nil {
+				return err
+			}
+			if err := s.teamRepo.RemoveMembers(ctx, name, ids); err != nil {
+				return err
+			}
+		}
+
+		hasHistory, err := s.teamRepo.HasHistory(ctx, name)
+		if err != nil {
+			return err
+		}
+		if hasHistory {
+			return s.teamRepo.ArchiveTeam(ctx, name, time.Now().UTC())
+		}
+		return s.teamRepo.DeleteTeam(ctx, name)
+	})
+	if err != nil {
+		return membershipError(err, "failed to delete team")
+	}
+	return nil
+}
+
+// addToGroup переводит в команду name тех из userIDs, кто в ней ещё не состоит.
+// Должен вызываться внутри транзакции.
+func (s *ProvisioningService) addToGroup(ctx context.Context, name string, userIDs []string) error {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	users, err := s.userRepo.GetByUserIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	found := make(map[string]model.User, len(users))
+	for _, u := range users {
+		found[u.UserID] = u
+	}
+
+	movers := make([]string, 0, len(userIDs))
+	fromTeams := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		u, ok := found[id]
+		if !ok {
+			return ErrNotFound(fmt.Sprintf("user %s not found", id))
+		}
+		if u.TeamName == name {
+			continue
+		}
+		movers = append(movers, id)
+		if u.TeamName != "" {
+			fromTeams = append(fromTeams, id)
+		}
+	}
+	if len(movers) == 0 {
+		return nil
+	}
+
+	if len(fromTeams) > 0 {
+		if _, err := reassignOpenReviews(ctx, s.userRepo, s.prRepo, s.rampUp, s.events, fromTeams); err != nil {
+			return err
+		}
+	}
+	return s.teamRepo.MoveMembers(ctx, name, movers)
+}
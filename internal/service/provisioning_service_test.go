@@ -0,0 +1,244 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/repository"
+	"pull-request-service/internal/service"
+	"pull-request-service/internal/service/mocks"
+)
+
+func runInTx(tm *mocks.TransactionManager) {
+	tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+}
+
+func TestProvisioningService_ReplaceUser(t *testing.T) {
+	alice := model.User{UserID: "u1", Username: "alice", TeamName: "backend", IsActive: true}
+
+	tests := []struct {
+		name              string
+		input             model.User
+		setupMocks        func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository)
+		wantReassignments int
+		wantCode          string
+	}{
+		{
+			name:  "Success: Deactivation reassigns open reviews",
+			input: model.User{UserID: "u1", Username: "alice", TeamName: "backend", IsActive: false},
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository) {
+				ur.On("GetByUserID", mock.Anything, "u1").Return(alice, nil).Once()
+				ur.On("SetIsActive", mock.Anything, "u1", false).Return(model.User{}, nil)
+
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).
+					Return(map[string][]string{"u1": {"pr-1"}}, nil)
+				ur.On("GetByUserID", mock.Anything, "u1").Return(alice, nil).Once()
+				pr.On("GetPR", mock.Anything, "pr-1").Return(model.PullRequest{
+					PullRequestID: "pr-1", AuthorID: "u9", AssignedReviewers: []string{"u1"},
+				}, nil)
+				ur.On("ListActiveTeamMembersExcept", mock.Anything, "backend", mock.Anything).
+					Return([]model.User{{UserID: "u2", TeamName: "backend", IsActive: true}}, nil)
+				pr.On("ReassignReviewer", mock.Anything, "pr-1", "u1", "u2").Return(model.PullRequest{}, nil)
+
+				ur.On("GetByUserID", mock.Anything, "u1").Return(model.User{UserID: "u1", Username: "alice", TeamName: "backend"}, nil)
+			},
+			wantReassignments: 1,
+		},
+		{
+			name:  "Success: Rename and leave team",
+			input: model.User{UserID: "u1", Username: "alice.s", IsActive: true},
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository) {
+				ur.On("GetByUserID", mock.Anything, "u1").Return(alice, nil).Once()
+				ur.On("SetUsername", mock.Anything, "u1", "alice.s").Return(nil)
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).Return(map[string][]string{}, nil)
+				tr.On("RemoveMembers", mock.Anything, "backend", []string{"u1"}).Return(nil)
+				ur.On("GetByUserID", mock.Anything, "u1").Return(model.User{UserID: "u1", Username: "alice.s", IsActive: true}, nil)
+			},
+		},
+		{
+			name:  "Fail: Target team is archived",
+			input: model.User{UserID: "u1", Username: "alice", TeamName: "legacy", IsActive: true},
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository) {
+				ur.On("GetByUserID", mock.Anything, "u1").Return(alice, nil)
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).Return(map[string][]string{}, nil)
+				tr.On("MoveMembers", mock.Anything, "legacy", []string{"u1"}).Return(repository.ErrTeamArchived)
+			},
+			wantCode: "TEAM_ARCHIVED",
+		},
+		{
+			name:       "Fail: Empty username",
+			input:      model.User{UserID: "u1"},
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			ur := new(mocks.UserRepository)
+			pr := new(mocks.PRRepository)
+			tm := new(mocks.TransactionManager)
+			runInTx(tm)
+			tt.setupMocks(tr, ur, pr)
+
+			svc := service.NewProvisioningService(tr, ur, pr, tm)
+			_, reassignments, err := svc.ReplaceUser(context.Background(), tt.input)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, reassignments, tt.wantReassignments)
+			}
+			tr.AssertExpectations(t)
+			ur.AssertExpectations(t)
+			pr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProvisioningService_SetGroupMembers(t *testing.T) {
+	backend := model.Team{TeamName: "backend", Members: []model.TeamMember{
+		{UserID: "u1", Username: "alice", IsActive: true},
+		{UserID: "u2", Username: "bob", IsActive: true},
+	}}
+
+	tests := []struct {
+		name       string
+		userIDs    []string
+		setupMocks func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository)
+		wantCode   string
+	}{
+		{
+			name:    "Success: Removes missing, moves new from other team",
+			userIDs: []string{"u2", "u3"},
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository) {
+				tr.On("GetTeamByName", mock.Anything, "backend").Return(backend, nil)
+
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).Return(map[string][]string{}, nil)
+				tr.On("RemoveMembers", mock.Anything, "backend", []string{"u1"}).Return(nil)
+
+				ur.On("GetByUserIDs", mock.Anything, []string{"u2", "u3"}).Return([]model.User{
+					{UserID: "u2", TeamName: "backend"},
+					{UserID: "u3", TeamName: "frontend"},
+				}, nil)
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u3"}).Return(map[string][]string{}, nil)
+				tr.On("MoveMembers", mock.Anything, "backend", []string{"u3"}).Return(nil)
+			},
+		},
+		{
+			name:    "Fail: Unknown user",
+			userIDs: []string{"u1", "u2", "u404"},
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository) {
+				tr.On("GetTeamByName", mock.Anything, "backend").Return(backend, nil)
+				ur.On("GetByUserIDs", mock.Anything, []string{"u1", "u2", "u404"}).Return([]model.User{
+					{UserID: "u1", TeamName: "backend"},
+					{UserID: "u2", TeamName: "backend"},
+				}, nil)
+			},
+			wantCode: "NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			ur := new(mocks.UserRepository)
+			pr := new(mocks.PRRepository)
+			tm := new(mocks.TransactionManager)
+			runInTx(tm)
+			tt.setupMocks(tr, ur, pr)
+
+			svc := service.NewProvisioningService(tr, ur, pr, tm)
+			_, err := svc.SetGroupMembers(context.Background(), "backend", tt.userIDs)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+			}
+			tr.AssertExpectations(t)
+			ur.AssertExpectations(t)
+			pr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestProvisioningService_DeleteGroup(t *testing.T) {
+	archivedAt := time.Now().UTC()
+	backend := model.Team{TeamName: "backend", Members: []model.TeamMember{
+		{UserID: "u1", Username: "alice", IsActive: true},
+	}}
+
+	tests := []struct {
+		name       string
+		setupMocks func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository)
+		wantCode   string
+	}{
+		{
+			name: "Success: Team with history is emptied and archived, members stay active",
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository) {
+				tr.On("GetTeamByName", mock.Anything, "backend").Return(backend, nil)
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).Return(map[string][]string{}, nil)
+				tr.On("RemoveMembers", mock.Anything, "backend", []string{"u1"}).Return(nil)
+				tr.On("HasHistory", mock.Anything, "backend").Return(true, nil)
+				tr.On("ArchiveTeam", mock.Anything, "backend", mock.AnythingOfType("time.Time")).Return(nil)
+			},
+		},
+		{
+			name: "Success: Team without history is emptied and deleted",
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository) {
+				tr.On("GetTeamByName", mock.Anything, "backend").Return(backend, nil)
+				tr.On("HasHistory", mock.Anything, "backend").Return(false, nil)
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).Return(map[string][]string{}, nil)
+				tr.On("RemoveMembers", mock.Anything, "backend", []string{"u1"}).Return(nil)
+				tr.On("DeleteTeam", mock.Anything, "backend").Return(nil)
+			},
+		},
+		{
+			name: "Fail: Archived team is already gone",
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository) {
+				tr.On("GetTeamByName", mock.Anything, "backend").
+					Return(model.Team{TeamName: "backend", ArchivedAt: &archivedAt}, nil)
+			},
+			wantCode: "NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			ur := new(mocks.UserRepository)
+			pr := new(mocks.PRRepository)
+			tm := new(mocks.TransactionManager)
+			runInTx(tm)
+			tt.setupMocks(tr, ur, pr)
+
+			svc := service.NewProvisioningService(tr, ur, pr, tm)
+			err := svc.DeleteGroup(context.Background(), "backend")
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+			}
+			tr.AssertExpectations(t)
+			ur.AssertExpectations(t)
+			pr.AssertExpectations(t)
+		})
+	}
+}
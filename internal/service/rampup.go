@@ -0,0 +1,62 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"pull-request-service/internal/model"
+)
+
+// RampUpPolicy ограничивает нагрузку на вернувшихся пользователей: в течение Days дней
+// после повторной активации им назначается не более MaxAssignments новых ревью.
+// Нулевое значение любого из полей отключает ограничение: период без лимита ничего не ограничивает.
+type RampUpPolicy struct {
+	Days           int
+	MaxAssignments int
+}
+
+// Enabled сообщает, включено ли ограничение.
+func (p RampUpPolicy) Enabled() bool {
+	return p.Days > 0 && p.MaxAssignments > 0
+}
+
+// forPriority возвращает политику для PR с приоритетом priority: hotfix-PR ограничение разгона
+// не действует, чтобы срочное исправление не осталось без ревьюверов.
+func (p RampUpPolicy) forPriority(priority model.PullRequestPriority) RampUpPolicy {
+	if priority == model.PriorityHotfix {
+		return RampUpPolicy{}
+	}
+	return p
+}
+
+// filterRampUp убирает из кандидатов вернувшихся пользователей, исчерпавших лимит назначений
+// периода разгона. Порядок оставшихся кандидатов сохраняется.
+func (p RampUpPolicy) filterRampUp(ctx context.Context, repo UserRepository, candidates []model.User) ([]model.User, error) {
+	if !p.Enabled() || len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	since := time.Now().UTC().AddDate(0, 0, -p.Days)
+	states, err := repo.ListRampUp(ctx, idsOf(candidates), since)
+	if err != nil {
+		return nil, err
+	}
+	if len(states) == 0 {
+		return candidates, nil
+	}
+
+	exhausted := make(map[string]struct{}, len(states))
+	for _, st := range states {
+		if st.AssignedSince >= p.MaxAssignments {
+			exhausted[st.UserID] = struct{}{}
+		}
+	}
+
+	eligible := make([]model.User, 0, len(candidates))
+	for _, u := range candidates {
+		if _, ok := exhausted[u.UserID]; !ok {
+			eligible = append(eligible, u)
+		}
+	}
+	return eligible, nil
+}
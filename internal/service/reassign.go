@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/repository"
+)
+
+// reassignOpenReviews снимает пользователей userIDs со всех открытых PR, где они ревьюверы.
+// Каждое место отдаётся случайному активному участнику команды уходящего ревьювера
+// (или ближайшей родительской команды; на hotfix-PR — по возможности тому, у кого сейчас рабочее время),
+// а если замены нет — ревьювер просто удаляется из PR.
+// Возвращает по записи на каждое затронутое ревью; каждая запись также передаётся events
+// как событие reviewer.assigned или reviewer.removed. Должен вызываться внутри транзакции
+// и до того, как у пользователей поменяется команда.
+func reassignOpenReviews(
+	ctx context.Context,
+	userRepo UserRepository,
+	prRepo PRRepository,
+	rampUp RampUpPolicy,
+	events EventRecorder,
+	userIDs []string,
+) ([]model.ReviewReassignment, error) {
+	impactedPRsMap, err := prRepo.GetOpenPRsByReviewers(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]model.ReviewReassignment, 0)
+	if len(impactedPRsMap) == 0 {
+		return result, nil
+	}
+
+	// Обходим ревьюверов в детерминированном порядке, чтобы отчёт был стабильным
+	reviewerIDs := make([]string, 0, len(impactedPRsMap))
+	for id := range impactedPRsMap {
+		reviewerIDs = append(reviewerIDs, id)
+	}
+	sort.Strings(reviewerIDs)
+
+	for _, oldReviewerID := range reviewerIDs {
+		oldUser, err := userRepo.GetByUserID(ctx, oldReviewerID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, prID := range impactedPRsMap[oldReviewerID] {
+			pr, err := prRepo.GetPR(ctx, prID)
+			if err != nil {
+				if errors.Is(err, repository.ErrPRNotFound) {
+					continue
+				}
+				return nil, err
+			}
+
+			exclude := make([]string, 0, len(pr.AssignedReviewers)+len(userIDs)+1)
+			exclude = append(exclude, pr.AuthorID)
+			exclude = append(exclude, pr.AssignedReviewers...)
+			exclude = append(exclude, userIDs...)
+
+			candidates, err := reviewCandidates(ctx, userRepo, rampUp.forPriority(pr.Priority), oldUser.TeamName, exclude)
+			if err != nil {
+				return nil, err
+			}
+
+			change := model.ReviewReassignment{PullRequestID: prID, OldReviewerID: oldReviewerID}
+			if len(candidates) > 0 {
+				candidates, available, err := preferWorkingHours(ctx, userRepo, pr.Priority, candidates, 1)
+				if err != nil {
+					return nil, err
+				}
+				newReviewer := pickAvailable(candidates, available)
+
+				if _, err := prRepo.ReassignReviewer(ctx, prID, oldReviewerID, newReviewer.UserID); err != nil {
+					return nil, err
+				}
+				change.NewReviewerID = newReviewer.UserID
+				change.Action = model.ReassignActionReassigned
+				change.Reason = model.ReasonTeamMember
+				if newReviewer.TeamName != oldUser.TeamName {
+					change.Reason = model.ReasonParentTeamMember
+				}
+			} else {
+				if err := prRepo.RemoveReviewer(ctx, prID, oldReviewerID); err != nil {
+					return nil, err
+				}
+				change.Action = model.ReassignActionRemoved
+				change.Reason = model.ReasonNoCandidate
+			}
+			result = append(result, change)
+		}
+	}
+
+	changes := make([]model.Event, 0, len(result))
+	for _, change := range result {
+		changes = append(changes, reassignmentEvent(change))
+	}
+	if err := recordEvents(ctx, events, changes...); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"time"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/repository"
+)
+
+// GetReviewStats возвращает нагрузку ревьюверов по filter и её сводку по командам.
+// Команды без активных участников в сводку не попадают.
+func (s *TeamService) GetReviewStats(ctx context.Context, filter model.StatsFilter) (model.ReviewStats, error) {
+	if filter.Status != "" && !filter.Status.IsValid() {
+		return model.ReviewStats{}, ErrBadRequest("status must be OPEN, MERGED or CLOSED")
+	}
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return model.ReviewStats{}, ErrBadRequest("from must be before to")
+	}
+	if filter.TeamName != "" {
+		if _, err := s.repo.GetTeamByName(ctx, filter.TeamName); err != nil {
+			if errors.Is(err, repository.ErrTeamNotFound) {
+				return model.ReviewStats{}, ErrNotFound("team not found")
+			}
+			return model.ReviewStats{}, &AppError{Code: "INTERNAL", Message: "failed to get team", Status: 500, Err: err}
+		}
+	}
+
+	reviewers, err := s.prRepo.ListReviewerStats(ctx, filter, time.Now().UTC())
+	if err != nil {
+		return model.ReviewStats{}, &AppError{Code: "INTERNAL", Message: "failed to get review stats", Status: 500, Err: err}
+	}
+	return model.ReviewStats{Reviewers: reviewers, Teams: teamLoad(reviewers)}, nil
+}
+
+// teamLoad суммирует нагрузку ревьюверов по командам. Неактивные участники входят в суммы,
+// но не в коэффициент Джини: на них назначать нельзя, и их нули исказили бы оценку.
+func teamLoad(reviewers []model.ReviewerStats) []model.TeamLoadStats {
+	byTeam := make(map[string]*model.TeamLoadStats)
+	loads := make(map[string][]int)
+	for _, r := range reviewers {
+		if r.TeamName == "" {
+			continue
+		}
+		t, ok := byTeam[r.TeamName]
+		if !ok {
+			t = &model.TeamLoadStats{TeamName: r.TeamName}
+			byTeam[r.TeamName] = t
+		}
+		t.TotalAssignments += r.TotalAssignments
+		t.Open += r.Open
+		t.Merged += r.Merged
+		t.ReassignedAway += r.ReassignedAway
+		if r.IsActive {
+			t.ActiveReviewers++
+			loads[r.TeamName] = append(loads[r.TeamName], r.TotalAssignments)
+		}
+	}
+
+	teams := make([]model.TeamLoadStats, 0, len(byTeam))
+	for name, t := range byTeam {
+		if t.ActiveReviewers == 0 {
+			continue
+		}
+		t.Gini = gini(loads[name])
+		teams = append(teams, *t)
+	}
+	sort.Slice(teams, func(i, j int) bool { return teams[i].TeamName < teams[j].TeamName })
+	return teams
+}
+
+// gini вычисляет коэффициент Джини неотрицательных значений, округлённый до тысячных.
+// Для пустого набора и набора из нулей возвращает 0.
+func gini(values []int) float64 {
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+
+	// G = Σ (2i − n − 1)·x_i / (n·Σx) для x, упорядоченных по возрастанию, i = 1..n
+	n := len(sorted)
+	var sum, weighted int
+	for i, v := range sorted {
+		sum += v
+		weighted += (2*(i+1) - n - 1) * v
+	}
+	if sum == 0 {
+		return 0
+	}
+	return math.Round(float64(weighted)/float64(n*sum)*1000) / 1000
+}
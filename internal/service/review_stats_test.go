@@ -0,0 +1,92 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/repository"
+	"pull-request-service/internal/service"
+	"pull-request-service/internal/service/mocks"
+)
+
+func TestTeamService_GetReviewStats(t *testing.T) {
+	from := time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name       string
+		filter     model.StatsFilter
+		setupMocks func(tr *mocks.TeamRepository, pr *mocks.PRRepository)
+		wantTeams  []model.TeamLoadStats
+		wantCode   string
+	}{
+		{
+			name:   "Success: team totals and gini over active members",
+			filter: model.StatsFilter{From: &from, To: &to},
+			setupMocks: func(tr *mocks.TeamRepository, pr *mocks.PRRepository) {
+				pr.On("ListReviewerStats", mock.Anything, model.StatsFilter{From: &from, To: &to}, mock.Anything).Return([]model.ReviewerStats{
+					{ReviewerID: "u3", TeamName: "backend", IsActive: true, TotalAssignments: 4, Open: 2, Merged: 1, ReassignedAway: 1},
+					{ReviewerID: "u2", TeamName: "backend", IsActive: true, TotalAssignments: 2, Open: 1, Merged: 1},
+					{ReviewerID: "u9", TeamName: "backend", IsActive: false, TotalAssignments: 1, ReassignedAway: 1},
+					{ReviewerID: "u1", TeamName: "backend", IsActive: true},
+					{ReviewerID: "u5", TeamName: "frontend", IsActive: true, TotalAssignments: 3, Open: 3},
+					{ReviewerID: "u6", TeamName: "frontend", IsActive: true, TotalAssignments: 3, Merged: 3},
+					// Бывшая команда, в которой никого не осталось
+					{ReviewerID: "u7", TeamName: "legacy", IsActive: false, TotalAssignments: 2, Merged: 2},
+				}, nil)
+			},
+			wantTeams: []model.TeamLoadStats{
+				{TeamName: "backend", ActiveReviewers: 3, TotalAssignments: 7, Open: 3, Merged: 2, ReassignedAway: 2, Gini: 0.444},
+				{TeamName: "frontend", ActiveReviewers: 2, TotalAssignments: 6, Open: 3, Merged: 3, Gini: 0},
+			},
+		},
+		{
+			name:       "Fail: Unknown status",
+			filter:     model.StatsFilter{Status: "DRAFT"},
+			setupMocks: func(tr *mocks.TeamRepository, pr *mocks.PRRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "Fail: Empty date range",
+			filter:     model.StatsFilter{From: &to, To: &from},
+			setupMocks: func(tr *mocks.TeamRepository, pr *mocks.PRRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:   "Fail: Team not found",
+			filter: model.StatsFilter{TeamName: "ghosts"},
+			setupMocks: func(tr *mocks.TeamRepository, pr *mocks.PRRepository) {
+				tr.On("GetTeamByName", mock.Anything, "ghosts").Return(model.Team{}, repository.ErrTeamNotFound)
+			},
+			wantCode: "NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			pr := new(mocks.PRRepository)
+			tt.setupMocks(tr, pr)
+
+			svc := service.NewTeamService(tr, new(mocks.UserRepository), pr, new(mocks.TransactionManager))
+			stats, err := svc.GetReviewStats(context.Background(), tt.filter)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTeams, stats.Teams)
+				assert.Len(t, stats.Reviewers, 7)
+			}
+			tr.AssertExpectations(t)
+			pr.AssertExpectations(t)
+		})
+	}
+}
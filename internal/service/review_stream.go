@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/repository"
+)
+
+const (
+	// reviewStreamPoll — как часто проверяется журнал опубликованных событий.
+	reviewStreamPoll = 500 * time.Millisecond
+	// reviewStreamBatch — сколько событий журнала читается за раз.
+	reviewStreamBatch = 500
+	// reviewStreamBuffer — сколько событий может ждать медленного получателя, прежде чем его отключат.
+	reviewStreamBuffer = 64
+	// reviewStreamBacklogPage — сколько пропущенных событий читается из журнала за один запрос при переподключении.
+	reviewStreamBacklogPage = 1000
+)
+
+// EventLog описывает журнал опубликованных событий (таблица outbox).
+type EventLog interface {
+	LatestPublishedSeq(ctx context.Context) (int64, error)
+	ListPublishedSince(ctx context.Context, after int64, limit int) ([]model.Event, error)
+	ListReviewerEventsSince(ctx context.Context, userID string, after int64, limit int) ([]model.Event, error)
+}
+
+// ReviewStreamService раздаёт подписчикам события их ревью. Каждый экземпляр сервиса читает
+// общий журнал опубликованных событий и рассылает новые события подписчикам через брокер
+// в памяти, поэтому пользователь получает события независимо от того, какой экземпляр
+// обработал изменение. Переподключившийся клиент дочитывает пропущенное из того же журнала.
+type ReviewStreamService struct {
+	log      EventLog
+	userRepo UserRepository
+
+	mu   sync.Mutex
+	subs map[string]map[chan model.Event]struct{}
+}
+
+// NewReviewStreamService создаёт сервис потоков ревью. Рассылка начинается после запуска Run.
+func NewReviewStreamService(log EventLog, userRepo UserRepository) *ReviewStreamService {
+	return &ReviewStreamService{
+		log:      log,
+		userRepo: userRepo,
+		subs:     make(map[string]map[chan model.Event]struct{}),
+	}
+}
+
+// Run читает журнал, начиная с последнего опубликованного события, и рассылает новые
+// события подписчикам, пока не отменён ctx.
+func (s *ReviewStreamService) Run(ctx context.Context, logger *slog.Logger) {
+	var cursor int64
+	started := false
+
+	ticker := time.NewTicker(reviewStreamPoll)
+	defer ticker.Stop()
+	for {
+		if !started {
+			seq, err := s.log.LatestPublishedSeq(ctx)
+			if err == nil {
+				cursor, started = seq, true
+			} else if ctx.Err() == nil {
+				logger.Error("review stream init failed", slog.Any("err", err))
+			}
+		}
+		for started {
+			events, err := s.log.ListPublishedSince(ctx, cursor, reviewStreamBatch)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Error("review stream poll failed", slog.Any("err", err))
+				}
+				break
+			}
+			for _, ev := range events {
+				s.Publish(ev)
+				cursor = ev.Seq
+			}
+			if len(events) < reviewStreamBatch {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Publish передаёт событие подписчикам, которых оно касается. Подписчик, чей буфер
+// переполнен, отключается: клиент переподключится с Last-Event-ID и дочитает пропущенное из журнала.
+func (s *ReviewStreamService) Publish(ev model.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, userID := range reviewStreamRecipients(ev) {
+		for ch := range s.subs[userID] {
+			select {
+			case ch <- ev:
+			default:
+				s.dropLocked(userID, ch)
+			}
+		}
+	}
+}
+
+// Subscribe подписывает пользователя на его события. Если lastEventID > 0, в Backlog
+// возвращаются все события, опубликованные после него: журнал читается постранично до конца,
+// иначе события между концом Backlog и первым новым событием были бы пропущены.
+func (s *ReviewStreamService) Subscribe(ctx context.Context, userID string, lastEventID int64) (model.ReviewSubscription, error) {
+	if userID == "" {
+		return model.ReviewSubscription{}, ErrBadRequest("user_id is required")
+	}
+	if lastEventID < 0 {
+		return model.ReviewSubscription{}, ErrBadRequest("Last-Event-ID must be a non-negative integer")
+	}
+	if _, err := s.userRepo.GetByUserID(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return model.ReviewSubscription{}, ErrNotFound("user not found")
+		}
+		return model.ReviewSubscription{}, &AppError{Code: "INTERNAL", Message: "failed to get user", Status: 500, Err: err}
+	}
+
+	// Подписка оформляется до чтения журнала, чтобы события между чтением и подпиской не потерялись;
+	// возможные дубликаты отбрасывает получатель по Seq.
+	ch := make(chan model.Event, reviewStreamBuffer)
+	s.mu.Lock()
+	if s.subs[userID] == nil {
+		s.subs[userID] = make(map[chan model.Event]struct{})
+	}
+	s.subs[userID][ch] = struct{}{}
+	s.mu.Unlock()
+
+	sub := model.ReviewSubscription{
+		Backlog: make([]model.Event, 0),
+		Events:  ch,
+		Close: func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			s.dropLocked(userID, ch)
+		},
+	}
+
+	for after := lastEventID; after > 0; {
+		page, err := s.log.ListReviewerEventsSince(ctx, userID, after, reviewStreamBacklogPage)
+		if err != nil {
+			sub.Close()
+			return model.ReviewSubscription{}, &AppError{Code: "INTERNAL", Message: "failed to read event log", Status: 500, Err: err}
+		}
+		sub.Backlog = append(sub.Backlog, page...)
+		if len(page) < reviewStreamBacklogPage {
+			break
+		}
+		after = page[len(page)-1].Seq
+	}
+	return sub, nil
+}
+
+// dropLocked отписывает канал и закрывает его. Вызывается под s.mu.
+func (s *ReviewStreamService) dropLocked(userID string, ch chan model.Event) {
+	if _, ok := s.subs[userID][ch]; !ok {
+		return
+	}
+	delete(s.subs[userID], ch)
+	if len(s.subs[userID]) == 0 {
+		delete(s.subs, userID)
+	}
+	close(ch)
+}
+
+// reviewStreamRecipients возвращает пользователей, в чей поток ревью попадает событие.
+// О выставленном ревью узнают все ревьюверы PR и его автор.
+func reviewStreamRecipients(ev model.Event) []string {
+	switch ev.Type {
+	case model.EventReviewerAssigned:
+		if ev.ReplacedReviewerID != "" {
+			return []string{ev.ReviewerID, ev.ReplacedReviewerID}
+		}
+		return []string{ev.ReviewerID}
+	case model.EventReviewerRemoved:
+		return []string{ev.ReviewerID}
+	case model.EventPRMerged:
+		return ev.Reviewers
+	case model.EventReviewSubmitted:
+		return append([]string{ev.AuthorID}, ev.Reviewers...)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/repository"
+	"pull-request-service/internal/service"
+	"pull-request-service/internal/service/mocks"
+)
+
+func TestReviewStreamService_Subscribe(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      string
+		lastEventID int64
+		setupMocks  func(log *mocks.EventLog, ur *mocks.UserRepository)
+		wantBacklog int
+		wantCode    string
+	}{
+		{
+			name:        "Success: Resume returns backlog from log",
+			userID:      "u1",
+			lastEventID: 10,
+			setupMocks: func(log *mocks.EventLog, ur *mocks.UserRepository) {
+				ur.On("GetByUserID", mock.Anything, "u1").Return(model.User{UserID: "u1"}, nil)
+				log.On("ListReviewerEventsSince", mock.Anything, "u1", int64(10), mock.Anything).Return([]model.Event{
+					{Seq: 11, Type: model.EventReviewerAssigned, ReviewerID: "u1"},
+				}, nil)
+			},
+			wantBacklog: 1,
+		},
+		{
+			name:        "Success: Long backlog is read page by page until caught up",
+			userID:      "u1",
+			lastEventID: 10,
+			setupMocks: func(log *mocks.EventLog, ur *mocks.UserRepository) {
+				ur.On("GetByUserID", mock.Anything, "u1").Return(model.User{UserID: "u1"}, nil)
+				full := make([]model.Event, 1000)
+				for i := range full {
+					full[i] = model.Event{Seq: int64(11 + i), Type: model.EventReviewerAssigned, ReviewerID: "u1"}
+				}
+				log.On("ListReviewerEventsSince", mock.Anything, "u1", int64(10), 1000).Return(full, nil)
+				log.On("ListReviewerEventsSince", mock.Anything, "u1", int64(1010), 1000).Return([]model.Event{
+					{Seq: 1500, Type: model.EventReviewerRemoved, ReviewerID: "u1"},
+				}, nil)
+			},
+			wantBacklog: 1001,
+		},
+		{
+			name:   "Success: Fresh subscription has no backlog",
+			userID: "u1",
+			setupMocks: func(log *mocks.EventLog, ur *mocks.UserRepository) {
+				ur.On("GetByUserID", mock.Anything, "u1").Return(model.User{UserID: "u1"}, nil)
+			},
+		},
+		{
+			name:   "Fail: Unknown user",
+			userID: "u404",
+			setupMocks: func(log *mocks.EventLog, ur *mocks.UserRepository) {
+				ur.On("GetByUserID", mock.Anything, "u404").Return(model.User{}, repository.ErrUserNotFound)
+			},
+			wantCode: "NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			log := new(mocks.EventLog)
+			ur := new(mocks.UserRepository)
+			tt.setupMocks(log, ur)
+
+			svc := service.NewReviewStreamService(log, ur)
+			sub, err := svc.Subscribe(context.Background(), tt.userID, tt.lastEventID)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				require.NoError(t, err)
+				assert.Len(t, sub.Backlog, tt.wantBacklog)
+				sub.Close()
+			}
+			log.AssertExpectations(t)
+			ur.AssertExpectations(t)
+		})
+	}
+}
+
+func TestReviewStreamService_Publish(t *testing.T) {
+	ur := new(mocks.UserRepository)
+	ur.On("GetByUserID", mock.Anything, mock.Anything).Return(model.User{}, nil)
+	svc := service.NewReviewStreamService(new(mocks.EventLog), ur)
+
+	subscribe := func(userID string) model.ReviewSubscription {
+		sub, err := svc.Subscribe(context.Background(), userID, 0)
+		require.NoError(t, err)
+		return sub
+	}
+	u1, u2, u3 := subscribe("u1"), subscribe("u2"), subscribe("u3")
+	defer u1.Close()
+	defer u2.Close()
+	defer u3.Close()
+
+	// Переназначение с u1 на u2 попадает в потоки обоих, но не u3
+	svc.Publish(model.Event{Seq: 1, Type: model.EventReviewerAssigned, ReviewerID: "u2", ReplacedReviewerID: "u1"})
+	assert.Equal(t, int64(1), (<-u1.Events).Seq)
+	assert.Equal(t, int64(1), (<-u2.Events).Seq)
+	assert.Empty(t, u3.Events)
+
+	// Ревью в PR автора u1 видят автор и ревьюверы, но не посторонний u3
+	svc.Publish(model.Event{Seq: 2, Type: model.EventReviewSubmitted, AuthorID: "u1", Reviewers: []string{"u2"}, ReviewerID: "u2"})
+	assert.Equal(t, int64(2), (<-u1.Events).Seq)
+	assert.Equal(t, int64(2), (<-u2.Events).Seq)
+	assert.Empty(t, u3.Events)
+
+	// Медленный подписчик отключается, когда его буфер переполнен
+	for i := 0; i < 100; i++ {
+		svc.Publish(model.Event{Seq: int64(3 + i), Type: model.EventReviewerRemoved, ReviewerID: "u3"})
+	}
+	received := 0
+	for range u3.Events {
+		received++
+	}
+	assert.Less(t, received, 100)
+}
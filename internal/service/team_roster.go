@@ -0,0 +1,276 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/repository"
+)
+
+// ImportRoster применяет состав команд из файла одной транзакцией и возвращает сводку изменений.
+// Импорт только добавляет и обновляет: отсутствующие команды создаются, родитель и участники
+// перечисленных команд приводятся к файлу, а пользователи из других команд переводятся
+// с переназначением их открытых ревью. Участники, которых нет в файле, не затрагиваются.
+// Структуру файла (имена, user_id, дубли) должен заранее проверить вызывающий код.
+func (s *TeamService) ImportRoster(ctx context.Context, roster model.Roster) (model.RosterDiff, error) {
+	ordered, err := parentsFirst(roster.Teams)
+	if err != nil {
+		return model.RosterDiff{}, err
+	}
+
+	diff := model.NewRosterDiff()
+	err = s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		return s.applyRoster(ctx, ordered, &diff)
+	})
+	if err != nil {
+		return model.RosterDiff{}, membershipError(err, "failed to import roster")
+	}
+	return diff, nil
+}
+
+// SyncRoster приводит состав к желаемому состоянию из roster: делает всё то же, что ImportRoster,
+// и дополнительно деактивирует всех активных пользователей, которых нет в файле, переназначая
+// их открытые ревью так же, как MassDeactivate. Файл описывает весь состав: команды, которых в нём нет,
+// не удаляются и не архивируются, но их активные участники деактивируются, как и остальные отсутствующие.
+// При planOnly план рассчитывается в транзакции, которая затем откатывается.
+func (s *TeamService) SyncRoster(ctx context.Context, roster model.Roster, planOnly bool) (model.RosterDiff, error) {
+	ordered, err := parentsFirst(roster.Teams)
+	if err != nil {
+		return model.RosterDiff{}, err
+	}
+
+	desired := make(map[string]struct{})
+	for _, t := range ordered {
+		for _, m := range t.Members {
+			desired[m.UserID] = struct{}{}
+		}
+	}
+
+	diff := model.NewRosterDiff()
+	diff.PlanOnly = planOnly
+	err = s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := s.applyRoster(ctx, ordered, &diff); err != nil {
+			return err
+		}
+
+		active, err := s.userRepo.ListActiveUserIDs(ctx)
+		if err != nil {
+			return err
+		}
+		absent := make([]string, 0)
+		for _, id := range active {
+			if _, ok := desired[id]; !ok {
+				absent = append(absent, id)
+			}
+		}
+
+		if len(absent) > 0 {
+			if err := s.userRepo.DeactivateUsers(ctx, absent); err != nil {
+				return err
+			}
+			reassignments, err := s.reassignOpenReviews(ctx, absent)
+			if err != nil {
+				return err
+			}
+			diff.UsersDeactivated = append(diff.UsersDeactivated, absent...)
+			diff.Reassignments = append(diff.Reassignments, reassignments...)
+		}
+
+		if planOnly {
+			return errPreviewRollback
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errPreviewRollback) {
+		return model.RosterDiff{}, membershipError(err, "failed to sync roster")
+	}
+	return diff, nil
+}
+
+// applyRoster применяет команды ordered (родители раньше подкоманд) и дописывает изменения в diff.
+// Должен вызываться внутри транзакции.
+func (s *TeamService) applyRoster(ctx context.Context, ordered []model.Team, diff *model.RosterDiff) error {
+	userIDs := make([]string, 0)
+	for _, t := range ordered {
+		for _, m := range t.Members {
+			userIDs = append(userIDs, m.UserID)
+		}
+	}
+
+	existingUsers, err := s.userRepo.GetByUserIDs(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+	before := make(map[string]model.User, len(existingUsers))
+	for _, u := range existingUsers {
+		before[u.UserID] = u
+	}
+
+	deactivated := make([]string, 0)
+	for _, t := range ordered {
+		if err := s.importTeam(ctx, t, diff); err != nil {
+			return fmt.Errorf("team %s: %w", t.TeamName, err)
+		}
+
+		movers := make([]string, 0)
+		for _, m := range t.Members {
+			prev, ok := before[m.UserID]
+			if !ok {
+				diff.UsersCreated = append(diff.UsersCreated, m.UserID)
+				continue
+			}
+			if prev.TeamName != t.TeamName {
+				if prev.TeamName != "" {
+					movers = append(movers, m.UserID)
+				}
+				diff.UsersMoved = append(diff.UsersMoved, model.UserTeamChange{UserID: m.UserID, From: prev.TeamName, To: t.TeamName})
+			}
+			if prev.Username != m.Username {
+				diff.UsersUpdated = append(diff.UsersUpdated, m.UserID)
+			}
+			switch {
+			case prev.IsActive && !m.IsActive:
+				deactivated = append(deactivated, m.UserID)
+				diff.UsersDeactivated = append(diff.UsersDeactivated, m.UserID)
+			case !prev.IsActive && m.IsActive:
+				diff.UsersReactivated = append(diff.UsersReactivated, m.UserID)
+			}
+		}
+
+		if len(movers) > 0 {
+			// Ревью переназначаются до перевода, пока замена ищется в прежней команде
+			reassignments, err := s.reassignOpenReviews(ctx, movers)
+			if err != nil {
+				return fmt.Errorf("team %s: %w", t.TeamName, err)
+			}
+			diff.Reassignments = append(diff.Reassignments, reassignments...)
+			if err := s.repo.MoveMembers(ctx, t.TeamName, movers); err != nil {
+				return fmt.Errorf("team %s: %w", t.TeamName, err)
+			}
+		}
+		if len(t.Members) > 0 {
+			if err := s.repo.AddMembers(ctx, t.TeamName, t.Members); err != nil {
+				return fmt.Errorf("team %s: %w", t.TeamName, err)
+			}
+		}
+	}
+
+	if len(deactivated) > 0 {
+		reassignments, err := s.reassignOpenReviews(ctx, deactivated)
+		if err != nil {
+			return err
+		}
+		diff.Reassignments = append(diff.Reassignments, reassignments...)
+	}
+	return nil
+}
+
+// importTeam создаёт команду из файла или приводит родителя существующей команды к файлу.
+func (s *TeamService) importTeam(ctx context.Context, t model.Team, diff *model.RosterDiff) error {
+	current, err := s.repo.GetTeamByName(ctx, t.TeamName)
+	if errors.Is(err, repository.ErrTeamNotFound) {
+		if _, err := s.repo.CreateTeamWithMembers(ctx, model.Team{TeamName: t.TeamName, ParentTeamName: t.ParentTeamName}); err != nil {
+			return err
+		}
+		diff.TeamsCreated = append(diff.TeamsCreated, t.TeamName)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if current.ArchivedAt != nil {
+		return repository.ErrTeamArchived
+	}
+
+	if current.ParentTeamName != t.ParentTeamName {
+		if err := s.repo.SetParent(ctx, t.TeamName, t.ParentTeamName); err != nil {
+			return err
+		}
+		diff.TeamsMoved = append(diff.TeamsMoved, model.TeamParentChange{
+			TeamName: t.TeamName,
+			From:     current.ParentTeamName,
+			To:       t.ParentTeamName,
+		})
+	}
+	return nil
+}
+
+// ExportRoster возвращает состав всех неархивных команд. Команды без участников во всём поддереве
+// не выгружаются: такой файл нельзя было бы импортировать обратно.
+func (s *TeamService) ExportRoster(ctx context.Context) (model.Roster, error) {
+	teams, err := s.repo.ListRoster(ctx)
+	if err != nil {
+		return model.Roster{}, &AppError{
+			Code:    "INTERNAL",
+			Message: "failed to export roster",
+			Status:  500,
+			Err:     err,
+		}
+	}
+
+	parents := make(map[string]string, len(teams))
+	for _, t := range teams {
+		parents[t.TeamName] = t.ParentTeamName
+	}
+	keep := make(map[string]bool, len(teams))
+	for _, t := range teams {
+		if len(t.Members) == 0 {
+			continue
+		}
+		for name := t.TeamName; name != "" && !keep[name]; name = parents[name] {
+			keep[name] = true
+		}
+	}
+
+	roster := model.Roster{Teams: make([]model.Team, 0, len(keep))}
+	for _, t := range teams {
+		if keep[t.TeamName] {
+			roster.Teams = append(roster.Teams, t)
+		}
+	}
+	return roster, nil
+}
+
+// parentsFirst упорядочивает команды так, чтобы родитель из того же файла шёл раньше подкоманд.
+// Цикл в иерархии файла возвращается как BAD_REQUEST.
+func parentsFirst(teams []model.Team) ([]model.Team, error) {
+	byName := make(map[string]model.Team, len(teams))
+	for _, t := range teams {
+		byName[t.TeamName] = t
+	}
+
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := make(map[string]int, len(teams))
+	ordered := make([]model.Team, 0, len(teams))
+
+	var visit func(t model.Team) error
+	visit = func(t model.Team) error {
+		switch state[t.TeamName] {
+		case done:
+			return nil
+		case visiting:
+			return ErrBadRequest(fmt.Sprintf("team hierarchy in roster has a cycle at %s", t.TeamName))
+		}
+		state[t.TeamName] = visiting
+		if parent, ok := byName[t.ParentTeamName]; ok {
+			if err := visit(parent); err != nil {
+				return err
+			}
+		}
+		state[t.TeamName] = done
+		ordered = append(ordered, t)
+		return nil
+	}
+
+	for _, t := range teams {
+		if err := visit(t); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"errors"
-	"math/rand"
+	"fmt"
+	"strings"
+	"time"
 
 	"pull-request-service/internal/model"
 	"pull-request-service/internal/repository"
@@ -13,6 +15,19 @@ import (
 type TeamRepository interface {
 	CreateTeamWithMembers(ctx context.Context, team model.Team) (model.Team, error)
 	GetTeamByName(ctx context.Context, name string) (model.Team, error)
+	ListTeams(ctx context.Context, filter model.TeamFilter) ([]model.TeamSummary, error)
+	AddMembers(ctx context.Context, teamName string, members []model.TeamMember) error
+	RemoveMembers(ctx context.Context, teamName string, userIDs []string) error
+	MoveMembers(ctx context.Context, teamName string, userIDs []string) error
+	ArchiveTeam(ctx context.Context, teamName string, archivedAt time.Time) error
+	DeleteTeam(ctx context.Context, teamName string) error
+	HasHistory(ctx context.Context, teamName string) (bool, error)
+	SetParent(ctx context.Context, teamName, parentName string) error
+	ListDescendants(ctx context.Context, teamName string) ([]model.TeamNode, error)
+	ListTeamStats(ctx context.Context) ([]model.TeamStatsDTO, error)
+	ListRoster(ctx context.Context) ([]model.Team, error)
+	SetNotificationChannel(ctx context.Context, teamName, webhookURL string) error
+	SetLead(ctx context.Context, teamName, userID string) error
 }
 
 // TeamService содержит бизнес-логику по созданию и получению команд.
@@ -21,6 +36,8 @@ type TeamService struct {
 	userRepo  UserRepository // <-- Добавили
 	prRepo    PRRepository   // <-- Добавили
 	txManager TransactionManager
+	rampUp    RampUpPolicy
+	events    EventRecorder
 }
 
 // NewTeamService создаёт новый сервис для операций над командами.
@@ -38,9 +55,23 @@ func NewTeamService(
 	}
 }
 
+// SetRampUpPolicy задаёт ограничение назначений для вернувшихся пользователей
+// при переназначении ревью.
+func (s *TeamService) SetRampUpPolicy(p RampUpPolicy) {
+	s.rampUp = p
+}
+
+// SetEventRecorder задаёт получателя событий о ревью, переназначенных при изменении состава команд.
+func (s *TeamService) SetEventRecorder(r EventRecorder) {
+	s.events = r
+}
+
 // CreateTeam валидирует входные данные и создаёт команду с участниками.
 // В случае конфликтов по имени команды возвращает доменную ошибку TEAM_EXISTS.
-func (s *TeamService) CreateTeam(ctx context.Context, t model.Team) (model.Team, error) {
+// Участники, уже состоящие в других командах, по умолчанию не переносятся:
+// возвращается USER_IN_OTHER_TEAM со списком конфликтов. При moveExisting они переводятся
+// в новую команду так же, как через MoveUser — с переназначением их открытых ревью.
+func (s *TeamService) CreateTeam(ctx context.Context, t model.Team, moveExisting bool) (model.Team, error) {
 	if t.TeamName == "" {
 		return model.Team{}, ErrBadRequest("team_name must not be empty")
 	}
@@ -48,17 +79,55 @@ func (s *TeamService) CreateTeam(ctx context.Context, t model.Team) (model.Team,
 		return model.Team{}, ErrBadRequest("members must not be empty")
 	}
 
-	team, err := s.repo.CreateTeamWithMembers(ctx, t)
-	if err != nil {
-		if errors.Is(err, repository.ErrTeamExists) {
-			return model.Team{}, ErrDomain("TEAM_EXISTS", "team_name already exists")
+	userIDs := make([]string, 0, len(t.Members))
+	for _, m := range t.Members {
+		userIDs = append(userIDs, m.UserID)
+	}
+
+	var team model.Team
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.userRepo.GetByUserIDs(ctx, userIDs)
+		if err != nil {
+			return err
 		}
-		return model.Team{}, &AppError{
-			Code:    "INTERNAL",
-			Message: "failed to create team",
-			Status:  500,
-			Err:     err,
+
+		conflicts := usersInOtherTeams(existing, t.TeamName)
+		if len(conflicts) > 0 && !moveExisting {
+			return errUserInOtherTeam(conflicts)
+		}
+
+		moving := make(map[string]struct{}, len(conflicts))
+		movingIDs := make([]string, 0, len(conflicts))
+		for _, u := range conflicts {
+			moving[u.UserID] = struct{}{}
+			movingIDs = append(movingIDs, u.UserID)
+		}
+
+		toCreate := model.Team{
+			TeamName:       t.TeamName,
+			ParentTeamName: t.ParentTeamName,
+			Members:        make([]model.TeamMember, 0, len(t.Members)),
+		}
+		for _, m := range t.Members {
+			if _, ok := moving[m.UserID]; !ok {
+				toCreate.Members = append(toCreate.Members, m)
+			}
+		}
+
+		if _, err := s.repo.CreateTeamWithMembers(ctx, toCreate); err != nil {
+			return err
 		}
+		if len(movingIDs) > 0 {
+			if err := s.moveMembers(ctx, t.TeamName, movingIDs); err != nil {
+				return err
+			}
+		}
+
+		team, err = s.repo.GetTeamByName(ctx, t.TeamName)
+		return err
+	})
+	if err != nil {
+		return model.Team{}, membershipError(err, "failed to create team")
 	}
 	return team, nil
 }
@@ -83,79 +152,456 @@ func (s *TeamService) GetTeam(ctx context.Context, name string) (model.Team, err
 	return team, nil
 }
 
+// ListTeams возвращает страницу команд и курсор следующей страницы (пустой, если страниц больше нет).
+// Архивные команды скрыты, если не запрошены явно.
+func (s *TeamService) ListTeams(ctx context.Context, filter model.TeamFilter, cursor string) ([]model.TeamSummary, string, error) {
+	limit, err := normalizeLimit(filter.Limit)
+	if err != nil {
+		return nil, "", err
+	}
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filter.Limit = limit + 1
+	filter.After = after
+	teams, err := s.repo.ListTeams(ctx, filter)
+	if err != nil {
+		return nil, "", &AppError{
+			Code:    "INTERNAL",
+			Message: "failed to list teams",
+			Status:  500,
+			Err:     err,
+		}
+	}
+
+	next := ""
+	if len(teams) > limit {
+		teams = teams[:limit]
+		next = encodeCursor(teams[limit-1].TeamName)
+	}
+	return teams, next, nil
+}
+
+// errPreviewRollback откатывает транзакцию, в которой отчёт только рассчитывался.
+var errPreviewRollback = errors.New("preview rollback")
+
 // MassDeactivate деактивирует пользователей и безопасно обновляет PR.
-func (s *TeamService) MassDeactivate(ctx context.Context, userIDs []string) error {
+// Возвращает отчёт по каждому затронутому ревью. В режиме preview все изменения
+// выполняются в транзакции, которая затем откатывается, поэтому отчёт показывает
+// ожидаемые последствия, ничего не меняя (замена выбирается случайно и при реальной
+// деактивации может оказаться другой).
+func (s *TeamService) MassDeactivate(ctx context.Context, userIDs []string, preview bool) (model.DeactivationReport, error) {
+	report := model.DeactivationReport{
+		Preview:       preview,
+		UserIDs:       userIDs,
+		Reassignments: make([]model.ReviewReassignment, 0),
+	}
 	if len(userIDs) == 0 {
+		return report, nil
+	}
+
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.DeactivateUsers(ctx, userIDs); err != nil {
+			return err
+		}
+
+		reassignments, err := s.reassignOpenReviews(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+		report.Reassignments = reassignments
+
+		if preview {
+			return errPreviewRollback
+		}
 		return nil
+	})
+	if err != nil && !errors.Is(err, errPreviewRollback) {
+		return model.DeactivationReport{}, err
+	}
+	return report, nil
+}
+
+// reassignOpenReviews переназначает открытые ревью пользователей userIDs (см. reassignOpenReviews).
+func (s *TeamService) reassignOpenReviews(ctx context.Context, userIDs []string) ([]model.ReviewReassignment, error) {
+	return reassignOpenReviews(ctx, s.userRepo, s.prRepo, s.rampUp, s.events, userIDs)
+}
+
+// AddMembers добавляет участников в существующую команду и возвращает её актуальный состав.
+// Пользователи, уже состоящие в другой команде, не переносятся: для них возвращается USER_IN_OTHER_TEAM.
+func (s *TeamService) AddMembers(ctx context.Context, teamName string, members []model.TeamMember) (model.Team, error) {
+	if teamName == "" {
+		return model.Team{}, ErrBadRequest("team_name is required")
+	}
+	if len(members) == 0 {
+		return model.Team{}, ErrBadRequest("members must not be empty")
 	}
 
-	deactivatingSet := make(map[string]struct{}, len(userIDs))
-	for _, uid := range userIDs {
-		deactivatingSet[uid] = struct{}{}
+	userIDs := make([]string, 0, len(members))
+	for _, m := range members {
+		userIDs = append(userIDs, m.UserID)
 	}
 
-	return s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
-		if err := s.userRepo.DeactivateUsers(ctx, userIDs); err != nil {
+	var team model.Team
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		existing, err := s.userRepo.GetByUserIDs(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+		if conflicts := usersInOtherTeams(existing, teamName); len(conflicts) > 0 {
+			return errUserInOtherTeam(conflicts)
+		}
+
+		if err := s.repo.AddMembers(ctx, teamName, members); err != nil {
 			return err
 		}
 
-		impactedPRsMap, err := s.prRepo.GetOpenPRsByReviewers(ctx, userIDs)
+		team, err = s.repo.GetTeamByName(ctx, teamName)
+		return err
+	})
+	if err != nil {
+		return model.Team{}, membershipError(err, "failed to add members")
+	}
+	return team, nil
+}
+
+// RemoveMembers исключает пользователей из команды. Их открытые ревью переназначаются
+// так же, как при MassDeactivate, а сами пользователи остаются в системе без команды.
+func (s *TeamService) RemoveMembers(ctx context.Context, teamName string, userIDs []string) (model.Team, error) {
+	if teamName == "" {
+		return model.Team{}, ErrBadRequest("team_name is required")
+	}
+	if len(userIDs) == 0 {
+		return model.Team{}, ErrBadRequest("user_ids must not be empty")
+	}
+
+	var team model.Team
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetTeamByName(ctx, teamName)
 		if err != nil {
 			return err
 		}
 
-		if len(impactedPRsMap) == 0 {
-			return nil
+		members := make(map[string]struct{}, len(current.Members))
+		for _, m := range current.Members {
+			members[m.UserID] = struct{}{}
+		}
+		for _, uid := range userIDs {
+			if _, ok := members[uid]; !ok {
+				return ErrDomain("NOT_IN_TEAM", fmt.Sprintf("user %s is not a member of team %s", uid, teamName))
+			}
 		}
 
-		for oldReviewerID, prIDs := range impactedPRsMap {
+		if _, err := s.reassignOpenReviews(ctx, userIDs); err != nil {
+			return err
+		}
+		if err := s.repo.RemoveMembers(ctx, teamName, userIDs); err != nil {
+			return err
+		}
 
-			oldUser, err := s.userRepo.GetByUserID(ctx, oldReviewerID)
-			if err != nil {
-				return err
-			}
+		team, err = s.repo.GetTeamByName(ctx, teamName)
+		return err
+	})
+	if err != nil {
+		return model.Team{}, membershipError(err, "failed to remove members")
+	}
+	return team, nil
+}
 
-			for _, prID := range prIDs {
-				pr, err := s.prRepo.GetPR(ctx, prID)
-				if err != nil {
-					if errors.Is(err, repository.ErrPRNotFound) {
-						continue
-					}
-					return err
-				}
+// MoveUser переводит пользователя в другую команду. Открытые ревью, которые он вёл
+// в прежней команде, переназначаются на её участников. Перевод в текущую команду ничего не меняет.
+func (s *TeamService) MoveUser(ctx context.Context, userID, teamName string) (model.User, error) {
+	if userID == "" {
+		return model.User{}, ErrBadRequest("user_id is required")
+	}
+	if teamName == "" {
+		return model.User{}, ErrBadRequest("team_name is required")
+	}
 
-				exclude := make([]string, 0, len(pr.AssignedReviewers)+len(userIDs)+2)
+	var user model.User
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.userRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.TeamName == teamName {
+			return nil
+		}
 
-				exclude = append(exclude, pr.AuthorID)
+		if err := s.moveMembers(ctx, teamName, []string{userID}); err != nil {
+			return err
+		}
 
-				exclude = append(exclude, pr.AssignedReviewers...)
+		user, err = s.userRepo.GetByUserID(ctx, userID)
+		return err
+	})
+	if err != nil {
+		return model.User{}, membershipError(err, "failed to move user")
+	}
+	return user, nil
+}
 
-				exclude = append(exclude, userIDs...)
+// moveMembers переназначает открытые ревью пользователей и переводит их в команду teamName.
+// Должен вызываться внутри транзакции.
+func (s *TeamService) moveMembers(ctx context.Context, teamName string, userIDs []string) error {
+	if _, err := s.reassignOpenReviews(ctx, userIDs); err != nil {
+		return err
+	}
+	return s.repo.MoveMembers(ctx, teamName, userIDs)
+}
 
-				candidates, err := s.userRepo.ListActiveTeamMembersExcept(ctx, oldUser.TeamName, exclude)
-				if err != nil {
-					return err
-				}
+// ArchiveTeam архивирует команду: деактивирует всех её участников, снимает их с открытых ревью
+// (заменить их внутри команды некем, поэтому ревью передаются родительской команде, а без неё
+// ревьюверы удаляются из PR) и скрывает команду из списков.
+// Состав команды и история PR сохраняются. Повторная архивация ничего не меняет.
+func (s *TeamService) ArchiveTeam(ctx context.Context, teamName string) (model.Team, error) {
+	if teamName == "" {
+		return model.Team{}, ErrBadRequest("team_name is required")
+	}
 
-				if len(candidates) > 0 {
-					newReviewer := candidates[rand.Intn(len(candidates))]
+	var team model.Team
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		current, err := s.repo.GetTeamByName(ctx, teamName)
+		if err != nil {
+			return err
+		}
+		if current.ArchivedAt != nil {
+			team = current
+			return nil
+		}
 
-					if _, err := s.prRepo.ReassignReviewer(ctx, prID, oldReviewerID, newReviewer.UserID); err != nil {
-						return err
-					}
-				} else {
-					if err := s.prRepo.RemoveReviewer(ctx, prID, oldReviewerID); err != nil {
-						return err
-					}
-				}
-			}
+		if err := archiveTeam(ctx, s.repo, s.userRepo, s.prRepo, s.rampUp, s.events, current); err != nil {
+			return err
 		}
 
-		return nil
+		team, err = s.repo.GetTeamByName(ctx, teamName)
+		return err
 	})
+	if err != nil {
+		return model.Team{}, membershipError(err, "failed to archive team")
+	}
+	return team, nil
+}
+
+// archiveTeam деактивирует участников команды team, переназначает их открытые ревью и помечает
+// команду архивной. Должен вызываться внутри транзакции.
+func archiveTeam(
+	ctx context.Context,
+	teamRepo TeamRepository,
+	userRepo UserRepository,
+	prRepo PRRepository,
+	rampUp RampUpPolicy,
+	events EventRecorder,
+	team model.Team,
+) error {
+	userIDs := make([]string, 0, len(team.Members))
+	for _, m := range team.Members {
+		userIDs = append(userIDs, m.UserID)
+	}
+	if len(userIDs) > 0 {
+		if err := userRepo.DeactivateUsers(ctx, userIDs); err != nil {
+			return err
+		}
+		if _, err := reassignOpenReviews(ctx, userRepo, prRepo, rampUp, events, userIDs); err != nil {
+			return err
+		}
+	}
+	return teamRepo.ArchiveTeam(ctx, team.TeamName, time.Now().UTC())
 }
 
-// GetStats возвращает статистику (прокси метод)
-func (s *TeamService) GetStats(ctx context.Context) (interface{}, error) {
+// MoveTeam переносит команду вместе с её подкомандами под parentTeamName.
+// Пустой parentTeamName делает команду корневой. Перенос в собственное поддерево
+// возвращает TEAM_CYCLE, перенос под архивную команду — TEAM_ARCHIVED.
+func (s *TeamService) MoveTeam(ctx context.Context, teamName, parentTeamName string) (model.Team, error) {
+	if teamName == "" {
+		return model.Team{}, ErrBadRequest("team_name is required")
+	}
+	if teamName == parentTeamName {
+		return model.Team{}, membershipError(repository.ErrTeamCycle, "failed to move team")
+	}
+
+	var team model.Team
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.SetParent(ctx, teamName, parentTeamName); err != nil {
+			return err
+		}
+		var err error
+		team, err = s.repo.GetTeamByName(ctx, teamName)
+		return err
+	})
+	if err != nil {
+		return model.Team{}, membershipError(err, "failed to move team")
+	}
+	return team, nil
+}
+
+// ListDescendants возвращает все подкоманды команды по уровням вложенности.
+func (s *TeamService) ListDescendants(ctx context.Context, teamName string) ([]model.TeamNode, error) {
+	if teamName == "" {
+		return nil, ErrBadRequest("team_name is required")
+	}
+	nodes, err := s.repo.ListDescendants(ctx, teamName)
+	if err != nil {
+		return nil, membershipError(err, "failed to list sub-teams")
+	}
+	return nodes, nil
+}
+
+// GetTeamStats возвращает статистику по каждой команде: счётчики её собственных участников (Direct)
+// и суммарные счётчики по всему поддереву (Rollup).
+func (s *TeamService) GetTeamStats(ctx context.Context) ([]model.TeamStatsDTO, error) {
+	stats, err := s.repo.ListTeamStats(ctx)
+	if err != nil {
+		return nil, &AppError{
+			Code:    "INTERNAL",
+			Message: "failed to get team stats",
+			Status:  500,
+			Err:     err,
+		}
+	}
+
+	children := make(map[string][]int, len(stats))
+	for i, st := range stats {
+		if st.ParentTeamName != "" {
+			children[st.ParentTeamName] = append(children[st.ParentTeamName], i)
+		}
+	}
+
+	done := make([]bool, len(stats))
+	var rollup func(i int) model.TeamCounters
+	rollup = func(i int) model.TeamCounters {
+		if done[i] {
+			return stats[i].Rollup
+		}
+		total := stats[i].Direct
+		for _, c := range children[stats[i].TeamName] {
+			sub := rollup(c)
+			total.MembersCount += sub.MembersCount
+			total.ActiveMembersCount += sub.ActiveMembersCount
+			total.ReviewCount += sub.ReviewCount
+			total.OpenReviewCount += sub.OpenReviewCount
+		}
+		stats[i].Rollup = total
+		done[i] = true
+		return total
+	}
+	for i := range stats {
+		rollup(i)
+	}
+	return stats, nil
+}
+
+// DeleteTeam удаляет команду без участников, подкоманд и истории PR.
+// Команду с участниками (в том числе архивную) или подкомандами удалить нельзя — возвращается TEAM_NOT_EMPTY;
+// команду, бывшие участники которой писали или ревьюили PR, — TEAM_HAS_HISTORY: её нужно архивировать.
+func (s *TeamService) DeleteTeam(ctx context.Context, teamName string) error {
+	if teamName == "" {
+		return ErrBadRequest("team_name is required")
+	}
+	if err := s.repo.DeleteTeam(ctx, teamName); err != nil {
+		return membershipError(err, "failed to delete team")
+	}
+	return nil
+}
+
+// usersInOtherTeams возвращает пользователей, которые состоят в команде, отличной от teamName.
+func usersInOtherTeams(users []model.User, teamName string) []model.User {
+	conflicts := make([]model.User, 0)
+	for _, u := range users {
+		if u.TeamName != "" && u.TeamName != teamName {
+			conflicts = append(conflicts, u)
+		}
+	}
+	return conflicts
+}
+
+// UserTeamConflict описывает пользователя, который уже состоит в другой команде.
+type UserTeamConflict struct {
+	UserID   string `json:"user_id"`
+	TeamName string `json:"team_name"`
+}
+
+// errUserInOtherTeam конструирует доменную ошибку USER_IN_OTHER_TEAM со списком конфликтующих пользователей.
+func errUserInOtherTeam(conflicts []model.User) *AppError {
+	parts := make([]string, 0, len(conflicts))
+	details := make([]UserTeamConflict, 0, len(conflicts))
+	for _, u := range conflicts {
+		parts = append(parts, fmt.Sprintf("%s (%s)", u.UserID, u.TeamName))
+		details = append(details, UserTeamConflict{UserID: u.UserID, TeamName: u.TeamName})
+	}
+	appErr := ErrDomain("USER_IN_OTHER_TEAM", "users already belong to another team: "+strings.Join(parts, ", "))
+	appErr.Details = details
+	return appErr
+}
+
+// membershipError переводит ошибки операций над составом и жизненным циклом команды в AppError.
+func membershipError(err error, msg string) error {
+	var appErr *AppError
+	switch {
+	case errors.As(err, &appErr):
+		return appErr
+	case errors.Is(err, repository.ErrTeamExists):
+		return ErrDomain("TEAM_EXISTS", "team_name already exists")
+	case errors.Is(err, repository.ErrTeamNotFound):
+		return ErrNotFound("team not found")
+	case errors.Is(err, repository.ErrUserNotFound):
+		return ErrNotFound("user not found")
+	case errors.Is(err, repository.ErrUserExists):
+		return ErrDomain("USER_EXISTS", "user_id already exists")
+	case errors.Is(err, repository.ErrUserInOtherTeam):
+		return ErrDomain("USER_IN_OTHER_TEAM", "user already belongs to another team")
+	case errors.Is(err, repository.ErrTeamArchived):
+		return ErrDomain("TEAM_ARCHIVED", "team is archived")
+	case errors.Is(err, repository.ErrTeamNotEmpty):
+		return ErrDomain("TEAM_NOT_EMPTY", "team still has members or sub-teams")
+	case errors.Is(err, repository.ErrTeamHasHistory):
+		return ErrDomain("TEAM_HAS_HISTORY", "team has pull request history, archive it instead")
+	case errors.Is(err, repository.ErrParentTeamNotFound):
+		return ErrNotFound("parent team not found")
+	case errors.Is(err, repository.ErrTeamCycle):
+		return ErrDomain("TEAM_CYCLE", "team cannot be moved under itself or its descendant")
+	case errors.Is(err, repository.ErrUsernameTaken):
+		return ErrDomain("USERNAME_TAKEN", "username already taken in team")
+	}
+	return &AppError{
+		Code:    "INTERNAL",
+		Message: msg,
+		Status:  500,
+		Err:     err,
+	}
+}
+
+// GetStats возвращает число назначений по каждому ревьюверу.
+func (s *TeamService) GetStats(ctx context.Context) ([]model.StatsDTO, error) {
 	return s.prRepo.GetReviewerStats(ctx)
 }
+
+// SetNotificationChannel задаёт URL incoming webhook (Slack/Mattermost), в который
+// уходят уведомления о назначении ревьюверов на PR команды. Пустой URL выключает уведомления.
+func (s *TeamService) SetNotificationChannel(ctx context.Context, teamName, webhookURL string) error {
+	if teamName == "" {
+		return ErrBadRequest("team_name is required")
+	}
+	if webhookURL != "" && !isHTTPURL(webhookURL) {
+		return ErrBadRequest("webhook_url must be an absolute http(s) URL")
+	}
+	if err := s.repo.SetNotificationChannel(ctx, teamName, webhookURL); err != nil {
+		return membershipError(err, "failed to set notification channel")
+	}
+	return nil
+}
+
+// SetLead назначает руководителя команды, которому уходит еженедельная сводка по зависшим PR.
+// Пустой userID снимает руководителя.
+func (s *TeamService) SetLead(ctx context.Context, teamName, userID string) error {
+	if teamName == "" {
+		return ErrBadRequest("team_name is required")
+	}
+	if err := s.repo.SetLead(ctx, teamName, userID); err != nil {
+		return membershipError(err, "failed to set team lead")
+	}
+	return nil
+}
@@ -2,12 +2,15 @@ package service_test
 
 import (
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"pull-request-service/internal/model"
+	"pull-request-service/internal/repository"
 	"pull-request-service/internal/service"
 	"pull-request-service/internal/service/mocks"
 )
@@ -20,7 +23,9 @@ func TestTeamService_MassDeactivate(t *testing.T) {
 	tests := []struct {
 		name       string
 		userIDs    []string
+		preview    bool
 		setupMocks func(ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager)
+		wantReport []model.ReviewReassignment
 		wantErr    bool
 	}{
 		{
@@ -36,7 +41,8 @@ func TestTeamService_MassDeactivate(t *testing.T) {
 				// 3. Поиск PR (пусто)
 				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).Return(map[string][]string{}, nil)
 			},
-			wantErr: false,
+			wantReport: []model.ReviewReassignment{},
+			wantErr:    false,
 		},
 		{
 			name:    "Success: Reassign to available candidate",
@@ -74,6 +80,9 @@ func TestTeamService_MassDeactivate(t *testing.T) {
 				pr.On("ReassignReviewer", mock.Anything, "pr-1", "u1", "u2").
 					Return(model.PullRequest{}, nil)
 			},
+			wantReport: []model.ReviewReassignment{
+				{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2", Action: model.ReassignActionReassigned, Reason: model.ReasonTeamMember},
+			},
 			wantErr: false,
 		},
 		{
@@ -91,13 +100,78 @@ func TestTeamService_MassDeactivate(t *testing.T) {
 					PullRequestID: "pr-1", AuthorID: "author", AssignedReviewers: []string{"u1"},
 				}, nil)
 
-				// Кандидатов нет (пустой слайс)
+				// Кандидатов нет (пустой слайс) ни в команде, ни выше по иерархии
 				ur.On("ListActiveTeamMembersExcept", mock.Anything, "backend", mock.Anything).
 					Return([]model.User{}, nil)
+				ur.On("ListActiveAncestorMembersExcept", mock.Anything, "backend", mock.Anything).
+					Return([]model.User{}, nil)
 
 				// Ожидаем УДАЛЕНИЕ
 				pr.On("RemoveReviewer", mock.Anything, "pr-1", "u1").Return(nil)
 			},
+			wantReport: []model.ReviewReassignment{
+				{PullRequestID: "pr-1", OldReviewerID: "u1", Action: model.ReassignActionRemoved, Reason: model.ReasonNoCandidate},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Success: Escalate to nearest parent team",
+			userIDs: []string{"u1"},
+			setupMocks: func(ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				ur.On("DeactivateUsers", mock.Anything, []string{"u1"}).Return(nil)
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).
+					Return(map[string][]string{"u1": {"pr-1"}}, nil)
+				ur.On("GetByUserID", mock.Anything, "u1").Return(u1, nil)
+				pr.On("GetPR", mock.Anything, "pr-1").Return(model.PullRequest{
+					PullRequestID: "pr-1", AuthorID: "author", AssignedReviewers: []string{"u1"},
+				}, nil)
+
+				ur.On("ListActiveTeamMembersExcept", mock.Anything, "backend", mock.Anything).
+					Return([]model.User{}, nil)
+				// Кандидаты есть у родителя и у корня — берётся только ближайший уровень
+				ur.On("ListActiveAncestorMembersExcept", mock.Anything, "backend", mock.Anything).
+					Return([]model.User{
+						{UserID: "u10", TeamName: "engineering", IsActive: true},
+						{UserID: "u20", TeamName: "company", IsActive: true},
+					}, nil)
+
+				pr.On("ReassignReviewer", mock.Anything, "pr-1", "u1", "u10").
+					Return(model.PullRequest{}, nil)
+			},
+			wantReport: []model.ReviewReassignment{
+				{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u10", Action: model.ReassignActionReassigned, Reason: model.ReasonParentTeamMember},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Success: Preview returns report and rolls back",
+			userIDs: []string{"u1"},
+			preview: true,
+			setupMocks: func(ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				// Менеджер транзакций получает ошибку из fn и откатывает транзакцию
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					err := fn(ctx)
+					assert.Error(t, err, "preview must roll back the transaction")
+					return err
+				})
+				ur.On("DeactivateUsers", mock.Anything, []string{"u1"}).Return(nil)
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).
+					Return(map[string][]string{"u1": {"pr-1"}}, nil)
+				ur.On("GetByUserID", mock.Anything, "u1").Return(u1, nil)
+				pr.On("GetPR", mock.Anything, "pr-1").Return(model.PullRequest{
+					PullRequestID: "pr-1", AuthorID: "author", AssignedReviewers: []string{"u1"},
+				}, nil)
+				ur.On("ListActiveTeamMembersExcept", mock.Anything, "backend", mock.Anything).
+					Return([]model.User{u2}, nil)
+				pr.On("ReassignReviewer", mock.Anything, "pr-1", "u1", "u2").
+					Return(model.PullRequest{}, nil)
+			},
+			wantReport: []model.ReviewReassignment{
+				{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2", Action: model.ReassignActionReassigned, Reason: model.ReasonTeamMember},
+			},
 			wantErr: false,
 		},
 	}
@@ -113,15 +187,782 @@ func TestTeamService_MassDeactivate(t *testing.T) {
 			tt.setupMocks(ur, pr, tm)
 
 			svc := service.NewTeamService(tr, ur, pr, tm)
-			err := svc.MassDeactivate(context.Background(), tt.userIDs)
+			report, err := svc.MassDeactivate(context.Background(), tt.userIDs, tt.preview)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.preview, report.Preview)
+				assert.Equal(t, tt.wantReport, report.Reassignments)
+			}
+			ur.AssertExpectations(t)
+			pr.AssertExpectations(t)
+		})
+	}
+}
+
+type txMarker struct{}
+
+func TestTeamService_MassDeactivate_RecordsEvents(t *testing.T) {
+	ur := new(mocks.UserRepository)
+	pr := new(mocks.PRRepository)
+	tm := new(mocks.TransactionManager)
+	events := new(mocks.EventRecorder)
+
+	// Транзакция помечает контекст, чтобы проверить, что события пишутся внутри неё
+	tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(context.WithValue(ctx, txMarker{}, true))
+	})
+	ur.On("DeactivateUsers", mock.Anything, []string{"u1"}).Return(nil)
+	pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).
+		Return(map[string][]string{"u1": {"pr-1"}}, nil)
+	ur.On("GetByUserID", mock.Anything, "u1").Return(model.User{UserID: "u1", TeamName: "backend"}, nil)
+	pr.On("GetPR", mock.Anything, "pr-1").Return(model.PullRequest{
+		PullRequestID: "pr-1", AuthorID: "u9", AssignedReviewers: []string{"u1"},
+	}, nil)
+	ur.On("ListActiveTeamMembersExcept", mock.Anything, "backend", mock.Anything).
+		Return([]model.User{{UserID: "u2", TeamName: "backend", IsActive: true}}, nil)
+	pr.On("ReassignReviewer", mock.Anything, "pr-1", "u1", "u2").Return(model.PullRequest{}, nil)
+	events.On("RecordEvents", mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Value(txMarker{}) == true
+	}), mock.MatchedBy(func(evs []model.Event) bool {
+		return len(evs) == 1 && evs[0].Type == model.EventReviewerAssigned &&
+			evs[0].ReviewerID == "u2" && evs[0].ReplacedReviewerID == "u1" &&
+			evs[0].Reason == string(model.ReasonTeamMember)
+	})).Return(nil)
+
+	svc := service.NewTeamService(new(mocks.TeamRepository), ur, pr, tm)
+	svc.SetEventRecorder(events)
+	_, err := svc.MassDeactivate(context.Background(), []string{"u1"}, false)
+
+	assert.NoError(t, err)
+	events.AssertExpectations(t)
+}
+
+func TestTeamService_AddMembers(t *testing.T) {
+	members := []model.TeamMember{
+		{UserID: "u3", Username: "Carol", IsActive: true},
+		{UserID: "u4", Username: "Dave", IsActive: true},
+	}
+
+	tests := []struct {
+		name       string
+		setupMocks func(tr *mocks.TeamRepository, ur *mocks.UserRepository, tm *mocks.TransactionManager)
+		wantCode   string
+	}{
+		{
+			name: "Success: New users are added",
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				ur.On("GetByUserIDs", mock.Anything, []string{"u3", "u4"}).Return([]model.User{}, nil)
+				tr.On("AddMembers", mock.Anything, "backend", members).Return(nil)
+				tr.On("GetTeamByName", mock.Anything, "backend").Return(model.Team{TeamName: "backend", Members: members}, nil)
+			},
+		},
+		{
+			name: "Fail: User belongs to another team",
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				ur.On("GetByUserIDs", mock.Anything, []string{"u3", "u4"}).
+					Return([]model.User{{UserID: "u4", TeamName: "payments", IsActive: true}}, nil)
+				// AddMembers не должен вызываться
+			},
+			wantCode: "USER_IN_OTHER_TEAM",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			ur := new(mocks.UserRepository)
+			pr := new(mocks.PRRepository)
+			tm := new(mocks.TransactionManager)
+
+			tt.setupMocks(tr, ur, tm)
+
+			svc := service.NewTeamService(tr, ur, pr, tm)
+			team, err := svc.AddMembers(context.Background(), "backend", members)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, team.Members, 2)
+			}
+			tr.AssertExpectations(t)
+			ur.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_RemoveMembers(t *testing.T) {
+	u1 := model.User{UserID: "u1", TeamName: "backend", IsActive: true}
+	u2 := model.User{UserID: "u2", TeamName: "backend", IsActive: true}
+	backend := model.Team{TeamName: "backend", Members: []model.TeamMember{
+		{UserID: "u1", IsActive: true},
+		{UserID: "u2", IsActive: true},
+	}}
+
+	tests := []struct {
+		name       string
+		userIDs    []string
+		setupMocks func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager)
+		wantCode   string
+	}{
+		{
+			name:    "Success: Open reviews are reassigned before removal",
+			userIDs: []string{"u1"},
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				tr.On("GetTeamByName", mock.Anything, "backend").Return(backend, nil)
+
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).
+					Return(map[string][]string{"u1": {"pr-1"}}, nil)
+				ur.On("GetByUserID", mock.Anything, "u1").Return(u1, nil)
+				pr.On("GetPR", mock.Anything, "pr-1").Return(model.PullRequest{
+					PullRequestID: "pr-1", AuthorID: "author", AssignedReviewers: []string{"u1"},
+				}, nil)
+				ur.On("ListActiveTeamMembersExcept", mock.Anything, "backend", mock.Anything).
+					Return([]model.User{u2}, nil)
+				pr.On("ReassignReviewer", mock.Anything, "pr-1", "u1", "u2").
+					Return(model.PullRequest{}, nil)
+
+				tr.On("RemoveMembers", mock.Anything, "backend", []string{"u1"}).Return(nil)
+			},
+		},
+		{
+			name:    "Fail: User is not a member",
+			userIDs: []string{"u9"},
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				tr.On("GetTeamByName", mock.Anything, "backend").Return(backend, nil)
+			},
+			wantCode: "NOT_IN_TEAM",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			ur := new(mocks.UserRepository)
+			pr := new(mocks.PRRepository)
+			tm := new(mocks.TransactionManager)
+
+			tt.setupMocks(tr, ur, pr, tm)
+
+			svc := service.NewTeamService(tr, ur, pr, tm)
+			_, err := svc.RemoveMembers(context.Background(), "backend", tt.userIDs)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+			}
+			tr.AssertExpectations(t)
+			ur.AssertExpectations(t)
+			pr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_MoveUser(t *testing.T) {
+	u1 := model.User{UserID: "u1", TeamName: "backend", IsActive: true}
+
+	tests := []struct {
+		name       string
+		teamName   string
+		setupMocks func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager)
+		wantTeam   string
+		wantErr    bool
+	}{
+		{
+			name:     "Success: Moved to another team",
+			teamName: "payments",
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				ur.On("GetByUserID", mock.Anything, "u1").Return(u1, nil).Once()
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).Return(map[string][]string{}, nil)
+				tr.On("MoveMembers", mock.Anything, "payments", []string{"u1"}).Return(nil)
+				ur.On("GetByUserID", mock.Anything, "u1").
+					Return(model.User{UserID: "u1", TeamName: "payments", IsActive: true}, nil).Once()
+			},
+			wantTeam: "payments",
+		},
+		{
+			name:     "Success: Same team is a no-op",
+			teamName: "backend",
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				ur.On("GetByUserID", mock.Anything, "u1").Return(u1, nil)
+			},
+			wantTeam: "backend",
+		},
+		{
+			name:     "Fail: Target team not found",
+			teamName: "ghosts",
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				ur.On("GetByUserID", mock.Anything, "u1").Return(u1, nil)
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).Return(map[string][]string{}, nil)
+				tr.On("MoveMembers", mock.Anything, "ghosts", []string{"u1"}).Return(repository.ErrTeamNotFound)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			ur := new(mocks.UserRepository)
+			pr := new(mocks.PRRepository)
+			tm := new(mocks.TransactionManager)
+
+			tt.setupMocks(tr, ur, pr, tm)
+
+			svc := service.NewTeamService(tr, ur, pr, tm)
+			user, err := svc.MoveUser(context.Background(), "u1", tt.teamName)
+
+			if tt.wantErr {
+				assert.True(t, service.IsNotFound(err))
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTeam, user.TeamName)
+			}
+			tr.AssertExpectations(t)
+			ur.AssertExpectations(t)
+			pr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_ArchiveTeam(t *testing.T) {
+	archivedAt := time.Now().UTC()
+	backend := model.Team{TeamName: "backend", Members: []model.TeamMember{
+		{UserID: "u1", IsActive: true},
+		{UserID: "u2", IsActive: true},
+	}}
+
+	tests := []struct {
+		name       string
+		setupMocks func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager)
+	}{
+		{
+			name: "Success: Members deactivated and detached from reviews",
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				tr.On("GetTeamByName", mock.Anything, "backend").Return(backend, nil).Once()
+				ur.On("DeactivateUsers", mock.Anything, []string{"u1", "u2"}).Return(nil)
+
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1", "u2"}).
+					Return(map[string][]string{"u1": {"pr-1"}}, nil)
+				ur.On("GetByUserID", mock.Anything, "u1").
+					Return(model.User{UserID: "u1", TeamName: "backend"}, nil)
+				pr.On("GetPR", mock.Anything, "pr-1").Return(model.PullRequest{
+					PullRequestID: "pr-1", AuthorID: "u9", AssignedReviewers: []string{"u1"},
+				}, nil)
+				// Вся команда уходит в архив, родительской команды нет — заменить ревьювера некем
+				ur.On("ListActiveTeamMembersExcept", mock.Anything, "backend", mock.Anything).
+					Return([]model.User{}, nil)
+				ur.On("ListActiveAncestorMembersExcept", mock.Anything, "backend", mock.Anything).
+					Return([]model.User{}, nil)
+				pr.On("RemoveReviewer", mock.Anything, "pr-1", "u1").Return(nil)
+
+				tr.On("ArchiveTeam", mock.Anything, "backend", mock.AnythingOfType("time.Time")).Return(nil)
+				tr.On("GetTeamByName", mock.Anything, "backend").
+					Return(model.Team{TeamName: "backend", ArchivedAt: &archivedAt}, nil).Once()
+			},
+		},
+		{
+			name: "Success: Already archived team is left as is",
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				tr.On("GetTeamByName", mock.Anything, "backend").
+					Return(model.Team{TeamName: "backend", ArchivedAt: &archivedAt}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			ur := new(mocks.UserRepository)
+			pr := new(mocks.PRRepository)
+			tm := new(mocks.TransactionManager)
+
+			tt.setupMocks(tr, ur, pr, tm)
+
+			svc := service.NewTeamService(tr, ur, pr, tm)
+			team, err := svc.ArchiveTeam(context.Background(), "backend")
+
+			assert.NoError(t, err)
+			assert.NotNil(t, team.ArchivedAt)
+			tr.AssertExpectations(t)
+			ur.AssertExpectations(t)
+			pr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_DeleteTeam(t *testing.T) {
+	tests := []struct {
+		name     string
+		repoErr  error
+		wantCode string
+	}{
+		{name: "Success: Team without members and history"},
+		{name: "Fail: Team still has members", repoErr: repository.ErrTeamNotEmpty, wantCode: "TEAM_NOT_EMPTY"},
+		{name: "Fail: Emptied team with PR history", repoErr: repository.ErrTeamHasHistory, wantCode: "TEAM_HAS_HISTORY"},
+		{name: "Fail: Unknown team", repoErr: repository.ErrTeamNotFound, wantCode: "NOT_FOUND"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			tr.On("DeleteTeam", mock.Anything, "legacy").Return(tt.repoErr)
+
+			svc := service.NewTeamService(tr, new(mocks.UserRepository), new(mocks.PRRepository), new(mocks.TransactionManager))
+			err := svc.DeleteTeam(context.Background(), "legacy")
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
 			} else {
 				assert.NoError(t, err)
 			}
+			tr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_CreateTeam(t *testing.T) {
+	input := model.Team{TeamName: "platform", Members: []model.TeamMember{
+		{UserID: "u1", Username: "Alice", IsActive: true},
+		{UserID: "u5", Username: "Eve", IsActive: true},
+	}}
+	// u1 уже состоит в backend, u5 — новый пользователь
+	existing := []model.User{{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: false}}
+
+	tests := []struct {
+		name         string
+		moveExisting bool
+		setupMocks   func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager)
+		wantCode     string
+	}{
+		{
+			name: "Fail: Users from other teams are not taken silently",
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				ur.On("GetByUserIDs", mock.Anything, []string{"u1", "u5"}).Return(existing, nil)
+				// CreateTeamWithMembers не должен вызываться
+			},
+			wantCode: "USER_IN_OTHER_TEAM",
+		},
+		{
+			name:         "Success: move_existing moves users with reassignment",
+			moveExisting: true,
+			setupMocks: func(tr *mocks.TeamRepository, ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				ur.On("GetByUserIDs", mock.Anything, []string{"u1", "u5"}).Return(existing, nil)
+
+				// Создаётся команда только с новыми пользователями, is_active u1 не перезаписывается
+				tr.On("CreateTeamWithMembers", mock.Anything, model.Team{
+					TeamName: "platform",
+					Members:  []model.TeamMember{{UserID: "u5", Username: "Eve", IsActive: true}},
+				}).Return(model.Team{}, nil)
+
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).Return(map[string][]string{}, nil)
+				tr.On("MoveMembers", mock.Anything, "platform", []string{"u1"}).Return(nil)
+				tr.On("GetTeamByName", mock.Anything, "platform").Return(model.Team{TeamName: "platform", Members: []model.TeamMember{
+					{UserID: "u1", Username: "Alice", IsActive: false},
+					{UserID: "u5", Username: "Eve", IsActive: true},
+				}}, nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			ur := new(mocks.UserRepository)
+			pr := new(mocks.PRRepository)
+			tm := new(mocks.TransactionManager)
+
+			tt.setupMocks(tr, ur, pr, tm)
+
+			svc := service.NewTeamService(tr, ur, pr, tm)
+			team, err := svc.CreateTeam(context.Background(), input, tt.moveExisting)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+				assert.Equal(t, []service.UserTeamConflict{{UserID: "u1", TeamName: "backend"}}, appErr.Details)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, team.Members, 2)
+			}
+			tr.AssertExpectations(t)
 			ur.AssertExpectations(t)
 			pr.AssertExpectations(t)
 		})
 	}
 }
+
+func TestTeamService_MoveTeam(t *testing.T) {
+	tests := []struct {
+		name       string
+		parent     string
+		setupMocks func(tr *mocks.TeamRepository, tm *mocks.TransactionManager)
+		wantCode   string
+	}{
+		{
+			name:   "Success: Subtree moved under parent",
+			parent: "engineering",
+			setupMocks: func(tr *mocks.TeamRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				tr.On("SetParent", mock.Anything, "backend", "engineering").Return(nil)
+				tr.On("GetTeamByName", mock.Anything, "backend").
+					Return(model.Team{TeamName: "backend", ParentTeamName: "engineering"}, nil)
+			},
+		},
+		{
+			name:   "Fail: Move under own descendant",
+			parent: "backend-infra",
+			setupMocks: func(tr *mocks.TeamRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				tr.On("SetParent", mock.Anything, "backend", "backend-infra").Return(repository.ErrTeamCycle)
+			},
+			wantCode: "TEAM_CYCLE",
+		},
+		{
+			name:   "Fail: Move under itself",
+			parent: "backend",
+			setupMocks: func(tr *mocks.TeamRepository, tm *mocks.TransactionManager) {
+				// Repo не должен вызываться
+			},
+			wantCode: "TEAM_CYCLE",
+		},
+		{
+			name:   "Fail: Parent not found",
+			parent: "ghost",
+			setupMocks: func(tr *mocks.TeamRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				tr.On("SetParent", mock.Anything, "backend", "ghost").Return(repository.ErrParentTeamNotFound)
+			},
+			wantCode: "NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			tm := new(mocks.TransactionManager)
+			tt.setupMocks(tr, tm)
+
+			svc := service.NewTeamService(tr, new(mocks.UserRepository), new(mocks.PRRepository), tm)
+			team, err := svc.MoveTeam(context.Background(), "backend", tt.parent)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.parent, team.ParentTeamName)
+			}
+			tr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_GetTeamStats(t *testing.T) {
+	tr := new(mocks.TeamRepository)
+	// Дочерние команды приходят раньше родителя — свёртка не должна зависеть от порядка
+	tr.On("ListTeamStats", mock.Anything).Return([]model.TeamStatsDTO{
+		{TeamName: "backend", ParentTeamName: "engineering", Direct: model.TeamCounters{MembersCount: 3, ActiveMembersCount: 2, ReviewCount: 5, OpenReviewCount: 1}},
+		{TeamName: "engineering", Direct: model.TeamCounters{MembersCount: 1, ActiveMembersCount: 1}},
+		{TeamName: "infra", ParentTeamName: "backend", Direct: model.TeamCounters{MembersCount: 2, ActiveMembersCount: 2, ReviewCount: 4, OpenReviewCount: 2}},
+		{TeamName: "sales", Direct: model.TeamCounters{MembersCount: 4, ActiveMembersCount: 4}},
+	}, nil)
+
+	svc := service.NewTeamService(tr, new(mocks.UserRepository), new(mocks.PRRepository), new(mocks.TransactionManager))
+	stats, err := svc.GetTeamStats(context.Background())
+
+	assert.NoError(t, err)
+	rollups := make(map[string]model.TeamCounters, len(stats))
+	for _, st := range stats {
+		rollups[st.TeamName] = st.Rollup
+	}
+	assert.Equal(t, model.TeamCounters{MembersCount: 5, ActiveMembersCount: 4, ReviewCount: 9, OpenReviewCount: 3}, rollups["backend"])
+	assert.Equal(t, model.TeamCounters{MembersCount: 6, ActiveMembersCount: 5, ReviewCount: 9, OpenReviewCount: 3}, rollups["engineering"])
+	assert.Equal(t, model.TeamCounters{MembersCount: 4, ActiveMembersCount: 4}, rollups["sales"])
+	tr.AssertExpectations(t)
+}
+
+func TestTeamService_ImportRoster(t *testing.T) {
+	roster := model.Roster{Teams: []model.Team{
+		// Подкоманда идёт раньше родителя — импорт должен сам упорядочить создание
+		{TeamName: "payments", ParentTeamName: "engineering", Members: []model.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true}, // новый пользователь
+			{UserID: "u2", Username: "Bob", IsActive: true},   // переводится из backend
+		}},
+		{TeamName: "engineering", Members: []model.TeamMember{
+			{UserID: "u3", Username: "Carol2", IsActive: true}, // меняется username
+		}},
+	}}
+
+	tr := new(mocks.TeamRepository)
+	ur := new(mocks.UserRepository)
+	pr := new(mocks.PRRepository)
+	tm := new(mocks.TransactionManager)
+
+	tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+		return fn(ctx)
+	})
+	ur.On("GetByUserIDs", mock.Anything, []string{"u3", "u1", "u2"}).Return([]model.User{
+		{UserID: "u2", Username: "Bob", TeamName: "backend", IsActive: true},
+		{UserID: "u3", Username: "Carol", TeamName: "engineering", IsActive: true},
+	}, nil)
+
+	// engineering уже есть и остаётся корневой
+	tr.On("GetTeamByName", mock.Anything, "engineering").Return(model.Team{TeamName: "engineering"}, nil)
+	tr.On("AddMembers", mock.Anything, "engineering", roster.Teams[1].Members).Return(nil)
+
+	// payments создаётся под engineering, u2 переводится с переназначением ревью
+	tr.On("GetTeamByName", mock.Anything, "payments").Return(model.Team{}, repository.ErrTeamNotFound)
+	tr.On("CreateTeamWithMembers", mock.Anything, model.Team{TeamName: "payments", ParentTeamName: "engineering"}).
+		Return(model.Team{}, nil)
+	pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u2"}).Return(map[string][]string{}, nil)
+	tr.On("MoveMembers", mock.Anything, "payments", []string{"u2"}).Return(nil)
+	tr.On("AddMembers", mock.Anything, "payments", roster.Teams[0].Members).Return(nil)
+
+	svc := service.NewTeamService(tr, ur, pr, tm)
+	diff, err := svc.ImportRoster(context.Background(), roster)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"payments"}, diff.TeamsCreated)
+	assert.Empty(t, diff.TeamsMoved)
+	assert.Equal(t, []string{"u1"}, diff.UsersCreated)
+	assert.Equal(t, []string{"u3"}, diff.UsersUpdated)
+	assert.Equal(t, []model.UserTeamChange{{UserID: "u2", From: "backend", To: "payments"}}, diff.UsersMoved)
+	tr.AssertExpectations(t)
+	ur.AssertExpectations(t)
+	pr.AssertExpectations(t)
+}
+
+func TestTeamService_ImportRoster_Cycle(t *testing.T) {
+	roster := model.Roster{Teams: []model.Team{
+		{TeamName: "a", ParentTeamName: "b", Members: []model.TeamMember{{UserID: "u1", Username: "A"}}},
+		{TeamName: "b", ParentTeamName: "a", Members: []model.TeamMember{{UserID: "u2", Username: "B"}}},
+	}}
+
+	svc := service.NewTeamService(new(mocks.TeamRepository), new(mocks.UserRepository), new(mocks.PRRepository), new(mocks.TransactionManager))
+	_, err := svc.ImportRoster(context.Background(), roster)
+
+	var appErr *service.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, "BAD_REQUEST", appErr.Code)
+}
+
+func TestTeamService_SyncRoster(t *testing.T) {
+	roster := model.Roster{Teams: []model.Team{
+		{TeamName: "backend", Members: []model.TeamMember{
+			{UserID: "u1", Username: "Alice", IsActive: true}, // возвращается из отпуска
+		}},
+	}}
+
+	for _, planOnly := range []bool{false, true} {
+		t.Run(fmt.Sprintf("plan_only=%v", planOnly), func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			ur := new(mocks.UserRepository)
+			pr := new(mocks.PRRepository)
+			tm := new(mocks.TransactionManager)
+
+			tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+				err := fn(ctx)
+				// В режиме плана транзакция обязана откатиться
+				assert.Equal(t, planOnly, err != nil)
+				return err
+			})
+			ur.On("GetByUserIDs", mock.Anything, []string{"u1"}).
+				Return([]model.User{{UserID: "u1", Username: "Alice", TeamName: "backend", IsActive: false}}, nil)
+			tr.On("GetTeamByName", mock.Anything, "backend").Return(model.Team{TeamName: "backend"}, nil)
+			tr.On("AddMembers", mock.Anything, "backend", roster.Teams[0].Members).Return(nil)
+
+			// u2 нет в файле — он деактивируется, его ревью передаются u1
+			ur.On("ListActiveUserIDs", mock.Anything).Return([]string{"u1", "u2"}, nil)
+			ur.On("DeactivateUsers", mock.Anything, []string{"u2"}).Return(nil)
+			pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u2"}).
+				Return(map[string][]string{"u2": {"pr-1"}}, nil)
+			ur.On("GetByUserID", mock.Anything, "u2").Return(model.User{UserID: "u2", TeamName: "backend"}, nil)
+			pr.On("GetPR", mock.Anything, "pr-1").Return(model.PullRequest{
+				PullRequestID: "pr-1", AuthorID: "u9", AssignedReviewers: []string{"u2"},
+			}, nil)
+			ur.On("ListActiveTeamMembersExcept", mock.Anything, "backend", mock.Anything).
+				Return([]model.User{{UserID: "u1", TeamName: "backend", IsActive: true}}, nil)
+			pr.On("ReassignReviewer", mock.Anything, "pr-1", "u2", "u1").Return(model.PullRequest{}, nil)
+
+			svc := service.NewTeamService(tr, ur, pr, tm)
+			diff, err := svc.SyncRoster(context.Background(), roster, planOnly)
+
+			assert.NoError(t, err)
+			assert.Equal(t, planOnly, diff.PlanOnly)
+			assert.Equal(t, []string{"u1"}, diff.UsersReactivated)
+			assert.Equal(t, []string{"u2"}, diff.UsersDeactivated)
+			assert.Equal(t, []model.ReviewReassignment{
+				{PullRequestID: "pr-1", OldReviewerID: "u2", NewReviewerID: "u1", Action: model.ReassignActionReassigned, Reason: model.ReasonTeamMember},
+			}, diff.Reassignments)
+			tr.AssertExpectations(t)
+			ur.AssertExpectations(t)
+			pr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_SetNotificationChannel(t *testing.T) {
+	tests := []struct {
+		name       string
+		teamName   string
+		webhookURL string
+		setupMocks func(tr *mocks.TeamRepository)
+		wantCode   string
+	}{
+		{
+			name:       "Success",
+			teamName:   "backend",
+			webhookURL: "https://hooks.slack.com/services/T0/B0/x",
+			setupMocks: func(tr *mocks.TeamRepository) {
+				tr.On("SetNotificationChannel", mock.Anything, "backend", "https://hooks.slack.com/services/T0/B0/x").Return(nil)
+			},
+		},
+		{
+			name:     "Success: empty URL disables notifications",
+			teamName: "backend",
+			setupMocks: func(tr *mocks.TeamRepository) {
+				tr.On("SetNotificationChannel", mock.Anything, "backend", "").Return(nil)
+			},
+		},
+		{
+			name:       "Fail: Not an http URL",
+			teamName:   "backend",
+			webhookURL: "slack://channel",
+			setupMocks: func(tr *mocks.TeamRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "Fail: Team not found",
+			teamName:   "ghosts",
+			webhookURL: "https://chat.example.com/hooks/1",
+			setupMocks: func(tr *mocks.TeamRepository) {
+				tr.On("SetNotificationChannel", mock.Anything, "ghosts", "https://chat.example.com/hooks/1").Return(repository.ErrTeamNotFound)
+			},
+			wantCode: "NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			tt.setupMocks(tr)
+
+			svc := service.NewTeamService(tr, new(mocks.UserRepository), new(mocks.PRRepository), new(mocks.TransactionManager))
+			err := svc.SetNotificationChannel(context.Background(), tt.teamName, tt.webhookURL)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+			}
+			tr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestTeamService_SetLead(t *testing.T) {
+	tests := []struct {
+		name       string
+		teamName   string
+		userID     string
+		setupMocks func(tr *mocks.TeamRepository)
+		wantCode   string
+	}{
+		{
+			name:     "Success",
+			teamName: "backend",
+			userID:   "u1",
+			setupMocks: func(tr *mocks.TeamRepository) {
+				tr.On("SetLead", mock.Anything, "backend", "u1").Return(nil)
+			},
+		},
+		{
+			name:       "Fail: Empty team name",
+			userID:     "u1",
+			setupMocks: func(tr *mocks.TeamRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:     "Fail: User not found",
+			teamName: "backend",
+			userID:   "u404",
+			setupMocks: func(tr *mocks.TeamRepository) {
+				tr.On("SetLead", mock.Anything, "backend", "u404").Return(repository.ErrUserNotFound)
+			},
+			wantCode: "NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := new(mocks.TeamRepository)
+			tt.setupMocks(tr)
+
+			svc := service.NewTeamService(tr, new(mocks.UserRepository), new(mocks.PRRepository), new(mocks.TransactionManager))
+			err := svc.SetLead(context.Background(), tt.teamName, tt.userID)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+			}
+			tr.AssertExpectations(t)
+		})
+	}
+}
@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"errors"
+	"net/mail"
+	"strings"
+	"time"
 
 	"pull-request-service/internal/model"
 	"pull-request-service/internal/repository"
@@ -11,38 +14,212 @@ import (
 // UserRepository описывает контракт репозитория пользователей для бизнес-слоя.
 type UserRepository interface {
 	GetByUserID(ctx context.Context, userID string) (model.User, error)
+	GetByUserIDs(ctx context.Context, userIDs []string) ([]model.User, error)
 	SetIsActive(ctx context.Context, userID string, isActive bool) (model.User, error)
 	ListActiveTeamMembersExcept(ctx context.Context, teamName string, exclude []string) ([]model.User, error)
+	ListActiveAncestorMembersExcept(ctx context.Context, teamName string, exclude []string) ([]model.User, error)
+	ListUsers(ctx context.Context, filter model.UserFilter) ([]model.User, error)
+	CountUsers(ctx context.Context, filter model.UserFilter) (int, error)
+	CreateUser(ctx context.Context, u model.User) error
+	SetUsername(ctx context.Context, userID, username string) error
+	ListRampUp(ctx context.Context, userIDs []string, since time.Time) ([]model.UserRampUp, error)
+	ListActivityEvents(ctx context.Context, userID string) ([]model.UserActivityEvent, error)
+	ListActiveUserIDs(ctx context.Context) ([]string, error)
 	DeactivateUsers(ctx context.Context, userIDs []string) error
+	SetChatHandle(ctx context.Context, userID, handle string) error
+	SetEmailPreferences(ctx context.Context, userID, email string, mode model.EmailMode) error
+	SetWorkingHours(ctx context.Context, userID string, hours *model.WorkingHours) error
+	ListWorkingHours(ctx context.Context, userIDs []string) ([]model.WorkingHours, error)
 }
 
 // UserService содержит бизнес-логику, связанную с пользователями,
 // в частности управление их активностью.
 type UserService struct {
-	repo UserRepository
+	repo      UserRepository
+	prRepo    PRRepository
+	txManager TransactionManager
+	rampUp    RampUpPolicy
+	events    EventRecorder
 }
 
 // NewUserService создаёт новый сервис для операций над пользователями.
-func NewUserService(repo UserRepository) *UserService {
-	return &UserService{repo: repo}
+func NewUserService(repo UserRepository, prRepo PRRepository, txManager TransactionManager) *UserService {
+	return &UserService{
+		repo:      repo,
+		prRepo:    prRepo,
+		txManager: txManager,
+	}
+}
+
+// SetRampUpPolicy задаёт ограничение назначений для вернувшихся пользователей
+// при переназначении ревью.
+func (s *UserService) SetRampUpPolicy(p RampUpPolicy) {
+	s.rampUp = p
+}
+
+// SetEventRecorder задаёт получателя событий о ревью, переназначенных при деактивации.
+func (s *UserService) SetEventRecorder(r EventRecorder) {
+	s.events = r
 }
 
 // SetIsActive обновляет признак активности пользователя и возвращает его актуальное состояние.
-func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive bool) (model.User, error) {
+// При деактивации открытые ревью пользователя в той же транзакции переназначаются так же,
+// как в MassDeactivate; список затронутых PR и новых ревьюверов возвращается вторым значением.
+func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive bool) (model.User, []model.ReviewReassignment, error) {
 	if userID == "" {
-		return model.User{}, ErrBadRequest("user_id is required")
+		return model.User{}, nil, ErrBadRequest("user_id is required")
 	}
-	user, err := s.repo.SetIsActive(ctx, userID, isActive)
+
+	var (
+		user          model.User
+		reassignments []model.ReviewReassignment
+	)
+	err := s.txManager.RunInTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = s.repo.SetIsActive(ctx, userID, isActive)
+		if err != nil || isActive {
+			return err
+		}
+		reassignments, err = reassignOpenReviews(ctx, s.repo, s.prRepo, s.rampUp, s.events, []string{userID})
+		return err
+	})
 	if err != nil {
 		if errors.Is(err, repository.ErrUserNotFound) {
-			return model.User{}, ErrNotFound("user not found")
+			return model.User{}, nil, ErrNotFound("user not found")
 		}
-		return model.User{}, &AppError{
+		return model.User{}, nil, &AppError{
 			Code:    "INTERNAL",
 			Message: "failed to update user",
 			Status:  500,
 			Err:     err,
 		}
 	}
-	return user, nil
+	return user, reassignments, nil
+}
+
+// ListUsers возвращает страницу пользователей по фильтрам и курсор следующей страницы
+// (пустой, если страниц больше нет). Для каждого пользователя указана его команда.
+func (s *UserService) ListUsers(ctx context.Context, filter model.UserFilter, cursor string) ([]model.User, string, error) {
+	limit, err := normalizeLimit(filter.Limit)
+	if err != nil {
+		return nil, "", err
+	}
+	after, err := decodeCursor(cursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filter.Limit = limit + 1
+	filter.After = after
+	users, err := s.repo.ListUsers(ctx, filter)
+	if err != nil {
+		return nil, "", &AppError{
+			Code:    "INTERNAL",
+			Message: "failed to list users",
+			Status:  500,
+			Err:     err,
+		}
+	}
+
+	next := ""
+	if len(users) > limit {
+		users = users[:limit]
+		next = encodeCursor(users[limit-1].UserID)
+	}
+	return users, next, nil
+}
+
+// ListActivity возвращает историю активаций и деактиваций пользователя, новые события первыми.
+func (s *UserService) ListActivity(ctx context.Context, userID string) ([]model.UserActivityEvent, error) {
+	if userID == "" {
+		return nil, ErrBadRequest("user_id is required")
+	}
+	events, err := s.repo.ListActivityEvents(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return nil, ErrNotFound("user not found")
+		}
+		return nil, &AppError{
+			Code:    "INTERNAL",
+			Message: "failed to list activity events",
+			Status:  500,
+			Err:     err,
+		}
+	}
+	return events, nil
+}
+
+// SetChatHandle задаёт, как упоминать пользователя в уведомлениях чата: строка подставляется
+// в сообщение как есть (`<@U024BE7LH>` для Slack, `@login` для Mattermost). Пустая строка
+// возвращает упоминание по username.
+func (s *UserService) SetChatHandle(ctx context.Context, userID, handle string) error {
+	if userID == "" {
+		return ErrBadRequest("user_id is required")
+	}
+	if err := s.repo.SetChatHandle(ctx, userID, strings.TrimSpace(handle)); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrNotFound("user not found")
+		}
+		return &AppError{Code: "INTERNAL", Message: "failed to set chat handle", Status: 500, Err: err}
+	}
+	return nil
+}
+
+// SetEmailPreferences задаёт адрес и режим почтовых уведомлений: immediate — письмо на каждое
+// назначение, hourly/daily — сводка ожидающих ревью, off — без писем (адрес можно не указывать).
+func (s *UserService) SetEmailPreferences(ctx context.Context, userID, email string, mode model.EmailMode) error {
+	if userID == "" {
+		return ErrBadRequest("user_id is required")
+	}
+	if !mode.IsValid() {
+		return ErrBadRequest("mode must be one of off, immediate, hourly, daily")
+	}
+	email = strings.TrimSpace(email)
+	if email == "" && mode != model.EmailOff {
+		return ErrBadRequest("email is required unless mode is off")
+	}
+	if email != "" {
+		addr, err := mail.ParseAddress(email)
+		if err != nil || addr.Address != email {
+			return ErrBadRequest("email must be a plain address, e.g. alice@example.com")
+		}
+	}
+
+	if err := s.repo.SetEmailPreferences(ctx, userID, email, mode); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrNotFound("user not found")
+		}
+		return &AppError{Code: "INTERNAL", Message: "failed to set email preferences", Status: 500, Err: err}
+	}
+	return nil
+}
+
+// SetWorkingHours задаёт рабочие часы пользователя, которые учитываются при выборе ревьюверов
+// hotfix-PR; nil снимает расписание. Пустой часовой пояс означает UTC.
+func (s *UserService) SetWorkingHours(ctx context.Context, userID string, hours *model.WorkingHours) error {
+	if userID == "" {
+		return ErrBadRequest("user_id is required")
+	}
+	if hours != nil {
+		h := *hours
+		h.UserID = userID
+		if h.TimeZone == "" {
+			h.TimeZone = "UTC"
+		}
+		if _, err := time.LoadLocation(h.TimeZone); err != nil {
+			return ErrBadRequest("timezone must be an IANA time zone, e.g. Europe/Moscow")
+		}
+		if h.StartHour < 0 || h.StartHour > 23 || h.EndHour < 0 || h.EndHour > 23 || h.StartHour == h.EndHour {
+			return ErrBadRequest("start_hour and end_hour must be different hours from 0 to 23")
+		}
+		hours = &h
+	}
+
+	if err := s.repo.SetWorkingHours(ctx, userID, hours); err != nil {
+		if errors.Is(err, repository.ErrUserNotFound) {
+			return ErrNotFound("user not found")
+		}
+		return &AppError{Code: "INTERNAL", Message: "failed to set working hours", Status: 500, Err: err}
+	}
+	return nil
 }
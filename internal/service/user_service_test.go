@@ -3,30 +3,76 @@ package service_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"pull-request-service/internal/model"
+	"pull-request-service/internal/repository"
 	"pull-request-service/internal/service"
 	"pull-request-service/internal/service/mocks"
 )
 
 func TestUserService_SetIsActive(t *testing.T) {
 	tests := []struct {
-		name       string
-		userID     string
-		isActive   bool
-		setupMocks func(ur *mocks.UserRepository)
-		wantErr    bool
+		name              string
+		userID            string
+		isActive          bool
+		setupMocks        func(ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager)
+		wantReassignments []model.ReviewReassignment
+		wantErr           bool
 	}{
 		{
-			name:     "Success",
+			name:     "Success: Deactivation reassigns open reviews",
 			userID:   "u1",
 			isActive: false,
-			setupMocks: func(ur *mocks.UserRepository) {
+			setupMocks: func(ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
 				ur.On("SetIsActive", mock.Anything, "u1", false).
-					Return(model.User{UserID: "u1", IsActive: false}, nil)
+					Return(model.User{UserID: "u1", TeamName: "backend", IsActive: false}, nil)
+
+				pr.On("GetOpenPRsByReviewers", mock.Anything, []string{"u1"}).
+					Return(map[string][]string{"u1": {"pr-1", "pr-2"}}, nil)
+				ur.On("GetByUserID", mock.Anything, "u1").
+					Return(model.User{UserID: "u1", TeamName: "backend"}, nil)
+
+				// pr-1: есть замена в команде
+				pr.On("GetPR", mock.Anything, "pr-1").Return(model.PullRequest{
+					PullRequestID: "pr-1", AuthorID: "u5", AssignedReviewers: []string{"u1"},
+				}, nil)
+				ur.On("ListActiveTeamMembersExcept", mock.Anything, "backend", []string{"u5", "u1", "u1"}).
+					Return([]model.User{{UserID: "u2", TeamName: "backend", IsActive: true}}, nil)
+				pr.On("ReassignReviewer", mock.Anything, "pr-1", "u1", "u2").Return(model.PullRequest{}, nil)
+
+				// pr-2: заменить некем — ревьювер снимается
+				pr.On("GetPR", mock.Anything, "pr-2").Return(model.PullRequest{
+					PullRequestID: "pr-2", AuthorID: "u2", AssignedReviewers: []string{"u1"},
+				}, nil)
+				ur.On("ListActiveTeamMembersExcept", mock.Anything, "backend", []string{"u2", "u1", "u1"}).
+					Return([]model.User{}, nil)
+				ur.On("ListActiveAncestorMembersExcept", mock.Anything, "backend", []string{"u2", "u1", "u1"}).
+					Return([]model.User{}, nil)
+				pr.On("RemoveReviewer", mock.Anything, "pr-2", "u1").Return(nil)
+			},
+			wantReassignments: []model.ReviewReassignment{
+				{PullRequestID: "pr-1", OldReviewerID: "u1", NewReviewerID: "u2", Action: model.ReassignActionReassigned, Reason: model.ReasonTeamMember},
+				{PullRequestID: "pr-2", OldReviewerID: "u1", Action: model.ReassignActionRemoved, Reason: model.ReasonNoCandidate},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "Success: Activation only flips the flag",
+			userID:   "u1",
+			isActive: true,
+			setupMocks: func(ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				tm.On("RunInTransaction", mock.Anything, mock.Anything).Return(func(ctx context.Context, fn func(context.Context) error) error {
+					return fn(ctx)
+				})
+				ur.On("SetIsActive", mock.Anything, "u1", true).
+					Return(model.User{UserID: "u1", IsActive: true}, nil)
 			},
 			wantErr: false,
 		},
@@ -34,6 +80,69 @@ func TestUserService_SetIsActive(t *testing.T) {
 			name:     "Fail: Empty ID",
 			userID:   "",
 			isActive: true,
+			setupMocks: func(ur *mocks.UserRepository, pr *mocks.PRRepository, tm *mocks.TransactionManager) {
+				// Repo не должен вызываться
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ur := new(mocks.UserRepository)
+			pr := new(mocks.PRRepository)
+			tm := new(mocks.TransactionManager)
+			tt.setupMocks(ur, pr, tm)
+
+			svc := service.NewUserService(ur, pr, tm)
+			_, reassignments, err := svc.SetIsActive(context.Background(), tt.userID, tt.isActive)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantReassignments, reassignments)
+			}
+			ur.AssertExpectations(t)
+			pr.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_ListUsers(t *testing.T) {
+	page := []model.User{{UserID: "u1"}, {UserID: "u2"}, {UserID: "u3"}}
+
+	tests := []struct {
+		name       string
+		limit      int
+		cursor     string
+		setupMocks func(ur *mocks.UserRepository)
+		wantLen    int
+		wantNext   string
+		wantErr    bool
+	}{
+		{
+			name:  "Success: Next cursor when more rows exist",
+			limit: 2,
+			setupMocks: func(ur *mocks.UserRepository) {
+				// Репозиторий запрашивается на один элемент больше лимита
+				ur.On("ListUsers", mock.Anything, model.UserFilter{Limit: 3}).Return(page, nil)
+			},
+			wantLen:  2,
+			wantNext: "dTI", // base64("u2")
+		},
+		{
+			name:   "Success: Last page has no cursor",
+			limit:  5,
+			cursor: "dTI",
+			setupMocks: func(ur *mocks.UserRepository) {
+				ur.On("ListUsers", mock.Anything, model.UserFilter{Limit: 6, After: "u2"}).Return(page[2:], nil)
+			},
+			wantLen: 1,
+		},
+		{
+			name:   "Fail: Broken cursor",
+			cursor: "%%%",
 			setupMocks: func(ur *mocks.UserRepository) {
 				// Repo не должен вызываться
 			},
@@ -46,11 +155,270 @@ func TestUserService_SetIsActive(t *testing.T) {
 			ur := new(mocks.UserRepository)
 			tt.setupMocks(ur)
 
-			svc := service.NewUserService(ur)
-			_, err := svc.SetIsActive(context.Background(), tt.userID, tt.isActive)
+			svc := service.NewUserService(ur, new(mocks.PRRepository), new(mocks.TransactionManager))
+			users, next, err := svc.ListUsers(context.Background(), model.UserFilter{Limit: tt.limit}, tt.cursor)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, users, tt.wantLen)
+				assert.Equal(t, tt.wantNext, next)
+			}
+			ur.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_ListActivity(t *testing.T) {
+	changedAt := time.Date(2025, 10, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		userID     string
+		setupMocks func(ur *mocks.UserRepository)
+		wantLen    int
+		wantCode   string
+	}{
+		{
+			name:   "Success",
+			userID: "u1",
+			setupMocks: func(ur *mocks.UserRepository) {
+				ur.On("ListActivityEvents", mock.Anything, "u1").Return([]model.UserActivityEvent{
+					{UserID: "u1", IsActive: true, ChangedAt: changedAt},
+					{UserID: "u1", IsActive: false, ChangedAt: changedAt.Add(-time.Hour)},
+				}, nil)
+			},
+			wantLen: 2,
+		},
+		{
+			name:   "Fail: User not found",
+			userID: "u404",
+			setupMocks: func(ur *mocks.UserRepository) {
+				ur.On("ListActivityEvents", mock.Anything, "u404").Return(nil, repository.ErrUserNotFound)
+			},
+			wantCode: "NOT_FOUND",
+		},
+		{
+			name:   "Fail: Empty ID",
+			userID: "",
+			setupMocks: func(ur *mocks.UserRepository) {
+				// Repo не должен вызываться
+			},
+			wantCode: "BAD_REQUEST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ur := new(mocks.UserRepository)
+			tt.setupMocks(ur)
+
+			svc := service.NewUserService(ur, new(mocks.PRRepository), new(mocks.TransactionManager))
+			events, err := svc.ListActivity(context.Background(), tt.userID)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+				assert.Len(t, events, tt.wantLen)
+			}
+			ur.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_SetChatHandle(t *testing.T) {
+	tests := []struct {
+		name       string
+		userID     string
+		handle     string
+		setupMocks func(ur *mocks.UserRepository)
+		wantCode   string
+	}{
+		{
+			name:   "Success: handle is trimmed",
+			userID: "u1",
+			handle: "  <@U024BE7LH> ",
+			setupMocks: func(ur *mocks.UserRepository) {
+				ur.On("SetChatHandle", mock.Anything, "u1", "<@U024BE7LH>").Return(nil)
+			},
+		},
+		{
+			name:   "Fail: User not found",
+			userID: "u404",
+			handle: "@ghost",
+			setupMocks: func(ur *mocks.UserRepository) {
+				ur.On("SetChatHandle", mock.Anything, "u404", "@ghost").Return(repository.ErrUserNotFound)
+			},
+			wantCode: "NOT_FOUND",
+		},
+		{
+			name:       "Fail: Empty ID",
+			setupMocks: func(ur *mocks.UserRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ur := new(mocks.UserRepository)
+			tt.setupMocks(ur)
+
+			svc := service.NewUserService(ur, new(mocks.PRRepository), new(mocks.TransactionManager))
+			err := svc.SetChatHandle(context.Background(), tt.userID, tt.handle)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+			}
+			ur.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_SetEmailPreferences(t *testing.T) {
+	tests := []struct {
+		name       string
+		email      string
+		mode       model.EmailMode
+		setupMocks func(ur *mocks.UserRepository)
+		wantCode   string
+	}{
+		{
+			name:  "Success: daily digest",
+			email: " bob@example.com ",
+			mode:  model.EmailDaily,
+			setupMocks: func(ur *mocks.UserRepository) {
+				ur.On("SetEmailPreferences", mock.Anything, "u2", "bob@example.com", model.EmailDaily).Return(nil)
+			},
+		},
+		{
+			name: "Success: off without address",
+			mode: model.EmailOff,
+			setupMocks: func(ur *mocks.UserRepository) {
+				ur.On("SetEmailPreferences", mock.Anything, "u2", "", model.EmailOff).Return(nil)
+			},
+		},
+		{
+			name:       "Fail: address required",
+			mode:       model.EmailImmediate,
+			setupMocks: func(ur *mocks.UserRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "Fail: display name is not a plain address",
+			email:      "Bob <bob@example.com>",
+			mode:       model.EmailHourly,
+			setupMocks: func(ur *mocks.UserRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "Fail: unknown mode",
+			email:      "bob@example.com",
+			mode:       "weekly",
+			setupMocks: func(ur *mocks.UserRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:  "Fail: User not found",
+			email: "bob@example.com",
+			mode:  model.EmailImmediate,
+			setupMocks: func(ur *mocks.UserRepository) {
+				ur.On("SetEmailPreferences", mock.Anything, "u2", "bob@example.com", model.EmailImmediate).Return(repository.ErrUserNotFound)
+			},
+			wantCode: "NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ur := new(mocks.UserRepository)
+			tt.setupMocks(ur)
+
+			svc := service.NewUserService(ur, new(mocks.PRRepository), new(mocks.TransactionManager))
+			err := svc.SetEmailPreferences(context.Background(), "u2", tt.email, tt.mode)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+			}
+			ur.AssertExpectations(t)
+		})
+	}
+}
+
+func TestUserService_SetWorkingHours(t *testing.T) {
+	tests := []struct {
+		name       string
+		hours      *model.WorkingHours
+		setupMocks func(ur *mocks.UserRepository)
+		wantCode   string
+	}{
+		{
+			name:  "Success: empty timezone means UTC",
+			hours: &model.WorkingHours{StartHour: 9, EndHour: 18},
+			setupMocks: func(ur *mocks.UserRepository) {
+				ur.On("SetWorkingHours", mock.Anything, "u2",
+					&model.WorkingHours{UserID: "u2", TimeZone: "UTC", StartHour: 9, EndHour: 18}).Return(nil)
+			},
+		},
+		{
+			name:  "Success: night shift",
+			hours: &model.WorkingHours{TimeZone: "Asia/Novosibirsk", StartHour: 22, EndHour: 6},
+			setupMocks: func(ur *mocks.UserRepository) {
+				ur.On("SetWorkingHours", mock.Anything, "u2",
+					&model.WorkingHours{UserID: "u2", TimeZone: "Asia/Novosibirsk", StartHour: 22, EndHour: 6}).Return(nil)
+			},
+		},
+		{
+			name: "Success: clear schedule",
+			setupMocks: func(ur *mocks.UserRepository) {
+				ur.On("SetWorkingHours", mock.Anything, "u2", (*model.WorkingHours)(nil)).Return(nil)
+			},
+		},
+		{
+			name:       "Fail: unknown timezone",
+			hours:      &model.WorkingHours{TimeZone: "Mars/Olympus", StartHour: 9, EndHour: 18},
+			setupMocks: func(ur *mocks.UserRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "Fail: empty shift",
+			hours:      &model.WorkingHours{StartHour: 9, EndHour: 9},
+			setupMocks: func(ur *mocks.UserRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:  "Fail: User not found",
+			hours: &model.WorkingHours{TimeZone: "UTC", StartHour: 9, EndHour: 18},
+			setupMocks: func(ur *mocks.UserRepository) {
+				ur.On("SetWorkingHours", mock.Anything, "u2", mock.Anything).Return(repository.ErrUserNotFound)
+			},
+			wantCode: "NOT_FOUND",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ur := new(mocks.UserRepository)
+			tt.setupMocks(ur)
+
+			svc := service.NewUserService(ur, new(mocks.PRRepository), new(mocks.TransactionManager))
+			err := svc.SetWorkingHours(context.Background(), "u2", tt.hours)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
 			} else {
 				assert.NoError(t, err)
 			}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"net/url"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/repository"
+)
+
+// WebhookRepository описывает контракт хранилища подписок на исходящие вебхуки.
+type WebhookRepository interface {
+	CreateSubscription(ctx context.Context, sub model.WebhookSubscription) (model.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, id int64) error
+	ListDeadLetters(ctx context.Context, limit int) ([]model.WebhookDeadLetter, error)
+	RedeliverDeadLetter(ctx context.Context, id int64) error
+}
+
+// WebhookService управляет подписками на исходящие вебхуки и доставками,
+// исчерпавшими попытки. Сами доставки отправляет outbound.Dispatcher.
+type WebhookService struct {
+	repo WebhookRepository
+}
+
+// NewWebhookService создаёт новый сервис подписок на исходящие вебхуки.
+func NewWebhookService(repo WebhookRepository) *WebhookService {
+	return &WebhookService{repo: repo}
+}
+
+// CreateSubscription валидирует и сохраняет подписку. Если секрет не указан, он генерируется;
+// в ответе секрет возвращается, чтобы подписчик мог проверять подписи доставок.
+// Пустой список event_types означает подписку на все события.
+func (s *WebhookService) CreateSubscription(ctx context.Context, sub model.WebhookSubscription) (model.WebhookSubscription, error) {
+	if !isHTTPURL(sub.URL) {
+		return model.WebhookSubscription{}, ErrBadRequest("url must be an absolute http(s) URL")
+	}
+
+	types := make([]model.EventType, 0, len(sub.EventTypes))
+	seen := make(map[model.EventType]bool, len(sub.EventTypes))
+	for _, t := range sub.EventTypes {
+		if !t.IsValid() {
+			return model.WebhookSubscription{}, ErrBadRequest("unknown event type " + string(t))
+		}
+		if !seen[t] {
+			seen[t] = true
+			types = append(types, t)
+		}
+	}
+	sub.EventTypes = types
+
+	if sub.Secret == "" {
+		var b [32]byte
+		_, _ = rand.Read(b[:])
+		sub.Secret = hex.EncodeToString(b[:])
+	}
+
+	created, err := s.repo.CreateSubscription(ctx, sub)
+	if err != nil {
+		return model.WebhookSubscription{}, &AppError{Code: "INTERNAL", Message: "failed to create subscription", Status: 500, Err: err}
+	}
+	return created, nil
+}
+
+// ListSubscriptions возвращает все подписки без секретов.
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]model.WebhookSubscription, error) {
+	subs, err := s.repo.ListSubscriptions(ctx)
+	if err != nil {
+		return nil, &AppError{Code: "INTERNAL", Message: "failed to list subscriptions", Status: 500, Err: err}
+	}
+	return subs, nil
+}
+
+// DeleteSubscription удаляет подписку; её неотправленные доставки отменяются.
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id int64) error {
+	if err := s.repo.DeleteSubscription(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrSubscriptionNotFound) {
+			return ErrNotFound("subscription not found")
+		}
+		return &AppError{Code: "INTERNAL", Message: "failed to delete subscription", Status: 500, Err: err}
+	}
+	return nil
+}
+
+// ListDeadLetters возвращает последние доставки, исчерпавшие попытки.
+func (s *WebhookService) ListDeadLetters(ctx context.Context, limit int) ([]model.WebhookDeadLetter, error) {
+	limit, err := normalizeLimit(limit)
+	if err != nil {
+		return nil, err
+	}
+	letters, err := s.repo.ListDeadLetters(ctx, limit)
+	if err != nil {
+		return nil, &AppError{Code: "INTERNAL", Message: "failed to list dead letters", Status: 500, Err: err}
+	}
+	return letters, nil
+}
+
+// Redeliver возвращает доставку из dead letters в очередь: она будет отправлена заново
+// с полным набором попыток и тем же id события.
+func (s *WebhookService) Redeliver(ctx context.Context, deadLetterID int64) error {
+	if err := s.repo.RedeliverDeadLetter(ctx, deadLetterID); err != nil {
+		if errors.Is(err, repository.ErrDeadLetterNotFound) {
+			return ErrNotFound("dead letter not found")
+		}
+		return &AppError{Code: "INTERNAL", Message: "failed to redeliver", Status: 500, Err: err}
+	}
+	return nil
+}
+
+// isHTTPURL сообщает, что raw — абсолютный http(s) URL.
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
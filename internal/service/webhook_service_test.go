@@ -0,0 +1,81 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"pull-request-service/internal/model"
+	"pull-request-service/internal/repository"
+	"pull-request-service/internal/service"
+	"pull-request-service/internal/service/mocks"
+)
+
+func TestWebhookService_CreateSubscription(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      model.WebhookSubscription
+		setupMocks func(repo *mocks.WebhookRepository)
+		wantTypes  []model.EventType
+		wantCode   string
+	}{
+		{
+			name:  "Success: Secret is generated and types deduplicated",
+			input: model.WebhookSubscription{URL: "https://hooks.example.com/pr", EventTypes: []model.EventType{"reviewer.assigned", "reviewer.assigned"}},
+			setupMocks: func(repo *mocks.WebhookRepository) {
+				repo.On("CreateSubscription", mock.Anything, mock.MatchedBy(func(s model.WebhookSubscription) bool {
+					return len(s.Secret) == 64
+				})).Return(func(_ context.Context, s model.WebhookSubscription) model.WebhookSubscription {
+					s.ID = 1
+					return s
+				}, nil)
+			},
+			wantTypes: []model.EventType{model.EventReviewerAssigned},
+		},
+		{
+			name:       "Fail: Relative URL",
+			input:      model.WebhookSubscription{URL: "/hooks"},
+			setupMocks: func(repo *mocks.WebhookRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+		{
+			name:       "Fail: Unknown event type",
+			input:      model.WebhookSubscription{URL: "http://hooks", EventTypes: []model.EventType{"pull_request.closed"}},
+			setupMocks: func(repo *mocks.WebhookRepository) {},
+			wantCode:   "BAD_REQUEST",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(mocks.WebhookRepository)
+			tt.setupMocks(repo)
+
+			svc := service.NewWebhookService(repo)
+			sub, err := svc.CreateSubscription(context.Background(), tt.input)
+
+			if tt.wantCode != "" {
+				var appErr *service.AppError
+				assert.ErrorAs(t, err, &appErr)
+				assert.Equal(t, tt.wantCode, appErr.Code)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantTypes, sub.EventTypes)
+				assert.NotEmpty(t, sub.Secret)
+			}
+			repo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestWebhookService_Redeliver(t *testing.T) {
+	repo := new(mocks.WebhookRepository)
+	repo.On("RedeliverDeadLetter", mock.Anything, int64(404)).Return(repository.ErrDeadLetterNotFound)
+
+	err := service.NewWebhookService(repo).Redeliver(context.Background(), 404)
+
+	assert.True(t, service.IsNotFound(err))
+	repo.AssertExpectations(t)
+}
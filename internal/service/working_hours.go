@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"pull-request-service/internal/model"
+)
+
+// inWorkingHours сообщает, приходится ли момент now на рабочие часы h.
+// Неизвестный часовой пояс не должен лишать пользователя ревью, поэтому такой пользователь считается доступным.
+func inWorkingHours(h model.WorkingHours, now time.Time) bool {
+	loc, err := time.LoadLocation(h.TimeZone)
+	if err != nil {
+		return true
+	}
+	hour := now.In(loc).Hour()
+	if h.StartHour < h.EndHour {
+		return hour >= h.StartHour && hour < h.EndHour
+	}
+	return hour >= h.StartHour || hour < h.EndHour
+}
+
+// preferWorkingHours для hotfix-PR ставит вперёд кандидатов, у которых сейчас рабочее время
+// (или расписание не задано), сохраняя порядок внутри обеих групп, и возвращает число таких кандидатов.
+// Для остальных приоритетов, а также когда кандидатов не больше need и выбирать не из чего,
+// список возвращается как есть, а все кандидаты считаются доступными.
+func preferWorkingHours(
+	ctx context.Context,
+	repo UserRepository,
+	priority model.PullRequestPriority,
+	candidates []model.User,
+	need int,
+) ([]model.User, int, error) {
+	if priority != model.PriorityHotfix || len(candidates) <= need {
+		return candidates, len(candidates), nil
+	}
+
+	hours, err := repo.ListWorkingHours(ctx, idsOf(candidates))
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(hours) == 0 {
+		return candidates, len(candidates), nil
+	}
+
+	now := time.Now()
+	away := make(map[string]struct{}, len(hours))
+	for _, h := range hours {
+		if !inWorkingHours(h, now) {
+			away[h.UserID] = struct{}{}
+		}
+	}
+
+	ordered := make([]model.User, 0, len(candidates))
+	var later []model.User
+	for _, u := range candidates {
+		if _, ok := away[u.UserID]; ok {
+			later = append(later, u)
+			continue
+		}
+		ordered = append(ordered, u)
+	}
+	available := len(ordered)
+	return append(ordered, later...), available, nil
+}
+
+// pickAvailable выбирает случайного кандидата, предпочитая первых available из них.
+func pickAvailable(candidates []model.User, available int) model.User {
+	if available == 0 {
+		available = len(candidates)
+	}
+	return candidates[rand.Intn(available)]
+}
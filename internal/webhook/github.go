@@ -0,0 +1,120 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Заголовки вебхуков GitHub.
+const (
+	GitHubEventHeader     = "X-GitHub-Event"
+	GitHubSignatureHeader = "X-Hub-Signature-256"
+	GitHubDeliveryHeader  = "X-GitHub-Delivery"
+)
+
+// GitHub принимает вебхуки GitHub, подписанные секретом Secret.
+type GitHub struct {
+	Secret string
+}
+
+// Name реализует Provider.
+func (GitHub) Name() string { return "github" }
+
+// Verify реализует Provider: проверяет подпись X-Hub-Signature-256.
+func (p GitHub) Verify(header http.Header, body []byte) error {
+	return VerifyGitHubSignature(p.Secret, header.Get(GitHubSignatureHeader), body)
+}
+
+// Parse реализует Provider (см. ParseGitHubEvent).
+func (GitHub) Parse(header http.Header, body []byte) (Event, error) {
+	return ParseGitHubEvent(header.Get(GitHubEventHeader), body)
+}
+
+// DeliveryID реализует Provider.
+func (GitHub) DeliveryID(header http.Header) string { return header.Get(GitHubDeliveryHeader) }
+
+// ErrInvalidSignature возвращается, если подпись вебхука отсутствует или не совпадает.
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// VerifyGitHubSignature проверяет заголовок X-Hub-Signature-256 (`sha256=<hex HMAC тела>`).
+func VerifyGitHubSignature(secret, signature string, body []byte) error {
+	hexSum, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return ErrInvalidSignature
+	}
+	got, err := hex.DecodeString(hexSum)
+	if err != nil {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// githubPullRequestEvent — нужная сервису часть события pull_request.
+type githubPullRequestEvent struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		Draft  bool   `json:"draft"`
+		Merged bool   `json:"merged"`
+		User   struct {
+			Login string `json:"login"`
+		} `json:"user"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// ParseGitHubEvent переводит событие GitHub в Event. pull_request_id строится как
+// `<owner>/<repo>#<номер>`. opened и reopened создают PR (черновики — только после
+// ready_for_review), closed вливает его или, без слияния, закрывает; остальные события и действия игнорируются.
+func ParseGitHubEvent(eventType string, body []byte) (Event, error) {
+	if eventType != "pull_request" {
+		return Event{Action: ActionIgnore, Reason: fmt.Sprintf("unsupported event %q", eventType)}, nil
+	}
+
+	var e githubPullRequestEvent
+	if err := json.Unmarshal(body, &e); err != nil {
+		return Event{}, fmt.Errorf("invalid pull_request payload: %w", err)
+	}
+	pr := e.PullRequest
+	if e.Repository.FullName == "" || pr.Number <= 0 || pr.User.Login == "" {
+		return Event{}, errors.New("pull_request payload lacks repository, number or author")
+	}
+
+	ev := Event{
+		PullRequestID:   fmt.Sprintf("%s#%d", e.Repository.FullName, pr.Number),
+		PullRequestName: pr.Title,
+		AuthorLogin:     pr.User.Login,
+	}
+	switch e.Action {
+	case "opened", "reopened":
+		if pr.Draft {
+			return Event{Action: ActionIgnore, Reason: "draft pull request"}, nil
+		}
+		ev.Action = ActionCreate
+	case "ready_for_review":
+		ev.Action = ActionCreate
+	case "closed":
+		ev.Action = ActionMerge
+		if !pr.Merged {
+			ev.Action = ActionClose
+		}
+	default:
+		return Event{Action: ActionIgnore, Reason: fmt.Sprintf("unsupported action %q", e.Action)}, nil
+	}
+	return ev, nil
+}
@@ -0,0 +1,95 @@
+package webhook_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/webhook"
+)
+
+func TestParseGitHubEvent(t *testing.T) {
+	opened := webhook.Event{
+		Action:          webhook.ActionCreate,
+		PullRequestID:   "octo-org/api#42",
+		PullRequestName: "Add rate limiting to /pullRequest/create",
+		AuthorLogin:     "alice-gh",
+	}
+	merged := opened
+	merged.Action = webhook.ActionMerge
+	closed := opened
+	closed.Action = webhook.ActionClose
+
+	tests := []struct {
+		name      string
+		eventType string
+		fixture   string
+		want      webhook.Event
+	}{
+		{name: "Opened", eventType: "pull_request", fixture: "pull_request_opened.json", want: opened},
+		{name: "Reopened", eventType: "pull_request", fixture: "pull_request_reopened.json", want: opened},
+		{name: "Ready for review", eventType: "pull_request", fixture: "pull_request_ready_for_review.json", want: opened},
+		{name: "Closed with merge", eventType: "pull_request", fixture: "pull_request_closed_merged.json", want: merged},
+		{name: "Closed without merge", eventType: "pull_request", fixture: "pull_request_closed_unmerged.json", want: closed},
+		{
+			name: "Ignored: Draft", eventType: "pull_request", fixture: "pull_request_opened_draft.json",
+			want: webhook.Event{Action: webhook.ActionIgnore, Reason: "draft pull request"},
+		},
+		{
+			name: "Ignored: Other action", eventType: "pull_request", fixture: "pull_request_labeled.json",
+			want: webhook.Event{Action: webhook.ActionIgnore, Reason: `unsupported action "labeled"`},
+		},
+		{
+			name: "Ignored: Ping", eventType: "ping", fixture: "ping.json",
+			want: webhook.Event{Action: webhook.ActionIgnore, Reason: `unsupported event "ping"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("testdata", "github", tt.fixture))
+			require.NoError(t, err)
+
+			ev, err := webhook.ParseGitHubEvent(tt.eventType, body)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, ev)
+		})
+	}
+
+	t.Run("Fail: Payload without repository", func(t *testing.T) {
+		_, err := webhook.ParseGitHubEvent("pull_request", []byte(`{"action":"opened","pull_request":{"number":1}}`))
+		assert.Error(t, err)
+	})
+}
+
+func TestVerifyGitHubSignature(t *testing.T) {
+	body := []byte(`{"action":"opened"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	valid := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.NoError(t, webhook.VerifyGitHubSignature("secret", valid, body))
+	assert.ErrorIs(t, webhook.VerifyGitHubSignature("other", valid, body), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.VerifyGitHubSignature("secret", valid, []byte(`{}`)), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.VerifyGitHubSignature("secret", "", body), webhook.ErrInvalidSignature)
+	assert.ErrorIs(t, webhook.VerifyGitHubSignature("secret", "sha1=abc", body), webhook.ErrInvalidSignature)
+}
+
+func TestLoginMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logins.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("Alice-GH: u1\nbob: u2\n"), 0o600))
+
+	logins, err := webhook.LoadLoginMap(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "u1", logins.UserID("alice-gh"))
+	assert.Equal(t, "u2", logins.UserID("BOB"))
+	assert.Equal(t, "carol", logins.UserID("carol"))
+}
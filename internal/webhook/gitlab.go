@@ -0,0 +1,150 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Заголовки вебхуков GitLab.
+const (
+	GitLabEventHeader    = "X-Gitlab-Event"
+	GitLabTokenHeader    = "X-Gitlab-Token"
+	GitLabDeliveryHeader = "X-Gitlab-Event-UUID"
+)
+
+// ErrInvalidToken возвращается, если секретный токен вебхука GitLab отсутствует или не совпадает.
+var ErrInvalidToken = errors.New("invalid webhook token")
+
+// GitLab принимает вебхуки GitLab с секретным токеном Token.
+type GitLab struct {
+	Token string
+}
+
+// Name реализует Provider.
+func (GitLab) Name() string { return "gitlab" }
+
+// Verify реализует Provider: сравнивает X-Gitlab-Token с ожидаемым токеном.
+func (p GitLab) Verify(header http.Header, _ []byte) error {
+	if subtle.ConstantTimeCompare([]byte(header.Get(GitLabTokenHeader)), []byte(p.Token)) != 1 {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+// Parse реализует Provider (см. ParseGitLabEvent).
+func (GitLab) Parse(header http.Header, body []byte) (Event, error) {
+	return ParseGitLabEvent(header.Get(GitLabEventHeader), body)
+}
+
+// DeliveryID реализует Provider.
+func (GitLab) DeliveryID(header http.Header) string { return header.Get(GitLabDeliveryHeader) }
+
+// gitlabDraftPrefixes — маркеры черновика в заголовке MR (регистр не важен).
+var gitlabDraftPrefixes = []string{"draft:", "[draft]", "(draft)", "wip:", "[wip]"}
+
+// gitlabMergeRequestEvent — нужная сервису часть события Merge Request Hook.
+type gitlabMergeRequestEvent struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		ID       int    `json:"id"`
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	ObjectAttributes struct {
+		IID            int    `json:"iid"`
+		Title          string `json:"title"`
+		Action         string `json:"action"`
+		AuthorID       int    `json:"author_id"`
+		Draft          bool   `json:"draft"`
+		WorkInProgress bool   `json:"work_in_progress"`
+	} `json:"object_attributes"`
+	Changes struct {
+		Draft *struct {
+			Previous bool `json:"previous"`
+			Current  bool `json:"current"`
+		} `json:"draft"`
+		Title *struct {
+			Previous string `json:"previous"`
+			Current  string `json:"current"`
+		} `json:"title"`
+	} `json:"changes"`
+}
+
+// ParseGitLabEvent переводит событие GitLab в Event. pull_request_id строится как
+// `<группа>/<проект>!<iid>`. open и reopen создают MR (черновики — только когда update
+// снимает с них отметку черновика), merge вливает его, close закрывает; прочие действия игнорируются.
+//
+// Автор определяется по логину инициатора события, если им был сам автор, иначе — по числовому
+// author_id, поэтому в LoginMap для GitLab допустимы ключи обоих видов.
+func ParseGitLabEvent(eventType string, body []byte) (Event, error) {
+	if eventType != "Merge Request Hook" {
+		return Event{Action: ActionIgnore, Reason: fmt.Sprintf("unsupported event %q", eventType)}, nil
+	}
+
+	var e gitlabMergeRequestEvent
+	if err := json.Unmarshal(body, &e); err != nil {
+		return Event{}, fmt.Errorf("invalid merge_request payload: %w", err)
+	}
+	mr := e.ObjectAttributes
+	if e.ObjectKind != "merge_request" || e.Project.PathWithNamespace == "" || mr.IID <= 0 || mr.AuthorID <= 0 {
+		return Event{}, errors.New("merge_request payload lacks project, iid or author")
+	}
+
+	ev := Event{
+		PullRequestID:   fmt.Sprintf("%s!%d", e.Project.PathWithNamespace, mr.IID),
+		PullRequestName: mr.Title,
+		AuthorLogin:     strconv.Itoa(mr.AuthorID),
+	}
+	if e.User.ID == mr.AuthorID && e.User.Username != "" {
+		ev.AuthorLogin = e.User.Username
+	}
+
+	switch mr.Action {
+	case "open", "reopen":
+		if mr.Draft || mr.WorkInProgress || gitlabDraftTitle(mr.Title) {
+			return Event{Action: ActionIgnore, Reason: "draft merge request"}, nil
+		}
+		ev.Action = ActionCreate
+	case "update":
+		if !gitlabLeftDraft(e) {
+			return Event{Action: ActionIgnore, Reason: "update does not mark merge request ready"}, nil
+		}
+		ev.Action = ActionCreate
+	case "merge":
+		ev.Action = ActionMerge
+	case "close":
+		ev.Action = ActionClose
+	default:
+		return Event{Action: ActionIgnore, Reason: fmt.Sprintf("unsupported action %q", mr.Action)}, nil
+	}
+	return ev, nil
+}
+
+// gitlabLeftDraft сообщает, что update снял с MR отметку черновика: через поле draft
+// или (в старых версиях GitLab) удалением маркера из заголовка.
+func gitlabLeftDraft(e gitlabMergeRequestEvent) bool {
+	if d := e.Changes.Draft; d != nil {
+		return d.Previous && !d.Current
+	}
+	if t := e.Changes.Title; t != nil {
+		return gitlabDraftTitle(t.Previous) && !gitlabDraftTitle(t.Current)
+	}
+	return false
+}
+
+func gitlabDraftTitle(title string) bool {
+	title = strings.ToLower(strings.TrimSpace(title))
+	for _, p := range gitlabDraftPrefixes {
+		if strings.HasPrefix(title, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package webhook_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"pull-request-service/internal/webhook"
+)
+
+func TestParseGitLabEvent(t *testing.T) {
+	opened := webhook.Event{
+		Action:          webhook.ActionCreate,
+		PullRequestID:   "payments/billing!7",
+		PullRequestName: "Switch invoices to decimal amounts",
+		AuthorLogin:     "dana",
+	}
+	// Событие инициировал не автор — автор известен только по author_id
+	readyByOther := opened
+	readyByOther.AuthorLogin = "317"
+	merged := readyByOther
+	merged.Action = webhook.ActionMerge
+	closed := opened
+	closed.Action = webhook.ActionClose
+
+	tests := []struct {
+		name      string
+		eventType string
+		fixture   string
+		want      webhook.Event
+	}{
+		{name: "Open", eventType: "Merge Request Hook", fixture: "mr_open.json", want: opened},
+		{name: "Update: Draft flag cleared", eventType: "Merge Request Hook", fixture: "mr_update_ready.json", want: readyByOther},
+		{name: "Update: WIP prefix removed", eventType: "Merge Request Hook", fixture: "mr_update_ready_legacy.json", want: opened},
+		{name: "Merge", eventType: "Merge Request Hook", fixture: "mr_merge.json", want: merged},
+		{name: "Close", eventType: "Merge Request Hook", fixture: "mr_close.json", want: closed},
+		{
+			name: "Ignored: Draft", eventType: "Merge Request Hook", fixture: "mr_open_draft.json",
+			want: webhook.Event{Action: webhook.ActionIgnore, Reason: "draft merge request"},
+		},
+		{
+			name: "Ignored: Other update", eventType: "Merge Request Hook", fixture: "mr_update_description.json",
+			want: webhook.Event{Action: webhook.ActionIgnore, Reason: "update does not mark merge request ready"},
+		},
+		{
+			name: "Ignored: Approval", eventType: "Merge Request Hook", fixture: "mr_approved.json",
+			want: webhook.Event{Action: webhook.ActionIgnore, Reason: `unsupported action "approved"`},
+		},
+		{
+			name: "Ignored: Other hook", eventType: "Push Hook", fixture: "mr_open.json",
+			want: webhook.Event{Action: webhook.ActionIgnore, Reason: `unsupported event "Push Hook"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := os.ReadFile(filepath.Join("testdata", "gitlab", tt.fixture))
+			require.NoError(t, err)
+
+			ev, err := webhook.ParseGitLabEvent(tt.eventType, body)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, ev)
+		})
+	}
+}
+
+func TestGitLab_Verify(t *testing.T) {
+	p := webhook.GitLab{Token: "secret"}
+
+	header := http.Header{}
+	assert.ErrorIs(t, p.Verify(header, nil), webhook.ErrInvalidToken)
+
+	header.Set(webhook.GitLabTokenHeader, "wrong")
+	assert.ErrorIs(t, p.Verify(header, nil), webhook.ErrInvalidToken)
+
+	header.Set(webhook.GitLabTokenHeader, "secret")
+	assert.NoError(t, p.Verify(header, nil))
+}
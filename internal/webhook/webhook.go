@@ -0,0 +1,83 @@
+// Package webhook переводит события внешних git-хостингов в операции над PR сервиса.
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Action — операция над PR, к которой сводится внешнее событие.
+type Action string
+
+const (
+	// ActionCreate — PR открыт для ревью: создать его и назначить ревьюверов.
+	ActionCreate Action = "create"
+	// ActionMerge — PR влит.
+	ActionMerge Action = "merge"
+	// ActionClose — PR закрыт без слияния: ревьюверы освобождаются, PR можно открыть снова.
+	ActionClose Action = "close"
+	// ActionIgnore — событие не требует действий (черновик, неподдерживаемое действие и т.п.).
+	ActionIgnore Action = "ignore"
+)
+
+// Event описывает PR из внешнего события в терминах сервиса.
+// Для ActionIgnore заполнена только причина Reason.
+type Event struct {
+	Action          Action
+	PullRequestID   string
+	PullRequestName string
+	AuthorLogin     string
+	Reason          string
+}
+
+// Provider переводит вебхуки одного git-хостинга в Event. Общая часть — проверка
+// идемпотентности и вызовы сервиса PR — не зависит от хостинга, поэтому новый хостинг
+// подключается только реализацией этого интерфейса.
+type Provider interface {
+	// Name — имя хостинга, вебхук принимается на /webhooks/<Name>.
+	Name() string
+	// Verify проверяет подлинность запроса по заголовкам и телу.
+	Verify(header http.Header, body []byte) error
+	// Parse переводит событие в Event; неинтересные сервису события возвращаются с ActionIgnore.
+	Parse(header http.Header, body []byte) (Event, error)
+	// DeliveryID возвращает идентификатор доставки для журнала.
+	DeliveryID(header http.Header) string
+}
+
+// LoginMap сопоставляет логины git-хостинга с user_id сервиса. Логины сравниваются без учёта регистра.
+type LoginMap map[string]string
+
+// UserID возвращает user_id для логина. Логины без сопоставления возвращаются как есть: если логин
+// не совпадает с user_id сервиса, вызывающий игнорирует событие.
+func (m LoginMap) UserID(login string) string {
+	if id, ok := m[strings.ToLower(login)]; ok {
+		return id
+	}
+	return login
+}
+
+// LoadLoginMap читает сопоставление логинов из YAML- или JSON-файла вида `login: user_id`.
+func LoadLoginMap(path string) (LoginMap, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read login map: %w", err)
+	}
+
+	var raw map[string]string
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse login map %s: %w", path, err)
+	}
+
+	m := make(LoginMap, len(raw))
+	for login, userID := range raw {
+		if login == "" || userID == "" {
+			return nil, fmt.Errorf("login map %s: empty login or user_id", path)
+		}
+		m[strings.ToLower(login)] = userID
+	}
+	return m, nil
+}
@@ -0,0 +1,7 @@
+-- Порядок значений важен: ORDER BY priority DESC ставит hotfix первым.
+CREATE TYPE pr_priority AS ENUM ('low', 'normal', 'high', 'hotfix');
+
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS priority pr_priority NOT NULL DEFAULT 'normal';
+
+CREATE INDEX IF NOT EXISTS idx_pr_priority_created ON pull_requests(priority DESC, created_at);
@@ -0,0 +1,3 @@
+-- Пользователь, исключённый из команды через /team/removeMembers, остаётся в системе
+-- (на него ссылаются PR и история ревью), но больше не принадлежит ни одной команде.
+ALTER TABLE users ALTER COLUMN team_id DROP NOT NULL;
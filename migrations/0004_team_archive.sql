@@ -0,0 +1,2 @@
+-- Архивная команда скрыта из списков, но её история (участники, PR) сохраняется.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ NULL;
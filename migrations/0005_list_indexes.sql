@@ -0,0 +1,5 @@
+-- Поиск пользователей по префиксу username без учёта регистра (GET /users?username_prefix=...).
+CREATE INDEX IF NOT EXISTS idx_users_username_lower_prefix ON users (lower(username) text_pattern_ops);
+
+-- Список неархивных команд (GET /teams) с keyset-пагинацией по team_name.
+CREATE INDEX IF NOT EXISTS idx_teams_active_name ON teams (team_name) WHERE archived_at IS NULL;
@@ -0,0 +1,8 @@
+-- Поиск PR по подстроке названия (GET /pullRequests?name=...).
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX IF NOT EXISTS idx_pr_name_trgm ON pull_requests USING gin (pull_request_name gin_trgm_ops);
+
+-- Keyset-пагинация списка PR по дате создания и по названию.
+CREATE INDEX IF NOT EXISTS idx_pr_created_id ON pull_requests (created_at, pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_pr_name_id ON pull_requests (pull_request_name, pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_pr_merged_at ON pull_requests (merged_at) WHERE merged_at IS NOT NULL;
@@ -0,0 +1,13 @@
+-- Состояние ревью каждого назначенного ревьювера и время назначения.
+CREATE TYPE review_state AS ENUM ('PENDING', 'APPROVED', 'CHANGES_REQUESTED');
+
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN IF NOT EXISTS state       review_state NOT NULL DEFAULT 'PENDING',
+    ADD COLUMN IF NOT EXISTS assigned_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMPTZ  NULL;
+
+-- Для уже существующих назначений лучшая оценка времени назначения — создание PR.
+UPDATE pull_request_reviewers r
+SET assigned_at = pr.created_at
+FROM pull_requests pr
+WHERE pr.pull_request_id = r.pull_request_id;
@@ -0,0 +1,8 @@
+-- Иерархия команд: отдел может содержать команды, команда — подкоманды.
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS parent_team_id BIGINT NULL REFERENCES teams(id) ON DELETE RESTRICT;
+
+ALTER TABLE teams
+    ADD CONSTRAINT teams_parent_not_self CHECK (parent_team_id IS NULL OR parent_team_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_teams_parent ON teams(parent_team_id);
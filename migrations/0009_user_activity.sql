@@ -0,0 +1,25 @@
+-- Журнал активаций и деактиваций пользователей: нужен для аудита и для плавного
+-- возвращения ревьюверов после отсутствия. Заполняется триггером, поэтому фиксируются
+-- изменения is_active из любых запросов (setIsActive, массовая деактивация, архивация, импорт).
+CREATE TABLE IF NOT EXISTS user_activity_events (
+    id         BIGSERIAL PRIMARY KEY,
+    user_id    TEXT        NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    is_active  BOOLEAN     NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_activity_user_changed
+    ON user_activity_events(user_id, changed_at DESC, id DESC);
+
+CREATE OR REPLACE FUNCTION log_user_activity() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO user_activity_events (user_id, is_active) VALUES (NEW.user_id, NEW.is_active);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_users_activity
+    AFTER UPDATE OF is_active ON users
+    FOR EACH ROW
+    WHEN (OLD.is_active IS DISTINCT FROM NEW.is_active)
+    EXECUTE FUNCTION log_user_activity();
@@ -0,0 +1,37 @@
+-- Исходящие вебхуки: подписки, очередь доставок и доставки, исчерпавшие попытки.
+-- Доставки вставляются в той же транзакции, что и изменение PR, поэтому событие
+-- не теряется при падении сервиса и не уходит подписчикам, если транзакция откатилась.
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id          BIGSERIAL PRIMARY KEY,
+    url         TEXT        NOT NULL,
+    secret      TEXT        NOT NULL,
+    event_types TEXT[]      NOT NULL DEFAULT '{}',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id              BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT      NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id        TEXT        NOT NULL,
+    event_type      TEXT        NOT NULL,
+    payload         JSONB       NOT NULL,
+    attempts        INT         NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error      TEXT        NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due
+    ON webhook_deliveries(next_attempt_at, id);
+
+CREATE TABLE IF NOT EXISTS webhook_dead_letters (
+    id              BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT      NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_id        TEXT        NOT NULL,
+    event_type      TEXT        NOT NULL,
+    payload         JSONB       NOT NULL,
+    attempts        INT         NOT NULL,
+    last_error      TEXT        NOT NULL,
+    created_at      TIMESTAMPTZ NOT NULL,
+    failed_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
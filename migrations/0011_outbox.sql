@@ -0,0 +1,14 @@
+-- Transactional outbox: доменные события пишутся в той же транзакции, что и изменение,
+-- а фоновый relay публикует их в sinks по порядку seq и помечает published_at.
+-- Исходящие вебхуки получают доставки из этой таблицы, а не напрямую из бизнес-транзакций.
+CREATE TABLE IF NOT EXISTS outbox (
+    seq          BIGSERIAL PRIMARY KEY,
+    event_id     TEXT        NOT NULL UNIQUE,
+    event_type   TEXT        NOT NULL,
+    payload      JSONB       NOT NULL,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    published_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_pending
+    ON outbox(seq) WHERE published_at IS NULL;
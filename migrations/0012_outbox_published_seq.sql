@@ -0,0 +1,7 @@
+-- Порядковый номер публикации события. Назначается relay, который публикует события
+-- строго по одному экземпляру за раз, поэтому номера растут в порядке фиксации транзакций
+-- и служат курсором для потоков событий (SSE Last-Event-ID): новые события не могут
+-- появиться позади уже прочитанного номера.
+CREATE SEQUENCE IF NOT EXISTS outbox_published_seq;
+
+ALTER TABLE outbox ADD COLUMN IF NOT EXISTS published_seq BIGINT UNIQUE;
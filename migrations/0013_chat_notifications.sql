@@ -0,0 +1,19 @@
+-- Уведомления в чат (Slack/Mattermost incoming webhooks): канал команды, упоминание
+-- пользователя и очередь сообщений. Сообщения ставятся в очередь relay outbox в одной
+-- транзакции с пометкой событий опубликованными и отправляются фоновым notifier.
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS chat_webhook_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE users ADD COLUMN IF NOT EXISTS chat_handle TEXT NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS chat_notifications (
+    id              BIGSERIAL PRIMARY KEY,
+    webhook_url     TEXT        NOT NULL,
+    text            TEXT        NOT NULL,
+    attempts        INT         NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error      TEXT        NOT NULL DEFAULT '',
+    failed_at       TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_chat_notifications_due
+    ON chat_notifications(next_attempt_at, id) WHERE failed_at IS NULL;
@@ -0,0 +1,28 @@
+-- Уведомления по почте: адрес и режим рассылки пользователя и очередь писем.
+-- immediate — письмо на каждое назначение, hourly/daily — сводка ожидающих ревью
+-- не чаще раза в час/сутки; email_digest_at — время последней сводки.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS email           TEXT        NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS email_mode      TEXT        NOT NULL DEFAULT 'off',
+    ADD COLUMN IF NOT EXISTS email_digest_at TIMESTAMPTZ NULL;
+
+ALTER TABLE users
+    ADD CONSTRAINT users_email_mode_check CHECK (email_mode IN ('off', 'immediate', 'hourly', 'daily'));
+
+CREATE INDEX IF NOT EXISTS idx_users_email_digest
+    ON users(email_mode, email_digest_at) WHERE email_mode IN ('hourly', 'daily');
+
+CREATE TABLE IF NOT EXISTS email_notifications (
+    id              BIGSERIAL PRIMARY KEY,
+    recipient       TEXT        NOT NULL,
+    subject         TEXT        NOT NULL,
+    body            TEXT        NOT NULL,
+    attempts        INT         NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error      TEXT        NOT NULL DEFAULT '',
+    failed_at       TIMESTAMPTZ,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_email_notifications_due
+    ON email_notifications(next_attempt_at, id) WHERE failed_at IS NULL;
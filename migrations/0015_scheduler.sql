@@ -0,0 +1,19 @@
+-- Планировщик задач: история запусков и руководители команд, получающие сводку
+-- по зависшим PR. Уникальность (job_name, scheduled_at) не даёт выполнить одно
+-- срабатывание дважды при смене лидера.
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS lead_user_id TEXT NULL REFERENCES users(user_id) ON DELETE SET NULL;
+
+CREATE TABLE IF NOT EXISTS scheduler_runs (
+    id           BIGSERIAL PRIMARY KEY,
+    job_name     TEXT        NOT NULL,
+    scheduled_at TIMESTAMPTZ NOT NULL,
+    started_at   TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at  TIMESTAMPTZ NULL,
+    status       TEXT        NOT NULL DEFAULT 'running',
+    summary      TEXT        NOT NULL DEFAULT '',
+    error        TEXT        NOT NULL DEFAULT '',
+    UNIQUE (job_name, scheduled_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_scheduler_runs_started ON scheduler_runs(started_at DESC, id DESC);
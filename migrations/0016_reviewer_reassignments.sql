@@ -0,0 +1,36 @@
+-- Журнал снятых с PR ревьюверов: замена при переназначении (replaced_by — новый ревьювер)
+-- или снятие без замены (replaced_by IS NULL). Нужен статистике: строка в pull_request_reviewers
+-- при переназначении переходит к новому ревьюверу, и без журнала назначение прежнего теряется.
+-- Заполняется триггерами, поэтому учитываются все пути (reassign, деактивация, исключение из команды).
+CREATE TABLE IF NOT EXISTS reviewer_reassignments (
+    id              BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT        NOT NULL,
+    reviewer_id     TEXT        NOT NULL,
+    replaced_by     TEXT        NULL,
+    assigned_at     TIMESTAMPTZ NOT NULL,
+    reassigned_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_reassignments_reviewer
+    ON reviewer_reassignments(reviewer_id, assigned_at);
+
+CREATE OR REPLACE FUNCTION log_reviewer_reassignment() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO reviewer_reassignments (pull_request_id, reviewer_id, replaced_by, assigned_at)
+    VALUES (OLD.pull_request_id, OLD.reviewer_id,
+            CASE WHEN TG_OP = 'UPDATE' THEN NEW.reviewer_id END,
+            OLD.assigned_at);
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_reviewers_replaced
+    AFTER UPDATE OF reviewer_id ON pull_request_reviewers
+    FOR EACH ROW
+    WHEN (OLD.reviewer_id IS DISTINCT FROM NEW.reviewer_id)
+    EXECUTE FUNCTION log_reviewer_reassignment();
+
+CREATE TRIGGER trg_reviewers_removed
+    AFTER DELETE ON pull_request_reviewers
+    FOR EACH ROW
+    EXECUTE FUNCTION log_reviewer_reassignment();
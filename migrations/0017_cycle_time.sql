@@ -0,0 +1,79 @@
+-- Аналитика времени цикла ревью. Представление cycle_time_samples даёт по одному замеру
+-- на каждое завершённое событие:
+--   first_review — до первого ревью (не PENDING), approval — до первого одобрения, merge — до слияния.
+-- Для автора и его текущей команды отсчёт идёт от создания PR, для ревьювера — от его назначения.
+-- done_at — момент события, по нему замеры попадают в окна и недели.
+-- Процентили дорого считать на каждый запрос, поэтому фоновая задача периодически
+-- пересчитывает rollup-таблицы: по скользящим окнам (в днях) и по неделям для трендов.
+CREATE OR REPLACE VIEW cycle_time_samples AS
+WITH pr_facts AS (
+    SELECT pr.pull_request_id,
+           pr.author_id,
+           t.team_name,
+           pr.created_at,
+           pr.merged_at,
+           (SELECT min(r.reviewed_at) FROM pull_request_reviewers r
+            WHERE r.pull_request_id = pr.pull_request_id AND r.state <> 'PENDING') AS first_review_at,
+           (SELECT min(r.reviewed_at) FROM pull_request_reviewers r
+            WHERE r.pull_request_id = pr.pull_request_id AND r.state = 'APPROVED') AS approved_at
+    FROM pull_requests pr
+    JOIN users a ON a.user_id = pr.author_id
+    LEFT JOIN teams t ON t.id = a.team_id
+),
+pr_samples AS (
+    SELECT author_id, team_name, 'first_review' AS metric, first_review_at AS done_at, first_review_at - created_at AS took
+    FROM pr_facts WHERE first_review_at IS NOT NULL
+    UNION ALL
+    SELECT author_id, team_name, 'approval', approved_at, approved_at - created_at
+    FROM pr_facts WHERE approved_at IS NOT NULL
+    UNION ALL
+    SELECT author_id, team_name, 'merge', merged_at, merged_at - created_at
+    FROM pr_facts WHERE merged_at IS NOT NULL
+)
+SELECT 'author' AS dimension, author_id AS subject, metric, done_at,
+       GREATEST(EXTRACT(EPOCH FROM took), 0)::float8 AS seconds
+FROM pr_samples
+UNION ALL
+SELECT 'team', team_name, metric, done_at, GREATEST(EXTRACT(EPOCH FROM took), 0)::float8
+FROM pr_samples WHERE team_name IS NOT NULL
+UNION ALL
+SELECT 'reviewer', r.reviewer_id, 'first_review', r.reviewed_at,
+       GREATEST(EXTRACT(EPOCH FROM r.reviewed_at - r.assigned_at), 0)::float8
+FROM pull_request_reviewers r
+WHERE r.state <> 'PENDING' AND r.reviewed_at IS NOT NULL
+UNION ALL
+SELECT 'reviewer', r.reviewer_id, 'approval', r.reviewed_at,
+       GREATEST(EXTRACT(EPOCH FROM r.reviewed_at - r.assigned_at), 0)::float8
+FROM pull_request_reviewers r
+WHERE r.state = 'APPROVED' AND r.reviewed_at IS NOT NULL
+UNION ALL
+SELECT 'reviewer', r.reviewer_id, 'merge', pr.merged_at,
+       GREATEST(EXTRACT(EPOCH FROM pr.merged_at - r.assigned_at), 0)::float8
+FROM pull_request_reviewers r
+JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+WHERE pr.merged_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS cycle_time_window_rollups (
+    window_days  INT         NOT NULL,
+    dimension    TEXT        NOT NULL,
+    subject      TEXT        NOT NULL,
+    metric       TEXT        NOT NULL,
+    samples      INT         NOT NULL,
+    p50_seconds  FLOAT8      NOT NULL,
+    p90_seconds  FLOAT8      NOT NULL,
+    p99_seconds  FLOAT8      NOT NULL,
+    refreshed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (window_days, dimension, subject, metric)
+);
+
+CREATE TABLE IF NOT EXISTS cycle_time_weekly_rollups (
+    week_start   DATE        NOT NULL,
+    dimension    TEXT        NOT NULL,
+    subject      TEXT        NOT NULL,
+    metric       TEXT        NOT NULL,
+    samples      INT         NOT NULL,
+    p50_seconds  FLOAT8      NOT NULL,
+    p90_seconds  FLOAT8      NOT NULL,
+    p99_seconds  FLOAT8      NOT NULL,
+    PRIMARY KEY (dimension, subject, week_start, metric)
+);
@@ -0,0 +1,11 @@
+-- Курсоры внешних sinks outbox (HTTP, файл, лог). Такие sinks читают уже опубликованные события
+-- по published_seq вне транзакции relay, каждый со своей позицией и расписанием повторов,
+-- поэтому медленный или недоступный получатель не задерживает публикацию и остальные sinks.
+CREATE TABLE IF NOT EXISTS outbox_sink_cursors (
+    sink            TEXT PRIMARY KEY,
+    last_seq        BIGINT      NOT NULL,
+    attempts        INT         NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    last_error      TEXT        NOT NULL DEFAULT '',
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
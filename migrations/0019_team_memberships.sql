@@ -0,0 +1,48 @@
+-- Интервалы членства в командах: с какого и по какой момент пользователь состоял в команде.
+-- После /team/removeMembers users.team_id обнуляется, и только по этим интервалам видно, что PR,
+-- написанные или отревьюенные бывшими участниками, относятся к команде — такую команду нельзя удалить,
+-- её можно только архивировать. Интервал открывается при вступлении и закрывается при уходе
+-- по изменению users.team_id, какой бы операцией оно ни было сделано.
+CREATE TABLE IF NOT EXISTS team_memberships (
+    id        BIGSERIAL PRIMARY KEY,
+    team_id   BIGINT      NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    user_id   TEXT        NOT NULL REFERENCES users(user_id) ON DELETE CASCADE,
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    left_at   TIMESTAMPTZ NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_memberships_team ON team_memberships(team_id);
+CREATE INDEX IF NOT EXISTS idx_team_memberships_current
+    ON team_memberships(user_id, team_id) WHERE left_at IS NULL;
+
+-- Время вступления нынешних участников неизвестно: считаем, что они в команде с самого начала.
+INSERT INTO team_memberships (team_id, user_id, joined_at)
+SELECT u.team_id, u.user_id, '-infinity'
+FROM users u
+WHERE u.team_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM team_memberships m WHERE m.user_id = u.user_id AND m.left_at IS NULL);
+
+CREATE OR REPLACE FUNCTION log_team_membership() RETURNS trigger AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND OLD.team_id IS NOT NULL THEN
+        UPDATE team_memberships SET left_at = now()
+        WHERE user_id = OLD.user_id AND team_id = OLD.team_id AND left_at IS NULL;
+    END IF;
+    IF NEW.team_id IS NOT NULL THEN
+        INSERT INTO team_memberships (team_id, user_id) VALUES (NEW.team_id, NEW.user_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER trg_users_team_joined
+    AFTER INSERT ON users
+    FOR EACH ROW
+    WHEN (NEW.team_id IS NOT NULL)
+    EXECUTE FUNCTION log_team_membership();
+
+CREATE TRIGGER trg_users_team_changed
+    AFTER UPDATE OF team_id ON users
+    FOR EACH ROW
+    WHEN (OLD.team_id IS DISTINCT FROM NEW.team_id)
+    EXECUTE FUNCTION log_team_membership();
@@ -0,0 +1,3 @@
+-- PR, закрытый на хостинге без слияния. Ревьюверы остаются в pull_request_reviewers как история,
+-- но в нагрузку и напоминания закрытый PR не попадает: там учитываются только OPEN.
+ALTER TYPE pr_status ADD VALUE IF NOT EXISTS 'CLOSED';
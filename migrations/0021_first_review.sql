@@ -0,0 +1,61 @@
+-- reviewed_at меняется при каждой смене состояния ревью, поэтому по нему нельзя восстановить
+-- первое ревью и первое одобрение: повторное ревью сдвигало бы замеры cycle_time_samples.
+-- Моменты первого ревью и первого одобрения сохраняются отдельно и больше не меняются
+-- (сбрасываются только вместе с назначением при замене ревьювера).
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN IF NOT EXISTS first_reviewed_at TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS first_approved_at TIMESTAMPTZ NULL;
+
+-- Для прежних ревью точнее последнего reviewed_at оценки нет.
+UPDATE pull_request_reviewers
+SET first_reviewed_at = reviewed_at,
+    first_approved_at = CASE WHEN state = 'APPROVED' THEN reviewed_at END
+WHERE state <> 'PENDING' AND reviewed_at IS NOT NULL;
+
+CREATE OR REPLACE VIEW cycle_time_samples AS
+WITH pr_facts AS (
+    SELECT pr.pull_request_id,
+           pr.author_id,
+           t.team_name,
+           pr.created_at,
+           pr.merged_at,
+           (SELECT min(r.first_reviewed_at) FROM pull_request_reviewers r
+            WHERE r.pull_request_id = pr.pull_request_id) AS first_review_at,
+           (SELECT min(r.first_approved_at) FROM pull_request_reviewers r
+            WHERE r.pull_request_id = pr.pull_request_id) AS approved_at
+    FROM pull_requests pr
+    JOIN users a ON a.user_id = pr.author_id
+    LEFT JOIN teams t ON t.id = a.team_id
+),
+pr_samples AS (
+    SELECT author_id, team_name, 'first_review' AS metric, first_review_at AS done_at, first_review_at - created_at AS took
+    FROM pr_facts WHERE first_review_at IS NOT NULL
+    UNION ALL
+    SELECT author_id, team_name, 'approval', approved_at, approved_at - created_at
+    FROM pr_facts WHERE approved_at IS NOT NULL
+    UNION ALL
+    SELECT author_id, team_name, 'merge', merged_at, merged_at - created_at
+    FROM pr_facts WHERE merged_at IS NOT NULL
+)
+SELECT 'author' AS dimension, author_id AS subject, metric, done_at,
+       GREATEST(EXTRACT(EPOCH FROM took), 0)::float8 AS seconds
+FROM pr_samples
+UNION ALL
+SELECT 'team', team_name, metric, done_at, GREATEST(EXTRACT(EPOCH FROM took), 0)::float8
+FROM pr_samples WHERE team_name IS NOT NULL
+UNION ALL
+SELECT 'reviewer', r.reviewer_id, 'first_review', r.first_reviewed_at,
+       GREATEST(EXTRACT(EPOCH FROM r.first_reviewed_at - r.assigned_at), 0)::float8
+FROM pull_request_reviewers r
+WHERE r.first_reviewed_at IS NOT NULL
+UNION ALL
+SELECT 'reviewer', r.reviewer_id, 'approval', r.first_approved_at,
+       GREATEST(EXTRACT(EPOCH FROM r.first_approved_at - r.assigned_at), 0)::float8
+FROM pull_request_reviewers r
+WHERE r.first_approved_at IS NOT NULL
+UNION ALL
+SELECT 'reviewer', r.reviewer_id, 'merge', pr.merged_at,
+       GREATEST(EXTRACT(EPOCH FROM pr.merged_at - r.assigned_at), 0)::float8
+FROM pull_request_reviewers r
+JOIN pull_requests pr ON pr.pull_request_id = r.pull_request_id
+WHERE pr.merged_at IS NOT NULL;
@@ -0,0 +1,14 @@
+-- Рабочие часы пользователя: hotfix-PR назначаются в первую очередь тем, у кого сейчас рабочее время.
+-- Часы — в часовом поясе work_timezone, work_end_hour не включается; work_start_hour > work_end_hour
+-- означает ночную смену. NULL в обоих полях — расписание не задано, пользователь доступен всегда.
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS work_timezone   TEXT     NOT NULL DEFAULT 'UTC',
+    ADD COLUMN IF NOT EXISTS work_start_hour SMALLINT NULL,
+    ADD COLUMN IF NOT EXISTS work_end_hour   SMALLINT NULL;
+
+ALTER TABLE users
+    ADD CONSTRAINT users_work_hours_check CHECK (
+        (work_start_hour IS NULL AND work_end_hour IS NULL)
+        OR (work_start_hour BETWEEN 0 AND 23 AND work_end_hour BETWEEN 0 AND 23
+            AND work_start_hour <> work_end_hour)
+    );